@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_GiteaComplex" "example" {
+  resource_name = "example-giteacomplex"
+}
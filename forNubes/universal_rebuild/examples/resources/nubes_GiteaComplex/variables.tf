@@ -0,0 +1,5 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
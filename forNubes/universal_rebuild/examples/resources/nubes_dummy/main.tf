@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_dummy" "example" {
+  resource_name    = "example-dummy"
+  duration_ms      = 0
+  fail_at_start    = false
+  fail_in_progress = false
+  resource_realm   = var.resource_realm
+
+  # Optional parameters with their defaults:
+  # where_fail = 1
+  # bodymessage = "" # no default
+  # map_example = "" # no default
+  # json_example = "" # no default
+  # nested_ref_example = "" # no default
+  # yaml_example = "" # no default
+  # map_fixed = "" # no default
+  # array_map_fixed_example = "" # no default
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_flask" "example" {
+  resource_name      = "example-flask"
+  domain             = "web01"
+  resource_realm     = var.resource_realm
+  resource_cpu       = 300
+  resource_memory    = 256
+  resource_instances = 1
+  git_path           = "https://github.com/Foxyhhd/Baldurs-Gate-test.git"
+
+  # Optional parameters with their defaults:
+  # json_env = "{ &quot;param&quot;: &quot;value&quot; }"
+  # health_path = "" # no default
+}
@@ -0,0 +1,3 @@
+output "id" {
+  value = nubes_gitea.example.id
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_kafka" "example" {
+  resource_name                = "example-kafka"
+  resource_instances           = var.resource_instances
+  resource_memory              = var.resource_memory
+  resource_cpu                 = var.resource_cpu
+  resource_disk                = var.resource_disk
+  need_external_address_master = false
+  resource_realm               = var.resource_realm
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_lucee" "example" {
+  resource_name      = "example-lucee"
+  domain             = "web01"
+  git_path           = "https://github.com/xahys/testlucee"
+  resource_cpu       = 300
+  resource_memory    = 512
+  resource_realm     = var.resource_realm
+  resource_instances = 1
+  app_version        = "5.4"
+
+  # Optional parameters with their defaults:
+  # json_env = "{ \"param\": \"value\" }"
+  # health_path = "" # no default
+}
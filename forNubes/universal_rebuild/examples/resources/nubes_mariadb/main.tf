@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_s3" "example" {
+  resource_name  = "example-s3"
+  resource_realm = var.s3_resource_realm
+  display_name   = var.s3_display_name
+
+  # Optional parameters with their defaults:
+  # max_size_gb_per_user = 0 # no default
+  # max_objects_per_bucket = 0 # no default
+  # max_buckets_per_user = 0 # no default
+}
+
+resource "nubes_mariadb" "example" {
+  resource_name                = "example-mariadb"
+  resource_realm               = var.resource_realm
+  resource_cpu                 = 500
+  resource_memory              = 1024
+  resource_disk                = 1
+  resource_instances           = 1
+  need_external_address_master = false
+  app_version                  = "9.4.0"
+  auto_scale                   = false
+  s3_uid                       = nubes_s3.example.id
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+  # ext_backup_schedule = "0 * * * *"
+  # auto_scale_percentage = 10
+  # auto_scale_tech_window = 0
+  # auto_scale_quota_gb = 1
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_mongodb" "example" {
+  resource_name                = "example-mongodb"
+  resource_instances           = var.resource_instances
+  resource_memory              = var.resource_memory
+  resource_cpu                 = var.resource_cpu
+  resource_disk                = var.resource_disk
+  resource_realm               = var.resource_realm
+  need_external_address_master = "false"
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+}
@@ -0,0 +1,30 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "resource_instances" {
+  type        = number
+  description = "Value of resource_instances."
+}
+
+variable "resource_memory" {
+  type        = number
+  description = "Value of resource_memory."
+}
+
+variable "resource_cpu" {
+  type        = number
+  description = "Value of resource_cpu."
+}
+
+variable "resource_disk" {
+  type        = number
+  description = "Value of resource_disk."
+}
+
+variable "resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
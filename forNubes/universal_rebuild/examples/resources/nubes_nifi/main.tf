@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_kafka" "example" {
+  resource_name                = "example-kafka"
+  resource_instances           = var.kafka_resource_instances
+  resource_memory              = var.kafka_resource_memory
+  resource_cpu                 = var.kafka_resource_cpu
+  resource_disk                = var.kafka_resource_disk
+  need_external_address_master = false
+  resource_realm               = var.kafka_resource_realm
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+}
+
+resource "nubes_nifi" "example" {
+  resource_name = "example-nifi"
+  kafka_uid     = nubes_kafka.example.id
+  partitions    = var.partitions
+  replicas      = var.replicas
+  name_topic    = var.name_topic
+}
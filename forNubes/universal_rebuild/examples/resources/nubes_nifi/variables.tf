@@ -0,0 +1,45 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "kafka_resource_instances" {
+  type        = number
+  description = "Value of resource_instances."
+}
+
+variable "kafka_resource_memory" {
+  type        = number
+  description = "Value of resource_memory."
+}
+
+variable "kafka_resource_cpu" {
+  type        = number
+  description = "Value of resource_cpu."
+}
+
+variable "kafka_resource_disk" {
+  type        = number
+  description = "Value of resource_disk."
+}
+
+variable "kafka_resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
+
+variable "partitions" {
+  type        = number
+  description = "Value of partitions."
+}
+
+variable "replicas" {
+  type        = number
+  description = "Value of replicas."
+}
+
+variable "name_topic" {
+  type        = string
+  description = "Value of name_topic."
+}
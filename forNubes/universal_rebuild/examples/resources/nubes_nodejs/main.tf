@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_nodejs" "example" {
+  resource_name      = "example-nodejs"
+  domain             = "web01"
+  git_path           = "https://github.com/xahys/testnode.git"
+  resource_cpu       = 500
+  resource_memory    = 1024
+  resource_instances = 1
+  resource_realm     = var.resource_realm
+  app_version        = "23"
+
+  # Optional parameters with their defaults:
+  # health_path = "" # no default
+  # json_env = "" # no default
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_nodered" "example" {
+  resource_name      = "example-nodered"
+  resource_cpu       = 500
+  resource_memory    = 512
+  resource_disk      = 1
+  resource_realm     = var.resource_realm
+  domain             = var.domain
+  resource_instances = 1
+}
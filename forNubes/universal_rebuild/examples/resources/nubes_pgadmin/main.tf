@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_pgadmin" "example" {
+  resource_name   = "example-pgadmin"
+  domain          = "web01"
+  resource_cpu    = 200
+  resource_memory = 256
+  resource_disk   = 1
+  resource_realm  = var.resource_realm
+  login           = var.login
+  password        = var.password
+}
@@ -0,0 +1,21 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
+
+variable "login" {
+  type        = string
+  description = "Value of login."
+}
+
+variable "password" {
+  type        = string
+  description = "Value of password."
+  sensitive   = true
+}
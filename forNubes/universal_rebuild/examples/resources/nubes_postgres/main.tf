@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_s3" "example" {
+  resource_name  = "example-s3"
+  resource_realm = var.s3_resource_realm
+  display_name   = var.s3_display_name
+
+  # Optional parameters with their defaults:
+  # max_size_gb_per_user = 0 # no default
+  # max_objects_per_bucket = 0 # no default
+  # max_buckets_per_user = 0 # no default
+}
+
+resource "nubes_postgres" "example" {
+  resource_name           = "example-postgres"
+  s3_uid                  = nubes_s3.example.id
+  resource_instances      = 1
+  resource_memory         = 512
+  resource_cpu            = 500
+  resource_disk           = "10"
+  resource_realm          = var.resource_realm
+  app_version             = "17"
+  json_parameters         = "{ \"log_connections\": \"off\", \"log_disconnections\": \"off\" }"
+  enable_pg_pooler_master = false
+  enable_pg_pooler_slave  = false
+  allow_no_ssl            = false
+  auto_scale              = true
+  auto_scale_percentage   = 10
+  auto_scale_tech_window  = 0
+  auto_scale_quota_gb     = "1"
+
+  # Optional parameters with their defaults:
+  # need_external_address_master = false
+  # need_external_address_slave = false
+  # ext_backup_schedule = "0 0 * * *"
+  # ext_backup_num_to_retain = 7
+  # ip_space_name_master = "" # no default
+  # ip_space_name_slave = "" # no default
+}
@@ -0,0 +1,20 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "s3_resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
+
+variable "s3_display_name" {
+  type        = string
+  description = "Value of display_name."
+}
+
+variable "resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_rabbitmq" "example" {
+  resource_name                = "example-rabbitmq"
+  resource_cpu                 = 1000
+  resource_memory              = 1124
+  resource_disk                = 30
+  resource_realm               = var.resource_realm
+  resource_instances           = 1
+  need_external_address_master = false
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+  # need_external_address_slave = false
+  # ip_space_name_slave = "" # no default
+}
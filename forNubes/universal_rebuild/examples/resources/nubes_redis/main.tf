@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_redis" "example" {
+  resource_name                = "example-redis"
+  resource_cpu                 = 1000
+  resource_memory              = 1024
+  resource_disk                = 30
+  resource_instances           = 1
+  resource_realm               = var.resource_realm
+  need_external_address_master = false
+  need_external_address_slave  = false
+
+  # Optional parameters with their defaults:
+  # ip_space_name_master = "" # no default
+  # ip_space_name_slave = "" # no default
+}
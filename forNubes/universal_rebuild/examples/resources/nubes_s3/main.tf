@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_s3" "example" {
+  resource_name  = "example-s3"
+  resource_realm = var.resource_realm
+  display_name   = var.display_name
+
+  # Optional parameters with their defaults:
+  # max_size_gb_per_user = 0 # no default
+  # max_objects_per_bucket = 0 # no default
+  # max_buckets_per_user = 0 # no default
+}
@@ -0,0 +1,15 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
+
+variable "display_name" {
+  type        = string
+  description = "Value of display_name."
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_s3" "example" {
+  resource_name  = "example-s3"
+  resource_realm = var.s3_resource_realm
+  display_name   = var.s3_display_name
+
+  # Optional parameters with their defaults:
+  # max_size_gb_per_user = 0 # no default
+  # max_objects_per_bucket = 0 # no default
+  # max_buckets_per_user = 0 # no default
+}
+
+resource "nubes_s3bucket" "example" {
+  resource_name = "example-s3bucket"
+  s3_user_uid   = nubes_s3.example.id
+  bucket_name   = var.bucket_name
+  max_size      = "-1"
+  read_all      = false
+  list_all      = false
+  cors_all      = false
+  placement     = "HOT"
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_superset" "example" {
+  resource_name      = "example-superset"
+  domain             = "web01"
+  emails             = "testuser1"
+  resource_cpu       = 500
+  resource_memory    = 512
+  resource_disk      = 1
+  resource_realm     = var.resource_realm
+  resource_instances = 1
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_vc_nsxt" "example" {
+  resource_name   = "example-vc-nsxt"
+  need_enable_avi = false
+  vdc_type        = "vdc"
+
+  # Optional parameters with their defaults:
+  # vdc_uid = "" # no default
+  # virtual_services_count = 1
+  # segroup_name = "" # no default
+  # vdc_group_uid = "" # no default
+  # need_external_address_snat = false # no default
+  # ip_space_name = "" # no default
+}
+
+resource "nubes_vc_vdc" "example" {
+  resource_name        = "example-vc-vdc"
+  organization_uid     = var.vc_vdc_organization_uid
+  vdc_provider_gateway = var.vc_vdc_vdc_provider_gateway
+  storage_config       = var.vc_vdc_storage_config
+  vdc_network_pool     = var.vc_vdc_vdc_network_pool
+  cpu_guaranteed       = 20
+  mem_guaranteed       = 20
+  cpu_allocated        = 10
+  mem_allocated        = 20
+}
+
+resource "nubes_vapp" "example" {
+  resource_name = "example-vapp"
+  nsxt_uid      = nubes_vc_nsxt.example.id
+  vapp_name     = var.vapp_name
+  vdc_uid       = nubes_vc_vdc.example.id
+}
@@ -0,0 +1,30 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "vapp_name" {
+  type        = string
+  description = "Value of vapp_name."
+}
+
+variable "vc_vdc_organization_uid" {
+  type        = string
+  description = "Value of organization_uid."
+}
+
+variable "vc_vdc_vdc_provider_gateway" {
+  type        = string
+  description = "Value of vdc_provider_gateway."
+}
+
+variable "vc_vdc_storage_config" {
+  type        = string
+  description = "Value of storage_config."
+}
+
+variable "vc_vdc_vdc_network_pool" {
+  type        = string
+  description = "Value of vdc_network_pool."
+}
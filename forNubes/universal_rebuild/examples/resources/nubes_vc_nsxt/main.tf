@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_vc_nsxt" "example" {
+  resource_name   = "example-vc-nsxt"
+  need_enable_avi = false
+  vdc_type        = "vdc"
+
+  # Optional parameters with their defaults:
+  # vdc_uid = "" # no default
+  # virtual_services_count = 1
+  # segroup_name = "" # no default
+  # vdc_group_uid = "" # no default
+  # need_external_address_snat = false # no default
+  # ip_space_name = "" # no default
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_vc_vdc" "example" {
+  resource_name        = "example-vc-vdc"
+  organization_uid     = var.organization_uid
+  vdc_provider_gateway = var.vdc_provider_gateway
+  storage_config       = var.storage_config
+  vdc_network_pool     = var.vdc_network_pool
+  cpu_guaranteed       = 20
+  mem_guaranteed       = 20
+  cpu_allocated        = 10
+  mem_allocated        = 20
+}
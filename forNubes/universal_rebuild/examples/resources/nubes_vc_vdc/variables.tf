@@ -0,0 +1,25 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "organization_uid" {
+  type        = string
+  description = "Value of organization_uid."
+}
+
+variable "vdc_provider_gateway" {
+  type        = string
+  description = "Value of vdc_provider_gateway."
+}
+
+variable "storage_config" {
+  type        = string
+  description = "Value of storage_config."
+}
+
+variable "vdc_network_pool" {
+  type        = string
+  description = "Value of vdc_network_pool."
+}
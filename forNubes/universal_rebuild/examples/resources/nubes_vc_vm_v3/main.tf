@@ -0,0 +1,63 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_vc_nsxt" "example" {
+  resource_name   = "example-vc-nsxt"
+  need_enable_avi = false
+  vdc_type        = "vdc"
+
+  # Optional parameters with their defaults:
+  # vdc_uid = "" # no default
+  # virtual_services_count = 1
+  # segroup_name = "" # no default
+  # vdc_group_uid = "" # no default
+  # need_external_address_snat = false # no default
+  # ip_space_name = "" # no default
+}
+
+resource "nubes_vc_vdc" "example" {
+  resource_name        = "example-vc-vdc"
+  organization_uid     = var.vc_vdc_organization_uid
+  vdc_provider_gateway = var.vc_vdc_vdc_provider_gateway
+  storage_config       = var.vc_vdc_storage_config
+  vdc_network_pool     = var.vc_vdc_vdc_network_pool
+  cpu_guaranteed       = 20
+  mem_guaranteed       = 20
+  cpu_allocated        = 10
+  mem_allocated        = 20
+}
+
+resource "nubes_vapp" "example" {
+  resource_name = "example-vapp"
+  nsxt_uid      = nubes_vc_nsxt.example.id
+  vapp_name     = var.vapp_vapp_name
+  vdc_uid       = nubes_vc_vdc.example.id
+}
+
+resource "nubes_vc_vm_v3" "example" {
+  resource_name            = "example-vc-vm-v3"
+  vapp_uid                 = nubes_vapp.example.id
+  vm_name                  = "web01"
+  vm_cpu                   = 1
+  vm_ram                   = 1
+  image_vm                 = var.image_vm
+  user_login               = "myuser"
+  user_public_key          = var.user_public_key
+  access_port_list         = "[\"22\"]"
+  need_add_zabbix_template = true
+
+  # Optional parameters with their defaults:
+  # vm_disk = 0 # no default
+  # ip_space_name = "no-needed"
+  # access_ip_list = "" # no default
+  # cloud_init = "" # no default
+}
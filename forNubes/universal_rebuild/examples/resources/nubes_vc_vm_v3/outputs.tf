@@ -0,0 +1,3 @@
+output "id" {
+  value = nubes_vc_vm_v3.example.id
+}
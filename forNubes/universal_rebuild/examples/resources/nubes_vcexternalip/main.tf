@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+provider "nubes" {
+  api_token = var.api_token
+}
+
+resource "nubes_vcexternalip" "example" {
+  resource_name          = "example-vcexternalip"
+  service_uid            = var.service_uid
+  dnat_create            = true
+  internal_port_access   = "5432"
+  internal_addr_access   = var.internal_addr_access
+  from_service_namespace = var.from_service_namespace
+  ip_space_name          = var.ip_space_name
+  resource_realm         = var.resource_realm
+
+  # Optional parameters with their defaults:
+  # snat_create = false # no default
+  # from_service_cloud_edge_name = "" # no default
+  # from_service_cloud_vdc_name = "" # no default
+  # from_service_cloud_org_name = "" # no default
+  # from_service_cloud_vmware_url = "" # no default
+  # from_service_cloud_edge_scope = "" # no default
+  # from_service_vdc_group_name = "" # no default
+}
@@ -0,0 +1,30 @@
+variable "api_token" {
+  type        = string
+  description = "Nubes API token."
+  sensitive   = true
+}
+
+variable "service_uid" {
+  type        = string
+  description = "Value of service_uid."
+}
+
+variable "internal_addr_access" {
+  type        = string
+  description = "Value of internal_addr_access."
+}
+
+variable "from_service_namespace" {
+  type        = string
+  description = "Value of from_service_namespace."
+}
+
+variable "ip_space_name" {
+  type        = string
+  description = "Value of ip_space_name."
+}
+
+variable "resource_realm" {
+  type        = string
+  description = "Value of resource_realm."
+}
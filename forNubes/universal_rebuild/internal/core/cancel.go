@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cancelOperationTimeout bounds the cancel request made after Terraform was
+// interrupted, when the caller's context is already done.
+const cancelOperationTimeout = 30 * time.Second
+
+// cancelOperation asks the API to cancel a running instanceOperation
+// (POST /instanceOperations/{uid}/cancel). The endpoint is not available for
+// every service; any failure is logged and reported as false.
+func (c *UniversalClient) cancelOperation(ctx context.Context, opUid string) bool {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelOperationTimeout)
+	defer cancel()
+
+	if _, _, err := c.doRequest(ctx, "POST", fmt.Sprintf("/instanceOperations/%s/cancel", opUid), map[string]interface{}{}); err != nil {
+		tflog.Warn(ctx, "unable to cancel remote operation", map[string]interface{}{
+			"operation": opUid,
+			"error":     err.Error(),
+		})
+		return false
+	}
+	tflog.Info(ctx, "remote operation cancelled", map[string]interface{}{"operation": opUid})
+	return true
+}
+
+// interruptOperation ends a wait the caller gave up on: the remote operation
+// is cancelled if possible, otherwise it is recorded as pending for refresh.
+func (c *UniversalClient) interruptOperation(ctx context.Context, opUid string) error {
+	if c.cancelOperation(ctx, opUid) {
+		return fmt.Errorf("operation %s cancelled (remote operation cancelled)", opUid)
+	}
+	markOperationPending(ctx, opUid)
+	return fmt.Errorf("operation %s cancelled (remote operation may still be running)", opUid)
+}
+
+// OperationStatus is the outcome of an instanceOperation.
+type OperationStatus struct {
+	Finished   bool
+	Successful bool
+	ErrorLog   string
+}
+
+// GetOperationStatus reads the operation once. Finished follows the same
+// dtFinish criterion as waitForOperationFinish.
+func (c *UniversalClient) GetOperationStatus(ctx context.Context, opUid string) (OperationStatus, error) {
+	respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s", opUid), nil)
+	if err != nil {
+		return OperationStatus{}, err
+	}
+	var status operationStatusResponse
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return OperationStatus{}, fmt.Errorf("failed to parse operation %s status: %w", opUid, err)
+	}
+
+	op := status.InstanceOperation
+	res := OperationStatus{}
+	if op.DtFinish != nil && strings.TrimSpace(*op.DtFinish) != "" {
+		res.Finished = true
+		res.Successful = op.IsSuccessful == nil || *op.IsSuccessful
+		if op.ErrorLog != nil {
+			res.ErrorLog = strings.TrimSpace(*op.ErrorLog)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// operationAPI reports every operation as running, slowly, and answers the
+// cancel endpoint with cancelStatus.
+func operationAPI(t *testing.T, cancelStatus int, cancels *int32) *UniversalClient {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/cancel"):
+			atomic.AddInt32(cancels, 1)
+			w.WriteHeader(cancelStatus)
+			fmt.Fprint(w, "{}")
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/instanceOperations/"):
+			// Slow enough that the interrupt arrives while a poll is in flight.
+			time.Sleep(50 * time.Millisecond)
+			fmt.Fprint(w, `{"instanceOperation":{"isInProgress":true}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+}
+
+// Interrupting the wait cancels the remote operation even though the
+// caller's context is already done.
+func TestWaitCancelledCancelsOperation(t *testing.T) {
+	var cancels int32
+	client := operationAPI(t, http.StatusOK, &cancels)
+	ctx, cancel := context.WithTimeout(WithPollPolicy(context.Background(), PollPolicy{Initial: time.Millisecond}), 20*time.Millisecond)
+	defer cancel()
+	ctx, opLog := WithOperationLog(ctx)
+
+	err := client.waitForOperationFinish(ctx, "op-1", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "remote operation cancelled") {
+		t.Fatalf("got %v", err)
+	}
+	if atomic.LoadInt32(&cancels) != 1 {
+		t.Errorf("%d cancel requests, want 1", cancels)
+	}
+	if _, op := opLog.Pending(); op != "" {
+		t.Errorf("cancelled operation %s left pending", op)
+	}
+}
+
+// When the cancel endpoint fails, the operation is left for refresh to
+// reconcile.
+func TestWaitCancelledMarksPending(t *testing.T) {
+	var cancels int32
+	client := operationAPI(t, http.StatusNotFound, &cancels)
+	ctx, cancel := context.WithTimeout(WithPollPolicy(context.Background(), PollPolicy{Initial: time.Millisecond}), 20*time.Millisecond)
+	defer cancel()
+	ctx, opLog := WithOperationLog(ctx)
+
+	err := client.waitForOperationFinish(ctx, "op-1", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "may still be running") {
+		t.Fatalf("got %v", err)
+	}
+	if _, op := opLog.Pending(); op != "op-1" {
+		t.Errorf("pending operation %q, want op-1", op)
+	}
+}
+
+func TestGetOperationStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instanceOperations/running":
+			fmt.Fprint(w, `{"instanceOperation":{"dtFinish":null,"isInProgress":true}}`)
+		case "/instanceOperations/failed":
+			fmt.Fprint(w, `{"instanceOperation":{"dtFinish":"2024-01-01","isSuccessful":false,"errorLog":" quota exceeded\n"}}`)
+		case "/instanceOperations/done":
+			fmt.Fprint(w, `{"instanceOperation":{"dtFinish":"2024-01-01"}}`)
+		}
+	}))
+	defer srv.Close()
+	client := &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	for op, want := range map[string]OperationStatus{
+		"running": {},
+		"failed":  {Finished: true, ErrorLog: "quota exceeded"},
+		"done":    {Finished: true, Successful: true},
+	} {
+		got, err := client.GetOperationStatus(context.Background(), op)
+		if err != nil || got != want {
+			t.Errorf("%s: got %+v, %v, want %+v", op, got, err, want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,6 +18,16 @@ type UniversalClient struct {
 	HttpClient  *http.Client
 	ApiEndpoint string
 	ApiToken    string
+
+	// VerifyUniqueNames makes create fail if another instance with the same
+	// service and display name appears (e.g. created from another workspace).
+	VerifyUniqueNames bool
+
+	// refCache maps "<refSvcId>/<displayName>" to a resolved instanceUid;
+	// ResolveInstanceRef evicts entries whose instance is gone.
+	refCache sync.Map
+	// polls coalesces status polls of the same operation or instance.
+	polls pollGroup
 }
 
 type genericInstanceReq struct {
@@ -61,43 +72,26 @@ type universalCfsParam struct {
 
 // CreateGenericInstanceUniversalV6 implements the universal flow:
 // instances -> instanceOperations -> get cfsParams -> submit params -> validate -> run
-func (c *UniversalClient) CreateGenericInstanceUniversalV6(ctx context.Context, serviceId int, displayName string, params map[int]string) (string, error) {
-	instPayload := genericInstanceReq{
-		ServiceId:   serviceId,
-		DisplayName: displayName,
-		Descr:       "Created via Terraform Universal Provider",
-	}
+func (c *UniversalClient) CreateGenericInstanceUniversalV6(ctx context.Context, serviceId int, displayName string, params map[int]string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "nubes.create_instance", attrServiceID.Int(serviceId))
+	defer func() { endSpan(span, err) }()
 
-	instResp, instHeaders, err := c.doRequest(ctx, "POST", "/instances", instPayload)
+	instanceUid, err := c.postInstance(ctx, serviceId, displayName)
 	if err != nil {
 		return "", err
 	}
+	recordInstance(ctx, instanceUid)
+	span.SetAttributes(attrInstanceUID.String(instanceUid))
 
-	instanceUid := extractUIDFromLocation(instHeaders.Get("Location"))
-	if instanceUid == "" {
-		var instResult struct {
-			InstanceUid string `json:"instanceUid"`
-		}
-		if err := json.Unmarshal(instResp, &instResult); err == nil && instResult.InstanceUid != "" {
-			instanceUid = instResult.InstanceUid
-		} else {
-			var justId string
-			if err2 := json.Unmarshal(instResp, &justId); err2 == nil && justId != "" {
-				instanceUid = justId
-			}
-		}
-	}
-	if instanceUid == "" {
-		return "", fmt.Errorf("could not extract instanceUid from response (Header: %s)", instHeaders.Get("Location"))
-	}
-
+	opCtx, opSpan := startSpan(ctx, "nubes.create_operation", attrServiceID.Int(serviceId), attrInstanceUID.String(instanceUid), attrOperation.String("create"))
 	opPayload := genericOpReq{
 		InstanceUid: instanceUid,
 		Operation:   "create",
 	}
 
-	opResp, opHeaders, err := c.doRequest(ctx, "POST", "/instanceOperations", opPayload)
+	opResp, opHeaders, err := c.doRequest(opCtx, "POST", "/instanceOperations", opPayload)
 	if err != nil {
+		endSpan(opSpan, err)
 		return "", err
 	}
 
@@ -116,8 +110,14 @@ func (c *UniversalClient) CreateGenericInstanceUniversalV6(ctx context.Context,
 		}
 	}
 	if opUid == "" {
-		return "", fmt.Errorf("failed to extract instanceOperationUid (Header: %s)", opHeaders.Get("Location"))
+		err := fmt.Errorf("failed to extract instanceOperationUid (Header: %s)", opHeaders.Get("Location"))
+		endSpan(opSpan, err)
+		return "", err
 	}
+	recordOperation(ctx, "create", opUid)
+	opSpan.SetAttributes(attrOpUID.String(opUid))
+	endSpan(opSpan, nil)
+	span.SetAttributes(attrOpUID.String(opUid))
 
 	opDetailsResp, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s?fields=cfsParams", opUid), nil)
 	if err != nil {
@@ -135,13 +135,7 @@ func (c *UniversalClient) CreateGenericInstanceUniversalV6(ctx context.Context,
 
 	sent := make(map[int]bool)
 	for paramId, value := range params {
-		pPayload := genericParamReq{
-			InstanceOperationUid:   opUid,
-			SvcOperationCfsParamId: paramId,
-			ParamValue:             value,
-		}
-		_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", pPayload)
-		if err != nil {
+		if err := c.submitParam(ctx, opUid, paramId, value); err != nil {
 			return "", fmt.Errorf("failed to set param %d: %w", paramId, err)
 		}
 		sent[paramId] = true
@@ -166,24 +160,16 @@ func (c *UniversalClient) CreateGenericInstanceUniversalV6(ctx context.Context,
 			continue
 		}
 
-		pPayload := genericParamReq{
-			InstanceOperationUid:   opUid,
-			SvcOperationCfsParamId: param.SvcOperationCfsParamId,
-			ParamValue:             val,
-		}
-		_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", pPayload)
-		if err != nil {
+		if err := c.submitParam(ctx, opUid, param.SvcOperationCfsParamId, val); err != nil {
 			return "", fmt.Errorf("failed to submit default param %d: %w", param.SvcOperationCfsParamId, err)
 		}
 	}
 
-	_, _, err = c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s/validate-cfs", opUid), nil)
-	if err != nil {
+	if err := c.validateOperation(ctx, opUid); err != nil {
 		return "", fmt.Errorf("validation failed: %w", err)
 	}
 
-	_, _, err = c.doRequest(ctx, "POST", fmt.Sprintf("/instanceOperations/%s/run", opUid), map[string]interface{}{})
-	if err != nil {
+	if err := c.runOperation(ctx, opUid); err != nil {
 		return "", fmt.Errorf("execution failed: %w", err)
 	}
 
@@ -224,7 +210,10 @@ func normalizeUniversalValueV6(val string, param universalCfsParam) string {
 }
 
 // RunInstanceOperationUniversal runs an available operation (modify/suspend/delete/resume) if possible.
-func (c *UniversalClient) RunInstanceOperationUniversal(ctx context.Context, instanceUid string, action string, params map[int]string) error {
+func (c *UniversalClient) RunInstanceOperationUniversal(ctx context.Context, instanceUid string, action string, params map[int]string) (err error) {
+	ctx, span := startSpan(ctx, "nubes.run_operation", attrInstanceUID.String(instanceUid), attrOperation.String(action))
+	defer func() { endSpan(span, err) }()
+
 	state, err := c.GetInstanceState(ctx, instanceUid)
 	if err != nil {
 		return err
@@ -259,23 +248,18 @@ func (c *UniversalClient) RunInstanceOperationUniversal(ctx context.Context, ins
 	if opUid == "" {
 		return fmt.Errorf("failed to get operation UID for %s", action)
 	}
+	recordOperation(ctx, action, opUid)
+	span.SetAttributes(attrOpUID.String(opUid))
 
 	if params != nil {
 		for paramId, value := range params {
-			pPayload := genericParamReq{
-				InstanceOperationUid:   opUid,
-				SvcOperationCfsParamId: paramId,
-				ParamValue:             value,
-			}
-			_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", pPayload)
-			if err != nil {
+			if err := c.submitParam(ctx, opUid, paramId, value); err != nil {
 				return fmt.Errorf("failed to set param %d: %w", paramId, err)
 			}
 		}
 	}
 
-	_, _, err = c.doRequest(ctx, "POST", fmt.Sprintf("/instanceOperations/%s/run", opUid), map[string]interface{}{})
-	if err != nil {
+	if err := c.runOperation(ctx, opUid); err != nil {
 		return err
 	}
 
@@ -284,7 +268,10 @@ func (c *UniversalClient) RunInstanceOperationUniversal(ctx context.Context, ins
 }
 
 // RunInstanceOperationUniversalWithDefaults runs an operation and submits required params (including defaults).
-func (c *UniversalClient) RunInstanceOperationUniversalWithDefaults(ctx context.Context, instanceUid string, action string, params map[int]string) error {
+func (c *UniversalClient) RunInstanceOperationUniversalWithDefaults(ctx context.Context, instanceUid string, action string, params map[int]string) (err error) {
+	ctx, span := startSpan(ctx, "nubes.run_operation", attrInstanceUID.String(instanceUid), attrOperation.String(action))
+	defer func() { endSpan(span, err) }()
+
 	state, err := c.GetInstanceState(ctx, instanceUid)
 	if err != nil {
 		return err
@@ -319,6 +306,8 @@ func (c *UniversalClient) RunInstanceOperationUniversalWithDefaults(ctx context.
 	if opUid == "" {
 		return fmt.Errorf("failed to get operation UID for %s", action)
 	}
+	recordOperation(ctx, action, opUid)
+	span.SetAttributes(attrOpUID.String(opUid))
 
 	opDetailsResp, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s?fields=cfsParams", opUid), nil)
 	if err != nil {
@@ -337,13 +326,7 @@ func (c *UniversalClient) RunInstanceOperationUniversalWithDefaults(ctx context.
 	sent := make(map[int]bool)
 	if params != nil {
 		for paramId, value := range params {
-			pPayload := genericParamReq{
-				InstanceOperationUid:   opUid,
-				SvcOperationCfsParamId: paramId,
-				ParamValue:             value,
-			}
-			_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", pPayload)
-			if err != nil {
+			if err := c.submitParam(ctx, opUid, paramId, value); err != nil {
 				return fmt.Errorf("failed to set param %d: %w", paramId, err)
 			}
 			sent[paramId] = true
@@ -363,24 +346,16 @@ func (c *UniversalClient) RunInstanceOperationUniversalWithDefaults(ctx context.
 		}
 		val = normalizeUniversalValueV6(val, param)
 
-		pPayload := genericParamReq{
-			InstanceOperationUid:   opUid,
-			SvcOperationCfsParamId: param.SvcOperationCfsParamId,
-			ParamValue:             val,
-		}
-		_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", pPayload)
-		if err != nil {
+		if err := c.submitParam(ctx, opUid, param.SvcOperationCfsParamId, val); err != nil {
 			return fmt.Errorf("failed to submit default param %d: %w", param.SvcOperationCfsParamId, err)
 		}
 	}
 
-	_, _, err = c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s/validate-cfs", opUid), nil)
-	if err != nil {
+	if err := c.validateOperation(ctx, opUid); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	_, _, err = c.doRequest(ctx, "POST", fmt.Sprintf("/instanceOperations/%s/run", opUid), map[string]interface{}{})
-	if err != nil {
+	if err := c.runOperation(ctx, opUid); err != nil {
 		return err
 	}
 
@@ -408,26 +383,12 @@ type InstanceStateResponse struct {
 func (c *UniversalClient) FindInstanceByDisplayName(ctx context.Context, serviceId int, displayName string) (*InstanceStateResponse, error) {
 	page := 1
 	for {
-		url := fmt.Sprintf("%s/instances?page=%d&size=100", c.ApiEndpoint, page)
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
-		if c.ApiToken != "" {
-			req.Header.Set("Authorization", "Bearer "+c.ApiToken)
-		}
-
-		resp, err := c.HttpClient.Do(req)
+		// The page is read in full and its body closed before the next
+		// request: with a concurrency cap an open body holds a slot.
+		respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instances?page=%d&size=100", page), nil)
 		if err != nil {
 			return nil, err
 		}
-		if resp.Body != nil {
-			defer resp.Body.Close()
-		}
-
-		if resp.StatusCode != 200 {
-			return nil, fmt.Errorf("status %d", resp.StatusCode)
-		}
 
 		var res struct {
 			Results []struct {
@@ -436,7 +397,7 @@ func (c *UniversalClient) FindInstanceByDisplayName(ctx context.Context, service
 				ServiceId   int    `json:"serviceId"`
 			} `json:"results"`
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		if err := json.Unmarshal(respBody, &res); err != nil {
 			return nil, err
 		}
 
@@ -483,7 +444,7 @@ func (c *UniversalClient) GetInstanceState(ctx context.Context, instanceUid stri
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status %d", resp.StatusCode)
+		return nil, statusError(resp)
 	}
 
 	var res struct {
@@ -518,6 +479,9 @@ func isInstanceDeleted(state *InstanceStateResponse) bool {
 //  3. ЗАПРЕЩЕНО ПРАВИТЬ ЭТОТ КОД БЕЗ ЯВНОГО СОГЛАСОВАНИЯ С ОПЕРАТОРОМ.
 //     Любые изменения (таймауты, критерии завершения, частота опроса, обработка ошибок)
 //     должны быть согласованы заранее.
+//  4. Частота опроса задаётся PollPolicy (polling.go): быстрая первая проверка,
+//     экспоненциальный рост до предела, подсказка сервера (eta/progress).
+//     Критерий завершения от неё не зависит.
 const defaultOperationTimeout = 30 * time.Minute
 
 type operationStatusResponse struct {
@@ -530,22 +494,36 @@ type operationStatusResponse struct {
 	} `json:"instanceOperation"`
 }
 
-func (c *UniversalClient) waitForOperationFinish(ctx context.Context, opUid string, timeout time.Duration) error {
-	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+func (c *UniversalClient) waitForOperationFinish(ctx context.Context, opUid string, timeout time.Duration) (err error) {
+	ctx, span := startSpan(ctx, "nubes.wait_operation", attrOpUID.String(opUid))
+	defer func() { endSpan(span, err) }()
+	policy := pollPolicyFromContext(ctx)
+	if policy.Timeout > 0 {
+		timeout = policy.Timeout
+	}
+	started := time.Now()
+	deadline := started.Add(timeout)
+	delays := newBackoff(policy)
+	timer := time.NewTimer(delays.first())
+	defer timer.Stop()
+	progress := newOperationReporter(opUid)
 
 	for {
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("operation %s cancelled", opUid)
-		case <-ticker.C:
+			return c.interruptOperation(ctx, opUid)
+		case <-timer.C:
 			if time.Now().After(deadline) {
+				markOperationPending(ctx, opUid)
 				return fmt.Errorf("timeout waiting for operation %s to finish", opUid)
 			}
 
-			respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s", opUid), nil)
+			respBody, err := c.pollOperation(ctx, opUid)
 			if err != nil {
+				if ctx.Err() != nil {
+					// Interrupted while the poll was in flight.
+					return c.interruptOperation(ctx, opUid)
+				}
 				return fmt.Errorf("failed to check operation %s status: %w", opUid, err)
 			}
 
@@ -553,50 +531,117 @@ func (c *UniversalClient) waitForOperationFinish(ctx context.Context, opUid stri
 			if err := json.Unmarshal(respBody, &status); err != nil {
 				return fmt.Errorf("failed to parse operation %s status: %w", opUid, err)
 			}
+			progress.observe(ctx, respBody)
 
 			// Критерий завершения — dtFinish (НЕ МЕНЯТЬ)
 			if status.InstanceOperation.DtFinish != nil && strings.TrimSpace(*status.InstanceOperation.DtFinish) != "" {
 				if status.InstanceOperation.IsSuccessful != nil && !*status.InstanceOperation.IsSuccessful {
-					if status.InstanceOperation.ErrorLog != nil && strings.TrimSpace(*status.InstanceOperation.ErrorLog) != "" {
-						return fmt.Errorf("operation %s failed: %s", opUid, *status.InstanceOperation.ErrorLog)
+					progress.finish(ctx, false)
+					errorLog := ""
+					if status.InstanceOperation.ErrorLog != nil {
+						errorLog = *status.InstanceOperation.ErrorLog
 					}
-					return fmt.Errorf("operation %s failed", opUid)
+					return operationFailedError(opUid, errorLog, progress.logTail())
 				}
+				progress.finish(ctx, true)
 				return nil
 			}
+			timer.Reset(delays.next(operationHint(respBody, time.Since(started))))
 		}
 	}
 }
 
+// submitParam posts one operation parameter value.
+func (c *UniversalClient) submitParam(ctx context.Context, opUid string, paramId int, value string) error {
+	ctx, span := startSpan(ctx, "nubes.submit_param", attrOpUID.String(opUid), attrParamID.Int(paramId))
+	logParamSubmit(ctx, opUid, paramId, value)
+	_, _, err := c.doRequest(ctx, "POST", "/instanceOperationCfsParams", genericParamReq{
+		InstanceOperationUid:   opUid,
+		SvcOperationCfsParamId: paramId,
+		ParamValue:             value,
+	})
+	endSpan(span, err)
+	return err
+}
+
+func (c *UniversalClient) validateOperation(ctx context.Context, opUid string) error {
+	ctx, span := startSpan(ctx, "nubes.validate", attrOpUID.String(opUid))
+	_, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s/validate-cfs", opUid), nil)
+	endSpan(span, err)
+	return err
+}
+
+func (c *UniversalClient) runOperation(ctx context.Context, opUid string) error {
+	ctx, span := startSpan(ctx, "nubes.run", attrOpUID.String(opUid))
+	_, _, err := c.doRequest(ctx, "POST", fmt.Sprintf("/instanceOperations/%s/run", opUid), map[string]interface{}{})
+	endSpan(span, err)
+	return err
+}
+
 // waitForInstanceIdle waits until no operation is pending/in-progress for the instance.
 func (c *UniversalClient) waitForInstanceIdle(ctx context.Context, instanceUid string, timeout time.Duration) error {
+	policy := pollPolicyFromContext(ctx)
+	if policy.Timeout > 0 {
+		timeout = policy.Timeout
+	}
 	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+	delays := newBackoff(policy)
+	timer := time.NewTimer(delays.first())
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("operation wait cancelled for instance %s", instanceUid)
-		case <-ticker.C:
+		case <-timer.C:
 			if time.Now().After(deadline) {
 				return fmt.Errorf("timeout waiting for instance %s to become idle", instanceUid)
 			}
 
-			state, err := c.GetInstanceState(ctx, instanceUid)
+			state, err := c.pollInstanceState(ctx, instanceUid)
 			if err != nil {
 				return fmt.Errorf("failed to check instance %s state: %w", instanceUid, err)
 			}
 			if !state.OperationIsPending && !state.OperationIsInProgress {
 				return nil
 			}
+			timer.Reset(delays.next(0))
 		}
 	}
 }
 
+// pollOperation fetches the operation status; concurrent waiters on the same
+// operation share one GET.
+func (c *UniversalClient) pollOperation(ctx context.Context, opUid string) ([]byte, error) {
+	v, err := c.polls.do(ctx, "operation/"+opUid, func(ctx context.Context) (interface{}, error) {
+		respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s", opUid), nil)
+		return respBody, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// pollInstanceState is GetInstanceState shared by concurrent waiters on the same instance.
+func (c *UniversalClient) pollInstanceState(ctx context.Context, instanceUid string) (*InstanceStateResponse, error) {
+	v, err := c.polls.do(ctx, "instance/"+instanceUid, func(ctx context.Context) (interface{}, error) {
+		return c.GetInstanceState(ctx, instanceUid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*InstanceStateResponse), nil
+}
+
 // Internal HTTP helpers
 
 func (c *UniversalClient) doRequest(ctx context.Context, method, path string, payload interface{}) ([]byte, http.Header, error) {
+	return c.doRequestWithHeaders(ctx, method, path, payload, nil)
+}
+
+// doRequestWithHeaders is doRequest with extra request headers.
+func (c *UniversalClient) doRequestWithHeaders(ctx context.Context, method, path string, payload interface{}, headers http.Header) ([]byte, http.Header, error) {
 	var body io.Reader
 	if payload != nil {
 		b, err := json.Marshal(payload)
@@ -616,6 +661,9 @@ func (c *UniversalClient) doRequest(ctx context.Context, method, path string, pa
 	if c.ApiToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.ApiToken)
 	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
 
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
@@ -629,7 +677,7 @@ func (c *UniversalClient) doRequest(ctx context.Context, method, path string, pa
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, nil, fmt.Errorf("API error %d: %s", resp.StatusCode, string(respBody))
+		return nil, nil, &APIError{StatusCode: resp.StatusCode, RequestID: requestID(resp), Body: string(respBody)}
 	}
 
 	return respBody, resp.Header, nil
@@ -676,11 +724,14 @@ func extractUIDFromLocation(loc string) string {
 // и serviceId == refSvcId. Этот подход применим для любых сервисов (например, Postgres).
 
 func (c *UniversalClient) resolveRefSvcParamValues(ctx context.Context, opParams []universalCfsParam, params map[int]string) (map[int]string, error) {
-	if len(params) == 0 || len(opParams) == 0 {
+	if len(params) == 0 {
 		return params, nil
 	}
 
 	refById := make(map[int]int)
+	for paramId, refSvcId := range paramRefsFromContext(ctx) {
+		refById[paramId] = refSvcId
+	}
 	for _, p := range opParams {
 		if p.RefSvcId != nil && *p.RefSvcId > 0 {
 			refById[p.SvcOperationCfsParamId] = *p.RefSvcId
@@ -694,13 +745,10 @@ func (c *UniversalClient) resolveRefSvcParamValues(ctx context.Context, opParams
 	for paramId, value := range params {
 		newValue := value
 		if refSvcId, ok := refById[paramId]; ok {
-			if !isUUIDLike(value) {
-				uid, err := c.findInstanceUidByDisplayNameRefSvc(ctx, refSvcId, value)
+			if strings.TrimSpace(value) != "" && !IsUUID(value) {
+				uid, err := c.ResolveInstanceRef(ctx, refSvcId, value)
 				if err != nil {
-					return nil, err
-				}
-				if uid == "" {
-					return nil, fmt.Errorf("failed to resolve param %d: no instance found for serviceId=%d displayName=%s", paramId, refSvcId, value)
+					return nil, fmt.Errorf("failed to resolve param %d: %w", paramId, err)
 				}
 				newValue = uid
 			}
@@ -750,7 +798,8 @@ func (c *UniversalClient) findInstanceUidByDisplayNameRefSvc(ctx context.Context
 	return "", nil
 }
 
-func isUUIDLike(value string) bool {
+// IsUUID reports whether value is a canonical 8-4-4-4-12 hex UUID.
+func IsUUID(value string) bool {
 	trimmed := strings.TrimSpace(value)
 	if len(trimmed) != 36 {
 		return false
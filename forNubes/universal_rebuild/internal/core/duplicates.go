@@ -0,0 +1,48 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// FindInstanceUidsByDisplayName returns the UIDs of all non-deleted instances
+// of serviceId with exactly displayName.
+func (c *UniversalClient) FindInstanceUidsByDisplayName(ctx context.Context, serviceId int, displayName string) ([]string, error) {
+	var uids []string
+	for page := 1; page <= 100; page++ {
+		respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instances?page=%d&size=100", page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var res struct {
+			Results []struct {
+				InstanceUid string `json:"instanceUid"`
+				DisplayName string `json:"displayName"`
+				ServiceId   int    `json:"serviceId"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(respBody, &res); err != nil {
+			return nil, err
+		}
+		if len(res.Results) == 0 {
+			break
+		}
+
+		for _, item := range res.Results {
+			if item.ServiceId != serviceId || item.DisplayName != displayName {
+				continue
+			}
+			ref, err := c.getInstanceRef(ctx, item.InstanceUid)
+			if err != nil {
+				return nil, err
+			}
+			if ref.IsDeleted {
+				continue
+			}
+			uids = append(uids, item.InstanceUid)
+		}
+	}
+	return uids, nil
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// IdempotencyKeyHeader carries the client-generated key of POST /instances.
+// The same key is embedded in descr, so the instance can be found even if the
+// API ignores the header.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	instanceDescr          = "Created via Terraform Universal Provider"
+	createInstanceAttempts = 3
+)
+
+// createInstanceRetryDelay grows linearly with the attempt number.
+var createInstanceRetryDelay = 2 * time.Second
+
+func idempotencyMarker(key string) string {
+	return "[tf-request " + key + "]"
+}
+
+// postInstance creates the instance record exactly once. A failed request
+// (network error, timeout, 429 or 5xx) may still have reached the server, so
+// before every retry the instance carrying this request's marker is looked up
+// and reused.
+func (c *UniversalClient) postInstance(ctx context.Context, serviceId int, displayName string) (string, error) {
+	key, err := uuid.GenerateUUID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	marker := idempotencyMarker(key)
+	payload := genericInstanceReq{
+		ServiceId:   serviceId,
+		DisplayName: displayName,
+		Descr:       instanceDescr + " " + marker,
+	}
+	headers := http.Header{IdempotencyKeyHeader: []string{key}}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			instanceUid, err := c.findInstanceByMarker(ctx, serviceId, displayName, marker)
+			if err != nil {
+				return "", fmt.Errorf("failed to look up instance created by previous attempt: %w", err)
+			}
+			if instanceUid != "" {
+				tflog.Info(ctx, "instance created by previous attempt found", map[string]interface{}{
+					"instance": instanceUid,
+					"key":      key,
+				})
+				return instanceUid, nil
+			}
+		}
+
+		respBody, respHeaders, err := c.doRequestWithHeaders(ctx, "POST", "/instances", payload, headers)
+		if err == nil {
+			if instanceUid := instanceUidFromResponse(respBody, respHeaders); instanceUid != "" {
+				return instanceUid, nil
+			}
+			// Created, but the UID is not in the response: the marker finds it.
+			instanceUid, lookupErr := c.findInstanceByMarker(ctx, serviceId, displayName, marker)
+			if lookupErr == nil && instanceUid != "" {
+				return instanceUid, nil
+			}
+			return "", fmt.Errorf("could not extract instanceUid from response (Header: %s)", respHeaders.Get("Location"))
+		}
+		if attempt >= createInstanceAttempts || !retryableCreateError(ctx, err) {
+			return "", err
+		}
+
+		delay := time.Duration(attempt) * createInstanceRetryDelay
+		tflog.Warn(ctx, "create instance request failed, retrying", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", err
+		case <-timer.C:
+		}
+	}
+}
+
+func instanceUidFromResponse(respBody []byte, headers http.Header) string {
+	if uid := extractUIDFromLocation(headers.Get("Location")); uid != "" {
+		return uid
+	}
+	var res struct {
+		InstanceUid string `json:"instanceUid"`
+	}
+	if err := json.Unmarshal(respBody, &res); err == nil && res.InstanceUid != "" {
+		return res.InstanceUid
+	}
+	var justId string
+	if err := json.Unmarshal(respBody, &justId); err == nil {
+		return justId
+	}
+	return ""
+}
+
+// retryableCreateError reports whether the request may be repeated: the
+// caller is still waiting and the failure is transient.
+func retryableCreateError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// findInstanceByMarker returns the non-deleted instance of serviceId named
+// displayName whose descr carries marker, or "".
+func (c *UniversalClient) findInstanceByMarker(ctx context.Context, serviceId int, displayName string, marker string) (string, error) {
+	uids, err := c.FindInstanceUidsByDisplayName(ctx, serviceId, displayName)
+	if err != nil {
+		return "", err
+	}
+	for _, uid := range uids {
+		inst, err := c.GetInstanceDetails(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		if descr, ok := LookupPath(inst, "descr"); ok {
+			if s, ok := descr.(string); ok && strings.Contains(s, marker) {
+				return uid, nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instanceAPI serves POST /instances through create and the instance list and
+// details the marker lookup reads.
+type instanceAPI struct {
+	// create answers POST /instances; store records the instance as created.
+	create func(w http.ResponseWriter, req genericInstanceReq, store func() string)
+
+	mu        sync.Mutex
+	instances []genericInstanceReq
+	keys      []string
+}
+
+func (a *instanceAPI) uid(i int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1)
+}
+
+func (a *instanceAPI) posts() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.keys)
+}
+
+func (a *instanceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/instances":
+		var req genericInstanceReq
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		a.keys = append(a.keys, r.Header.Get(IdempotencyKeyHeader))
+		a.create(w, req, func() string {
+			a.instances = append(a.instances, req)
+			return a.uid(len(a.instances) - 1)
+		})
+	case r.Method == "GET" && r.URL.Path == "/instances":
+		results := []map[string]interface{}{}
+		if r.URL.Query().Get("page") == "1" {
+			for i, inst := range a.instances {
+				results = append(results, map[string]interface{}{"instanceUid": a.uid(i), "displayName": inst.DisplayName, "serviceId": inst.ServiceId})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/instances/"):
+		for i, inst := range a.instances {
+			if r.URL.Path == "/instances/"+a.uid(i) {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"instance": map[string]interface{}{
+					"instanceUid": a.uid(i), "serviceId": inst.ServiceId, "displayName": inst.DisplayName, "descr": inst.Descr,
+				}})
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newInstanceAPI(t *testing.T, create func(w http.ResponseWriter, req genericInstanceReq, store func() string)) (*instanceAPI, *UniversalClient) {
+	t.Helper()
+	delay := createInstanceRetryDelay
+	createInstanceRetryDelay = time.Millisecond
+	t.Cleanup(func() { createInstanceRetryDelay = delay })
+
+	api := &instanceAPI{create: create}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	return api, &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+}
+
+func created(w http.ResponseWriter, store func() string) {
+	w.Header().Set("Location", "./"+store())
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestPostInstance(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		create func(call int, w http.ResponseWriter, store func() string)
+		posts  int
+		err    string
+	}{
+		{
+			name:   "location",
+			create: func(_ int, w http.ResponseWriter, store func() string) { created(w, store) },
+			posts:  1,
+		},
+		{
+			name: "just id body",
+			create: func(_ int, w http.ResponseWriter, store func() string) {
+				fmt.Fprintf(w, "%q", store())
+			},
+			posts: 1,
+		},
+		{
+			name: "instanceUid body",
+			create: func(_ int, w http.ResponseWriter, store func() string) {
+				fmt.Fprintf(w, `{"instanceUid":%q}`, store())
+			},
+			posts: 1,
+		},
+		{
+			// The marker in descr identifies the instance.
+			name: "no uid in response",
+			create: func(_ int, w http.ResponseWriter, store func() string) {
+				store()
+				w.WriteHeader(http.StatusCreated)
+			},
+			posts: 1,
+		},
+		{
+			// The first request created the instance but failed: the retry
+			// finds it instead of posting again.
+			name: "5xx after create",
+			create: func(call int, w http.ResponseWriter, store func() string) {
+				store()
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			posts: 1,
+		},
+		{
+			name: "5xx before create",
+			create: func(call int, w http.ResponseWriter, store func() string) {
+				if call == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				created(w, store)
+			},
+			posts: 2,
+		},
+		{
+			name: "429",
+			create: func(call int, w http.ResponseWriter, store func() string) {
+				if call == 1 {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				created(w, store)
+			},
+			posts: 2,
+		},
+		{
+			name: "4xx is not retried",
+			create: func(_ int, w http.ResponseWriter, _ func() string) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			posts: 1,
+			err:   "API error 400",
+		},
+		{
+			name: "attempts exhausted",
+			create: func(_ int, w http.ResponseWriter, _ func() string) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			posts: createInstanceAttempts,
+			err:   "API error 500",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			api, client := newInstanceAPI(t, func(w http.ResponseWriter, _ genericInstanceReq, store func() string) {
+				calls++
+				tc.create(calls, w, store)
+			})
+			uid, err := client.postInstance(context.Background(), 7, "db")
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got %q, %v, want error %q", uid, err, tc.err)
+				}
+			} else if err != nil || uid != api.uid(0) {
+				t.Fatalf("got %q, %v, want %s", uid, err, api.uid(0))
+			}
+			if len(api.instances) > 1 {
+				t.Errorf("%d instances created", len(api.instances))
+			}
+			if got := api.posts(); got != tc.posts {
+				t.Errorf("%d POST /instances, want %d", got, tc.posts)
+			}
+			for _, key := range api.keys {
+				if key == "" || key != api.keys[0] {
+					t.Errorf("idempotency keys %v, want one key on every attempt", api.keys)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Only the caller's own marker is reused: an instance with the same name
+// created by someone else is not taken for this request's.
+func TestPostInstanceIgnoresOtherMarkers(t *testing.T) {
+	calls := 0
+	api, client := newInstanceAPI(t, func(w http.ResponseWriter, req genericInstanceReq, store func() string) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		created(w, store)
+	})
+	api.instances = append(api.instances, genericInstanceReq{ServiceId: 7, DisplayName: "db", Descr: instanceDescr + " " + idempotencyMarker("other")})
+
+	uid, err := client.postInstance(context.Background(), 7, "db")
+	if err != nil || uid != api.uid(1) {
+		t.Fatalf("got %q, %v, want the new instance %s", uid, err, api.uid(1))
+	}
+}
+
+func TestPostInstanceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api, client := newInstanceAPI(t, func(w http.ResponseWriter, _ genericInstanceReq, _ func() string) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.postInstance(ctx, 7, "db"); err == nil {
+		t.Fatal("got no error")
+	}
+	if got := api.posts(); got != 1 {
+		t.Errorf("%d POST /instances after cancel, want 1", got)
+	}
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestLimits bounds the load one provider process puts on the API.
+// Zero values disable the corresponding limit.
+type RequestLimits struct {
+	// RequestsPerSecond is the token bucket refill rate.
+	RequestsPerSecond float64
+	// Burst is the token bucket size (at least 1 when a rate is set).
+	Burst int
+	// MaxConcurrent caps requests in flight, counted until the body is closed.
+	MaxConcurrent int
+}
+
+// LimitTransport wraps base so that every request made through it, by any
+// resource, waits for a rate limiter token and a concurrency slot.
+func LimitTransport(base http.RoundTripper, limits RequestLimits) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &limitedTransport{base: base}
+	if limits.RequestsPerSecond > 0 {
+		burst := limits.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		t.bucket = &tokenBucket{rate: limits.RequestsPerSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+	}
+	if limits.MaxConcurrent > 0 {
+		t.slots = make(chan struct{}, limits.MaxConcurrent)
+	}
+	return t
+}
+
+type limitedTransport struct {
+	base   http.RoundTripper
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if t.slots != nil {
+			<-t.slots
+		}
+	}
+	if t.bucket != nil {
+		if err := t.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes one token, sleeping until it is available.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// pollGroup coalesces identical concurrent polls: callers with the same key
+// share the result of one request.
+type pollGroup struct {
+	mu    sync.Mutex
+	calls map[string]*pollCall
+}
+
+type pollCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+	// waiters is the number of callers sharing the result.
+	waiters int
+}
+
+// do runs fn once per key at a time. fn runs without the caller's
+// cancellation so that a cancelled first caller does not fail the others;
+// each caller still stops waiting when its own ctx is done.
+func (g *pollGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*pollCall{}
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &pollCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go func() {
+			call.val, call.err = fn(context.WithoutCancel(ctx))
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func okResponse(*http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func newRequest(t *testing.T, ctx context.Context) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://nubes.test/instances", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// inFlight is the number of concurrency slots taken.
+func inFlight(rt http.RoundTripper) int {
+	return len(rt.(*limitedTransport).slots)
+}
+
+func TestLimitTransportReleasesSlotOnError(t *testing.T) {
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	rt := LimitTransport(failing, RequestLimits{MaxConcurrent: 1})
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := rt.RoundTrip(newRequest(t, ctx))
+		cancel()
+		if err == nil || errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("request %d: got %v, want the transport error", i, err)
+		}
+	}
+	if n := inFlight(rt); n != 0 {
+		t.Errorf("%d slots still taken", n)
+	}
+}
+
+func TestLimitTransportReleasesSlotOnClose(t *testing.T) {
+	rt := LimitTransport(roundTripFunc(okResponse), RequestLimits{MaxConcurrent: 1})
+
+	resp, err := rt.RoundTrip(newRequest(t, context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := rt.RoundTrip(newRequest(t, ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second request with the body open: got %v, want deadline exceeded", err)
+	}
+
+	resp.Body.Close()
+	resp.Body.Close()
+	if n := inFlight(rt); n != 0 {
+		t.Fatalf("%d slots still taken after close", n)
+	}
+	resp, err = rt.RoundTrip(newRequest(t, context.Background()))
+	if err != nil {
+		t.Fatalf("request after close: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestLimitTransportReleasesSlotOnRateWait(t *testing.T) {
+	rt := LimitTransport(roundTripFunc(okResponse), RequestLimits{RequestsPerSecond: 0.01, Burst: 1, MaxConcurrent: 1})
+
+	resp, err := rt.RoundTrip(newRequest(t, context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if _, err := rt.RoundTrip(newRequest(t, ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want deadline exceeded while waiting for a token", err)
+	}
+	if d := time.Since(started); d > time.Second {
+		t.Errorf("token wait ignored the context: returned after %s", d)
+	}
+	if n := inFlight(rt); n != 0 {
+		t.Errorf("%d slots still taken after the token wait failed", n)
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	b := &tokenBucket{rate: 100, burst: 2, tokens: 2, last: time.Now()}
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two tokens from the burst, two more at 100/s.
+	if d := time.Since(started); d < 15*time.Millisecond {
+		t.Errorf("4 tokens in %s, want the last 2 to wait for refill", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := &tokenBucket{rate: 0.01, burst: 1, tokens: 0, last: time.Now()}
+	if err := slow.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context canceled", err)
+	}
+}
+
+// Lookups make further requests while paging; with more callers than slots
+// they must still all finish.
+func TestLimitTransportNestedLookups(t *testing.T) {
+	const uid = "00000000-0000-0000-0000-000000000001"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/instances" && r.URL.Query().Get("page") == "1":
+			fmt.Fprint(w, `{"results":[{"instanceUid":"00000000-0000-0000-0000-000000000002","displayName":"other","serviceId":1}]}`)
+		case r.URL.Path == "/instances" && r.URL.Query().Get("page") == "2":
+			fmt.Fprintf(w, `{"results":[{"instanceUid":%q,"displayName":"db","serviceId":1}]}`, uid)
+		case r.URL.Path == "/instances":
+			fmt.Fprint(w, `{"results":[]}`)
+		case r.URL.Path == "/instances/"+uid:
+			fmt.Fprintf(w, `{"instance":{"instanceUid":%q,"explainedStatus":"running"}}`, uid)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	const maxConcurrent = 2
+	client := &UniversalClient{
+		HttpClient:  &http.Client{Transport: LimitTransport(srv.Client().Transport, RequestLimits{MaxConcurrent: maxConcurrent})},
+		ApiEndpoint: srv.URL,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2*maxConcurrent)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			state, err := client.FindInstanceByDisplayName(ctx, 1, "db")
+			if err == nil && (state == nil || state.InstanceUid != uid) {
+				err = fmt.Errorf("got %+v, want instance %s", state, uid)
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("lookup %d: %v", i, err)
+		}
+	}
+	if n := inFlight(client.HttpClient.Transport); n != 0 {
+		t.Errorf("%d slots still taken", n)
+	}
+}
+
+// waiters returns the number of callers sharing the poll in flight for key.
+func (g *pollGroup) waiters(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if call, ok := g.calls[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
+
+// Concurrent waiters on one instance share a single GET, and a waiter that
+// gives up does not fail the others.
+func TestPollInstanceStateSharesRequest(t *testing.T) {
+	const uid = "00000000-0000-0000-0000-000000000001"
+	var (
+		mu   sync.Mutex
+		gets int
+	)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/instances/"+uid {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		gets++
+		mu.Unlock()
+		<-release
+		fmt.Fprintf(w, `{"instance":{"instanceUid":%q,"explainedStatus":"running"}}`, uid)
+	}))
+	defer srv.Close()
+	client := &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	const waiters = 5
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() {
+		_, err := client.pollInstanceState(cancelled, uid)
+		cancelledErr <- err
+	}()
+	var wg sync.WaitGroup
+	errs := make([]error, waiters-1)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			state, err := client.pollInstanceState(context.Background(), uid)
+			if err == nil && state.InstanceUid != uid {
+				err = fmt.Errorf("got %+v, want instance %s", state, uid)
+			}
+			errs[i] = err
+		}(i)
+	}
+	for client.polls.waiters("instance/"+uid) < waiters {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-cancelledErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled waiter: got %v, want context canceled", err)
+	}
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("waiter %d: %v", i, err)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("got %d GET requests, want 1", gets)
+	}
+}
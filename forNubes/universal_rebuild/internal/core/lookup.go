@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAmbiguousInstance is returned when several instances share a display name.
+var ErrAmbiguousInstance = errors.New("several instances match")
+
+// InstanceInfo is an existing instance as seen by a data source.
+type InstanceInfo struct {
+	InstanceUid     string
+	DisplayName     string
+	ServiceId       int
+	ExplainedStatus string
+	// Params holds the current paramValue of each svcOperationCfsParamId.
+	Params map[int]string
+}
+
+// LookupInstance finds a non-deleted instance of serviceId by UID or, when
+// instanceUid is empty, by its exact display name, and reads its current
+// params (GET /instances/{uid}?fields=cfsParams).
+func (c *UniversalClient) LookupInstance(ctx context.Context, serviceId int, instanceUid string, displayName string) (_ *InstanceInfo, err error) {
+	ctx, span := startSpan(ctx, "nubes.lookup_instance", attrServiceID.Int(serviceId))
+	defer func() { endSpan(span, err) }()
+
+	instanceUid = strings.TrimSpace(instanceUid)
+	if instanceUid == "" {
+		uids, err := c.FindInstanceUidsByDisplayName(ctx, serviceId, displayName)
+		if err != nil {
+			return nil, err
+		}
+		switch len(uids) {
+		case 0:
+			return nil, fmt.Errorf("%w: no instance of serviceId=%d with displayName=%s", ErrInstanceNotFound, serviceId, displayName)
+		case 1:
+			instanceUid = uids[0]
+		default:
+			return nil, fmt.Errorf("%w: displayName=%s of serviceId=%d: %s", ErrAmbiguousInstance, displayName, serviceId, strings.Join(uids, ", "))
+		}
+	}
+	span.SetAttributes(attrInstanceUID.String(instanceUid))
+
+	respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instances/%s?fields=cfsParams", instanceUid), nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+			return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceUid)
+		}
+		return nil, err
+	}
+	var res struct {
+		Instance struct {
+			InstanceUid     string              `json:"instanceUid"`
+			DisplayName     string              `json:"displayName"`
+			ServiceId       int                 `json:"serviceId"`
+			ExplainedStatus string              `json:"explainedStatus"`
+			IsDeleted       bool                `json:"isDeleted"`
+			CfsParams       []universalCfsParam `json:"cfsParams"`
+		} `json:"instance"`
+	}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse instance %s: %w", instanceUid, err)
+	}
+	inst := res.Instance
+	if inst.IsDeleted || strings.EqualFold(strings.TrimSpace(inst.ExplainedStatus), "deleted") {
+		return nil, fmt.Errorf("%w: %s is deleted", ErrInstanceNotFound, instanceUid)
+	}
+	if inst.ServiceId != serviceId {
+		return nil, fmt.Errorf("%w: %s has serviceId=%d, expected %d", ErrWrongService, instanceUid, inst.ServiceId, serviceId)
+	}
+
+	info := &InstanceInfo{
+		InstanceUid:     instanceUid,
+		DisplayName:     inst.DisplayName,
+		ServiceId:       inst.ServiceId,
+		ExplainedStatus: inst.ExplainedStatus,
+		Params:          make(map[int]string, len(inst.CfsParams)),
+	}
+	for _, p := range inst.CfsParams {
+		if p.ParamValue != nil {
+			info.Params[p.SvcOperationCfsParamId] = *p.ParamValue
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// OperationLog records instanceOperation UIDs started by the client, keyed by
+// action (create, modify, ...), so callers can read operation results later.
+// It also remembers the created instance and an operation left running when
+// the wait was interrupted.
+type OperationLog struct {
+	mu          sync.Mutex
+	uids        map[string]string
+	instanceUid string
+	pending     string
+}
+
+type operationLogKey struct{}
+
+// WithOperationLog attaches a new OperationLog to ctx.
+func WithOperationLog(ctx context.Context) (context.Context, *OperationLog) {
+	l := &OperationLog{uids: map[string]string{}}
+	return context.WithValue(ctx, operationLogKey{}, l), l
+}
+
+// Last returns the UID of the last operation started for action, or "".
+func (l *OperationLog) Last(action string) string {
+	if l == nil {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.uids[strings.ToLower(action)]
+}
+
+// Pending returns the instance created in this call (if any) and the UID of
+// an operation that was still running when waiting stopped.
+func (l *OperationLog) Pending() (instanceUid string, opUid string) {
+	if l == nil {
+		return "", ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.instanceUid, l.pending
+}
+
+func operationLogFromContext(ctx context.Context) *OperationLog {
+	l, _ := ctx.Value(operationLogKey{}).(*OperationLog)
+	return l
+}
+
+func recordInstance(ctx context.Context, instanceUid string) {
+	if l := operationLogFromContext(ctx); l != nil {
+		l.mu.Lock()
+		l.instanceUid = instanceUid
+		l.mu.Unlock()
+	}
+}
+
+func markOperationPending(ctx context.Context, opUid string) {
+	if l := operationLogFromContext(ctx); l != nil {
+		l.mu.Lock()
+		l.pending = opUid
+		l.mu.Unlock()
+	}
+}
+
+func recordOperation(ctx context.Context, action string, opUid string) {
+	l, ok := ctx.Value(operationLogKey{}).(*OperationLog)
+	if !ok || opUid == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.uids[strings.ToLower(action)] = opUid
+}
+
+// GetInstanceDetails returns the raw `instance` object of GET /instances/{uid}.
+func (c *UniversalClient) GetInstanceDetails(ctx context.Context, instanceUid string) (map[string]interface{}, error) {
+	respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instances/%s", instanceUid), nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Instance map[string]interface{} `json:"instance"`
+	}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse instance %s: %w", instanceUid, err)
+	}
+	return res.Instance, nil
+}
+
+// GetOperationDetails returns the raw `instanceOperation` object including cfsParams.
+func (c *UniversalClient) GetOperationDetails(ctx context.Context, opUid string) (map[string]interface{}, error) {
+	respBody, _, err := c.doRequest(ctx, "GET", fmt.Sprintf("/instanceOperations/%s?fields=cfsParams", opUid), nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		InstanceOperation map[string]interface{} `json:"instanceOperation"`
+	}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse operation %s: %w", opUid, err)
+	}
+	return res.InstanceOperation, nil
+}
+
+// LookupPath walks a dot-separated path through decoded JSON. A segment may
+// select an element of an array of objects by field value:
+//
+//	cfsParams[svcOperationCfsParam=password].paramValue
+func LookupPath(obj interface{}, path string) (interface{}, bool) {
+	cur := obj
+	for _, seg := range strings.Split(path, ".") {
+		key, selector, hasSelector := strings.Cut(seg, "[")
+		if key != "" {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = m[key]; !ok {
+				return nil, false
+			}
+		}
+		if !hasSelector {
+			continue
+		}
+		field, want, ok := strings.Cut(strings.TrimSuffix(selector, "]"), "=")
+		if !ok {
+			return nil, false
+		}
+		items, ok := cur.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		found := false
+		for _, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if fmt.Sprint(m[field]) == want {
+				cur, found = m, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return cur, cur != nil
+}
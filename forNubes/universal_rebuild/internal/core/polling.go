@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PollPolicy controls how often operation and instance status is polled.
+// The completion criteria (dtFinish) do not depend on it.
+type PollPolicy struct {
+	// Initial is the delay before the first check.
+	Initial time.Duration
+	// Max caps the delay between checks.
+	Max time.Duration
+	// Multiplier grows the delay after every check without a server hint.
+	Multiplier float64
+	// Timeout overrides the operation timeout when set.
+	Timeout time.Duration
+}
+
+// DefaultPollPolicy checks quickly first and backs off to 30s.
+var DefaultPollPolicy = PollPolicy{
+	Initial:    time.Second,
+	Max:        30 * time.Second,
+	Multiplier: 1.5,
+}
+
+type pollPolicyKey struct{}
+
+// WithPollPolicy sets a per-service polling policy (YAML `polling`). Zero
+// fields fall back to DefaultPollPolicy.
+func WithPollPolicy(ctx context.Context, p PollPolicy) context.Context {
+	return context.WithValue(ctx, pollPolicyKey{}, p)
+}
+
+func pollPolicyFromContext(ctx context.Context) PollPolicy {
+	p, _ := ctx.Value(pollPolicyKey{}).(PollPolicy)
+	if p.Initial <= 0 {
+		p.Initial = DefaultPollPolicy.Initial
+	}
+	if p.Max <= 0 {
+		p.Max = DefaultPollPolicy.Max
+	}
+	if p.Max < p.Initial {
+		p.Max = p.Initial
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultPollPolicy.Multiplier
+	}
+	return p
+}
+
+// backoff yields delays between polls: Initial, then growing by Multiplier up
+// to Max. A server hint (time left) replaces the growth when present.
+type backoff struct {
+	policy PollPolicy
+	delay  time.Duration
+}
+
+func newBackoff(p PollPolicy) *backoff {
+	return &backoff{policy: p, delay: p.Initial}
+}
+
+func (b *backoff) first() time.Duration {
+	return b.policy.Initial
+}
+
+func (b *backoff) next(hint time.Duration) time.Duration {
+	if hint > 0 {
+		// Check again at about half the remaining time, so the finish is not
+		// overshot when the estimate is optimistic.
+		b.delay = hint / 2
+	} else {
+		b.delay = time.Duration(float64(b.delay) * b.policy.Multiplier)
+	}
+	if b.delay < b.policy.Initial {
+		b.delay = b.policy.Initial
+	}
+	if b.delay > b.policy.Max {
+		b.delay = b.policy.Max
+	}
+	return b.delay
+}
+
+// operationProgress holds optional progress fields of an instanceOperation.
+// They are decoded leniently: a missing or malformed field only disables the hint.
+type operationProgress struct {
+	InstanceOperation struct {
+		Progress json.RawMessage `json:"progress"`
+		Eta      json.RawMessage `json:"eta"`
+	} `json:"instanceOperation"`
+}
+
+// operationHint estimates the time left from the server ETA (seconds or an
+// RFC 3339 timestamp) or from the progress percentage and elapsed time.
+func operationHint(respBody []byte, elapsed time.Duration) time.Duration {
+	var p operationProgress
+	if err := json.Unmarshal(respBody, &p); err != nil {
+		return 0
+	}
+	if eta, ok := parseEta(p.InstanceOperation.Eta); ok {
+		return eta
+	}
+	if pct, ok := parseNumber(p.InstanceOperation.Progress); ok && pct > 0 && pct < 100 {
+		return time.Duration(float64(elapsed) * (100 - pct) / pct)
+	}
+	return 0
+}
+
+func parseEta(raw json.RawMessage) (time.Duration, bool) {
+	if secs, ok := parseNumber(raw); ok && secs > 0 {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(s)); err == nil {
+			if left := time.Until(t); left > 0 {
+				return left, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func parseNumber(raw json.RawMessage) (float64, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+	var f float64
+	if err := json.Unmarshal(raw, &f); err == nil {
+		return f, true
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff(PollPolicy{Initial: time.Second, Max: 4 * time.Second, Multiplier: 2})
+	var got []time.Duration
+	got = append(got, b.first())
+	for i := 0; i < 4; i++ {
+		got = append(got, b.next(0))
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("delays %v, want %v", got, want)
+	}
+
+	// A server hint replaces the growth, within Initial..Max.
+	for hint, want := range map[time.Duration]time.Duration{
+		6 * time.Second:        3 * time.Second,
+		time.Second:            time.Second,
+		time.Minute:            4 * time.Second,
+		500 * time.Millisecond: time.Second,
+	} {
+		if got := b.next(hint); got != want {
+			t.Errorf("hint %s: delay %s, want %s", hint, got, want)
+		}
+	}
+}
+
+func TestPollPolicyDefaults(t *testing.T) {
+	p := pollPolicyFromContext(WithPollPolicy(context.Background(), PollPolicy{Initial: time.Minute}))
+	if p.Initial != time.Minute || p.Max != time.Minute || p.Multiplier != DefaultPollPolicy.Multiplier {
+		t.Errorf("got %+v", p)
+	}
+	if p := pollPolicyFromContext(context.Background()); p != DefaultPollPolicy {
+		t.Errorf("got %+v, want the default %+v", p, DefaultPollPolicy)
+	}
+}
+
+func TestOperationHint(t *testing.T) {
+	for body, want := range map[string]time.Duration{
+		`{"instanceOperation":{"eta":30}}`:         30 * time.Second,
+		`{"instanceOperation":{"eta":"12"}}`:       12 * time.Second,
+		`{"instanceOperation":{"progress":25}}`:    30 * time.Second,
+		`{"instanceOperation":{"progress":"50%"}}`: 10 * time.Second,
+		`{"instanceOperation":{"progress":100}}`:   0,
+		`{"instanceOperation":{"eta":"soon"}}`:     0,
+		`not json`:                                 0,
+	} {
+		if got := operationHint([]byte(body), 10*time.Second); got != want {
+			t.Errorf("%s: hint %s, want %s", body, got, want)
+		}
+	}
+}
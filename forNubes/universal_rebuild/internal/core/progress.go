@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// operationLogTailLines is how many trailing log lines go into an error.
+const operationLogTailLines = 20
+
+// Optional instanceOperation fields describing progress. Not every service
+// fills them; the first present key wins.
+var (
+	operationStageKeys = []string{"stage", "currentStage", "step"}
+	operationLogKeys   = []string{"log", "operationLog", "logText"}
+)
+
+// operationReporter turns successive status polls into tflog INFO messages:
+// stage and progress changes, new log lines and a final summary.
+type operationReporter struct {
+	opUid    string
+	started  time.Time
+	stage    string
+	progress string
+	stages   []string
+	log      string
+}
+
+func newOperationReporter(opUid string) *operationReporter {
+	return &operationReporter{opUid: opUid, started: time.Now()}
+}
+
+func (r *operationReporter) observe(ctx context.Context, respBody []byte) {
+	var res struct {
+		InstanceOperation map[string]interface{} `json:"instanceOperation"`
+	}
+	if err := json.Unmarshal(respBody, &res); err != nil || res.InstanceOperation == nil {
+		return
+	}
+	op := res.InstanceOperation
+
+	stage := firstString(op, operationStageKeys)
+	progress := ""
+	if v, ok := op["progress"]; ok && v != nil {
+		progress = fmt.Sprint(v)
+	}
+	if stage != r.stage || progress != r.progress {
+		if stage != "" && stage != r.stage {
+			r.stages = append(r.stages, stage)
+		}
+		r.stage, r.progress = stage, progress
+		if stage != "" || progress != "" {
+			tflog.Info(ctx, "operation progress", map[string]interface{}{
+				"operation": r.opUid,
+				"stage":     stage,
+				"progress":  progress,
+				"elapsed":   time.Since(r.started).Round(time.Second).String(),
+			})
+		}
+	}
+
+	if log := firstString(op, operationLogKeys); log != "" && log != r.log {
+		added := log
+		if strings.HasPrefix(log, r.log) {
+			added = log[len(r.log):]
+		}
+		r.log = log
+		if added = strings.TrimSpace(added); added != "" {
+			tflog.Info(ctx, "operation log", map[string]interface{}{
+				"operation": r.opUid,
+				"log":       added,
+			})
+		}
+	}
+}
+
+// finish logs the summary of the operation.
+func (r *operationReporter) finish(ctx context.Context, successful bool) {
+	tflog.Info(ctx, "operation finished", map[string]interface{}{
+		"operation":  r.opUid,
+		"successful": successful,
+		"duration":   time.Since(r.started).Round(time.Second).String(),
+		"stages":     strings.Join(r.stages, " -> "),
+	})
+}
+
+// logTail returns the last lines of the operation log seen so far.
+func (r *operationReporter) logTail() string {
+	lines := strings.Split(strings.TrimRight(r.log, "\n"), "\n")
+	if len(lines) > operationLogTailLines {
+		lines = lines[len(lines)-operationLogTailLines:]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func firstString(m map[string]interface{}, keys []string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && strings.TrimSpace(s) != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// operationFailedError reports a failed operation with its errorLog and the
+// tail of the operation log, when the log adds anything.
+func operationFailedError(opUid string, errorLog string, logTail string) error {
+	errorLog = strings.TrimSpace(errorLog)
+	msg := fmt.Sprintf("operation %s failed", opUid)
+	if errorLog != "" {
+		msg += ": " + errorLog
+	}
+	if logTail != "" && !strings.Contains(errorLog, logTail) {
+		msg += "\n\nlast operation log lines:\n" + logTail
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// scriptedOperation answers successive polls of any operation with the
+// statuses in order, repeating the last one, and counts cancel requests.
+type scriptedOperation struct {
+	statuses []string
+	// onPoll, if set, runs before the n-th poll (1-based) is answered.
+	onPoll func(n int)
+
+	mu      sync.Mutex
+	polls   int
+	cancels int
+}
+
+func (s *scriptedOperation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/cancel"):
+		s.cancels++
+		w.WriteHeader(http.StatusOK)
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/instanceOperations/"):
+		s.polls++
+		if s.onPoll != nil {
+			s.onPoll(s.polls)
+		}
+		i := s.polls - 1
+		if i >= len(s.statuses) {
+			i = len(s.statuses) - 1
+		}
+		w.Write([]byte(`{"instanceOperation":` + s.statuses[i] + `}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// logEntries decodes the JSON lines written by a tflogtest root logger.
+func logEntries(t *testing.T, logs *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	sc := bufio.NewScanner(logs)
+	for sc.Scan() {
+		var e map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("invalid log line %s: %v", sc.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// progressLine renders an entry as "<message> key=value ..." for the keys
+// the reporter sets, leaving out timing.
+func progressLine(e map[string]interface{}) string {
+	line := e["@message"].(string)
+	for _, k := range []string{"stage", "progress", "log", "successful", "stages"} {
+		switch v := e[k].(type) {
+		case string:
+			line += fmt.Sprintf(" %s=%q", k, v)
+		case bool:
+			line += fmt.Sprintf(" %s=%t", k, v)
+		}
+	}
+	return line
+}
+
+func waitWithLogs(t *testing.T, ctx context.Context, api *scriptedOperation) ([]string, error) {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	client := &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	var logs bytes.Buffer
+	ctx = tflogtest.RootLogger(WithPollPolicy(ctx, PollPolicy{Initial: time.Millisecond}), &logs)
+	err := client.waitForOperationFinish(ctx, "op-1", time.Minute)
+
+	var lines []string
+	for _, e := range logEntries(t, &logs) {
+		if e["operation"] == "op-1" {
+			lines = append(lines, progressLine(e))
+		}
+	}
+	return lines, err
+}
+
+func assertLines(t *testing.T, got []string, want []string) {
+	t.Helper()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got log:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWaitReportsProgress(t *testing.T) {
+	api := &scriptedOperation{statuses: []string{
+		`{"isInProgress":true}`,
+		`{"isInProgress":true,"stage":"provisioning","progress":10,"log":"allocating disk\n"}`,
+		// Unchanged: no new events.
+		`{"isInProgress":true,"stage":"provisioning","progress":10,"log":"allocating disk\n"}`,
+		`{"isInProgress":true,"currentStage":"configuring","progress":60,"log":"allocating disk\nstarting vm\n"}`,
+		`{"dtFinish":"2024-01-01T00:00:00Z","isSuccessful":true,"stage":"configuring","progress":100,"log":"allocating disk\nstarting vm\n"}`,
+	}}
+	lines, err := waitWithLogs(t, context.Background(), api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLines(t, lines, []string{
+		`operation progress stage="provisioning" progress="10"`,
+		`operation log log="allocating disk"`,
+		`operation progress stage="configuring" progress="60"`,
+		`operation log log="starting vm"`,
+		`operation progress stage="configuring" progress="100"`,
+		`operation finished successful=true stages="provisioning -> configuring"`,
+	})
+}
+
+func TestWaitFailedIncludesLogTail(t *testing.T) {
+	api := &scriptedOperation{statuses: []string{
+		`{"isInProgress":true,"step":"install","log":"installing\n"}`,
+		`{"dtFinish":"2024-01-01T00:00:00Z","isSuccessful":false,"errorLog":"exit code 1","log":"installing\npackage foo not found\n"}`,
+	}}
+	lines, err := waitWithLogs(t, context.Background(), api)
+	if err == nil || !strings.Contains(err.Error(), "operation op-1 failed: exit code 1") ||
+		!strings.Contains(err.Error(), "last operation log lines:\ninstalling\npackage foo not found") {
+		t.Fatalf("got %v", err)
+	}
+	assertLines(t, lines, []string{
+		`operation progress stage="install" progress=""`,
+		`operation log log="installing"`,
+		`operation log log="package foo not found"`,
+		`operation finished successful=false stages="install"`,
+	})
+}
+
+// Interrupting a running operation cancels it remotely; the progress seen
+// so far is still reported.
+func TestWaitInterruptedCancelsOperation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api := &scriptedOperation{
+		statuses: []string{`{"isInProgress":true,"stage":"provisioning","progress":30}`},
+		onPoll: func(n int) {
+			if n == 2 {
+				cancel()
+			}
+		},
+	}
+	lines, err := waitWithLogs(t, ctx, api)
+	if err == nil || !strings.Contains(err.Error(), "operation op-1 cancelled (remote operation cancelled)") {
+		t.Fatalf("got %v", err)
+	}
+	if api.cancels != 1 {
+		t.Errorf("%d cancel requests, want 1", api.cancels)
+	}
+	assertLines(t, lines, []string{
+		`operation progress stage="provisioning" progress="30"`,
+		`remote operation cancelled`,
+	})
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrInstanceNotFound is returned when a referenced instance does not exist.
+	ErrInstanceNotFound = errors.New("instance not found")
+	// ErrWrongService is returned when a referenced instance belongs to another service.
+	ErrWrongService = errors.New("instance belongs to another service")
+)
+
+type paramRefsKey struct{}
+
+// WithParamRefs declares svcOperationCfsParamId -> refSvcId links known from
+// the service YAML (`ref_service_id`). They are used together with refSvcId
+// reported by the API, so names are resolved even when the API omits it.
+func WithParamRefs(ctx context.Context, refs map[int]int) context.Context {
+	if len(refs) == 0 {
+		return ctx
+	}
+	merged := make(map[int]int, len(refs))
+	if prev, ok := ctx.Value(paramRefsKey{}).(map[int]int); ok {
+		for id, svc := range prev {
+			merged[id] = svc
+		}
+	}
+	for id, svc := range refs {
+		merged[id] = svc
+	}
+	return context.WithValue(ctx, paramRefsKey{}, merged)
+}
+
+func paramRefsFromContext(ctx context.Context) map[int]int {
+	refs, _ := ctx.Value(paramRefsKey{}).(map[int]int)
+	return refs
+}
+
+// ResolveInstanceRef resolves a reference to an instance of refSvcId given
+// either its UUID or its display name, and checks the instance belongs to
+// that service. Resolved names are cached for the lifetime of the client; a
+// cached instance that has since been deleted, or no longer belongs to
+// refSvcId, is evicted and the name looked up again.
+func (c *UniversalClient) ResolveInstanceRef(ctx context.Context, refSvcId int, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if IsUUID(value) {
+		if err := c.checkInstanceRef(ctx, refSvcId, value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	cacheKey := fmt.Sprintf("%d/%s", refSvcId, strings.ToLower(value))
+	if uid, ok := c.refCache.Load(cacheKey); ok {
+		err := c.checkInstanceRef(ctx, refSvcId, uid.(string))
+		if err == nil {
+			return uid.(string), nil
+		}
+		if !errors.Is(err, ErrInstanceNotFound) && !errors.Is(err, ErrWrongService) {
+			return "", err
+		}
+		c.refCache.Delete(cacheKey)
+	}
+	uid, err := c.findInstanceUidByDisplayNameRefSvc(ctx, refSvcId, value)
+	if err != nil {
+		return "", err
+	}
+	if uid == "" {
+		return "", fmt.Errorf("%w: no instance of serviceId=%d with displayName=%s", ErrInstanceNotFound, refSvcId, value)
+	}
+	c.refCache.Store(cacheKey, uid)
+	return uid, nil
+}
+
+// checkInstanceRef checks that instanceUid exists, is not deleted and
+// belongs to refSvcId.
+func (c *UniversalClient) checkInstanceRef(ctx context.Context, refSvcId int, instanceUid string) error {
+	ref, err := c.getInstanceRef(ctx, instanceUid)
+	if err != nil {
+		return err
+	}
+	if ref.IsDeleted {
+		return fmt.Errorf("%w: %s is deleted", ErrInstanceNotFound, instanceUid)
+	}
+	if ref.ServiceId != 0 && ref.ServiceId != refSvcId {
+		return fmt.Errorf("%w: %s has serviceId=%d, expected %d", ErrWrongService, instanceUid, ref.ServiceId, refSvcId)
+	}
+	return nil
+}
+
+type instanceRef struct {
+	InstanceUid string `json:"instanceUid"`
+	ServiceId   int    `json:"serviceId"`
+	IsDeleted   bool   `json:"isDeleted"`
+}
+
+// getInstanceRef reads the instance without the readiness checks of
+// GetInstanceState: a referenced instance may be busy and still valid.
+func (c *UniversalClient) getInstanceRef(ctx context.Context, instanceUid string) (*instanceRef, error) {
+	url := fmt.Sprintf("%s/instances/%s", c.ApiEndpoint, instanceUid)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.ApiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.ApiToken)
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrInstanceNotFound, instanceUid)
+	}
+	if resp.StatusCode != 200 {
+		return nil, statusError(resp)
+	}
+
+	var res struct {
+		Instance instanceRef `json:"instance"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res.Instance, nil
+}
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// refsAPI serves the instance list and details ResolveInstanceRef reads.
+type refsAPI struct {
+	mu sync.Mutex
+	// instances maps instanceUid to its displayName and serviceId.
+	instances map[string]refsInstance
+	lists     int
+}
+
+type refsInstance struct {
+	name      string
+	serviceID int
+}
+
+func (a *refsAPI) set(uid, name string, serviceID int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.instances = map[string]refsInstance{uid: {name, serviceID}}
+}
+
+func (a *refsAPI) listCalls() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.lists
+}
+
+func (a *refsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if r.URL.Path == "/instances" {
+		if r.URL.Query().Get("page") != "1" {
+			fmt.Fprint(w, `{"results":[]}`)
+			return
+		}
+		a.lists++
+		sep := ""
+		fmt.Fprint(w, `{"results":[`)
+		for uid, inst := range a.instances {
+			fmt.Fprintf(w, `%s{"instanceUid":%q,"displayName":%q,"serviceId":%d}`, sep, uid, inst.name, inst.serviceID)
+			sep = ","
+		}
+		fmt.Fprint(w, `]}`)
+		return
+	}
+	for uid, inst := range a.instances {
+		if r.URL.Path == "/instances/"+uid {
+			fmt.Fprintf(w, `{"instance":{"instanceUid":%q,"serviceId":%d}}`, uid, inst.serviceID)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func TestResolveInstanceRefEvictsStaleNames(t *testing.T) {
+	const (
+		oldUID = "00000000-0000-0000-0000-000000000001"
+		newUID = "00000000-0000-0000-0000-000000000002"
+	)
+	api := &refsAPI{}
+	api.set(oldUID, "bucket", 13)
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	c := &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+	ctx := context.Background()
+
+	resolve := func(want string, wantLists int) {
+		t.Helper()
+		uid, err := c.ResolveInstanceRef(ctx, 13, "bucket")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uid != want {
+			t.Errorf("got %s, want %s", uid, want)
+		}
+		if got := api.listCalls(); got != wantLists {
+			t.Errorf("got %d list calls, want %d", got, wantLists)
+		}
+	}
+
+	resolve(oldUID, 1)
+	// A cache hit is only checked, not looked up again.
+	resolve(oldUID, 1)
+
+	// The instance was deleted and recreated under the same name.
+	api.set(newUID, "bucket", 13)
+	resolve(newUID, 2)
+	resolve(newUID, 2)
+
+	// The name now belongs to an instance of another service.
+	api.set(newUID, "bucket", 12)
+	if _, err := c.ResolveInstanceRef(ctx, 13, "bucket"); !errors.Is(err, ErrInstanceNotFound) {
+		t.Errorf("got %v, want ErrInstanceNotFound", err)
+	}
+	if _, ok := c.refCache.Load("13/bucket"); ok {
+		t.Error("stale entry still cached")
+	}
+}
+
+func TestResolveInstanceRefKeepsCacheOnTransientErrors(t *testing.T) {
+	const uid = "00000000-0000-0000-0000-000000000001"
+	api := &refsAPI{}
+	api.set(uid, "bucket", 13)
+	var failing bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	c := &UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	if _, err := c.ResolveInstanceRef(context.Background(), 13, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	failing = true
+	if _, err := c.ResolveInstanceRef(context.Background(), 13, "bucket"); err == nil || errors.Is(err, ErrInstanceNotFound) {
+		t.Errorf("got %v, want the API error", err)
+	}
+	if _, ok := c.refCache.Load("13/bucket"); !ok {
+		t.Error("entry evicted on a transient error")
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redactedValue = "***"
+
+type sensitiveParamsKey struct{}
+
+// WithSensitiveParams marks svcOperationCfsParamIds whose values must never
+// appear in client logs. Generated resources call it for params declared
+// `sensitive` or `write_only` in the service YAML.
+func WithSensitiveParams(ctx context.Context, paramIds ...int) context.Context {
+	if len(paramIds) == 0 {
+		return ctx
+	}
+	ids := make(map[int]struct{}, len(paramIds))
+	if prev, ok := ctx.Value(sensitiveParamsKey{}).(map[int]struct{}); ok {
+		for id := range prev {
+			ids[id] = struct{}{}
+		}
+	}
+	for _, id := range paramIds {
+		ids[id] = struct{}{}
+	}
+	return context.WithValue(ctx, sensitiveParamsKey{}, ids)
+}
+
+func isSensitiveParam(ctx context.Context, paramId int) bool {
+	ids, ok := ctx.Value(sensitiveParamsKey{}).(map[int]struct{})
+	if !ok {
+		return false
+	}
+	_, found := ids[paramId]
+	return found
+}
+
+func redactParamValue(ctx context.Context, paramId int, value string) string {
+	if value != "" && isSensitiveParam(ctx, paramId) {
+		return redactedValue
+	}
+	return value
+}
+
+func logParamSubmit(ctx context.Context, opUid string, paramId int, value string) {
+	tflog.Debug(ctx, "submitting operation param", map[string]interface{}{
+		"instance_operation_uid": opUid,
+		"param_id":               paramId,
+		"value":                  redactParamValue(ctx, paramId, value),
+	})
+}
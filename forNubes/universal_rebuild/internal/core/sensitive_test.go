@@ -0,0 +1,111 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans routes the global tracer provider to a recorder for the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func TestSubmitParamRedactsSensitiveValues(t *testing.T) {
+	const (
+		secret    = "s3cr3t-password"
+		writeOnly = "write-only-token"
+		plain     = "plain-value"
+	)
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var b bytes.Buffer
+		_, _ = b.ReadFrom(r.Body)
+		bodies = append(bodies, b.String())
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	rec := recordSpans(t)
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	// Sensitive and write-only params are marked separately by the resources.
+	ctx = WithSensitiveParams(ctx, 1)
+	ctx = WithSensitiveParams(ctx, 2)
+
+	c := &UniversalClient{
+		HttpClient:  &http.Client{Transport: RequestIDTransport(srv.Client().Transport, "test")},
+		ApiEndpoint: srv.URL,
+	}
+	for id, value := range map[int]string{1: secret, 2: writeOnly, 3: plain} {
+		if err := c.submitParam(ctx, "op-1", id, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The API still receives the real values.
+	sent := strings.Join(bodies, "\n")
+	for _, v := range []string{secret, writeOnly, plain} {
+		if !strings.Contains(sent, v) {
+			t.Errorf("request bodies do not contain %q", v)
+		}
+	}
+
+	out := logs.String()
+	for _, v := range []string{secret, writeOnly} {
+		if strings.Contains(out, v) {
+			t.Errorf("logs contain the sensitive value %q:\n%s", v, out)
+		}
+	}
+	if !strings.Contains(out, plain) {
+		t.Errorf("logs do not contain the plain value %q:\n%s", plain, out)
+	}
+	if got := strings.Count(out, `"value":"`+redactedValue+`"`); got != 2 {
+		t.Errorf("got %d redacted values in logs, want 2:\n%s", got, out)
+	}
+
+	spans := rec.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	for _, s := range spans {
+		for _, kv := range s.Attributes() {
+			if v := kv.Value.Emit(); strings.Contains(v, secret) || strings.Contains(v, writeOnly) {
+				t.Errorf("span %s attribute %s carries a sensitive value", s.Name(), kv.Key)
+			}
+		}
+	}
+}
+
+func TestRedactParamValue(t *testing.T) {
+	ctx := WithSensitiveParams(context.Background(), 5)
+	for _, tc := range []struct {
+		id    int
+		value string
+		want  string
+	}{
+		{5, "secret", redactedValue},
+		{5, "", ""},
+		{6, "visible", "visible"},
+	} {
+		if got := redactParamValue(ctx, tc.id, tc.value); got != tc.want {
+			t.Errorf("redactParamValue(%d, %q) = %q, want %q", tc.id, tc.value, got, tc.want)
+		}
+	}
+	if got := redactParamValue(context.Background(), 5, "secret"); got != "secret" {
+		t.Errorf("without WithSensitiveParams: got %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Spans use the global tracer provider, which stays a no-op unless the
+// provider enables tracing (provider config or OTEL_* env vars).
+const tracerName = "terraform-provider-nubes/core"
+
+// Span attribute keys.
+const (
+	attrServiceID   = attribute.Key("nubes.service_id")
+	attrInstanceUID = attribute.Key("nubes.instance_uid")
+	attrOpUID       = attribute.Key("nubes.operation_uid")
+	attrOperation   = attribute.Key("nubes.operation")
+	attrParamID     = attribute.Key("nubes.param_id")
+)
+
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err (if any) and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
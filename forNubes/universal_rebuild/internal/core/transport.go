@@ -0,0 +1,98 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RequestIDHeader carries a per-request correlation id for support.
+const RequestIDHeader = "X-Request-Id"
+
+// UserAgent builds the User-Agent sent with every API request.
+func UserAgent(providerVersion string, terraformVersion string) string {
+	if providerVersion == "" {
+		providerVersion = "dev"
+	}
+	if terraformVersion == "" {
+		terraformVersion = "unknown"
+	}
+	return fmt.Sprintf("terraform-provider-nubes/%s terraform/%s", providerVersion, terraformVersion)
+}
+
+// RequestIDTransport sets User-Agent and a fresh X-Request-Id on every request
+// and logs each exchange with its request id.
+func RequestIDTransport(base http.RoundTripper, userAgent string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &requestIDTransport{base: base, userAgent: userAgent}
+}
+
+type requestIDTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	req = req.Clone(ctx)
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	id := req.Header.Get(RequestIDHeader)
+	if id == "" {
+		id, _ = uuid.GenerateUUID()
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	started := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"url":        req.URL.Redacted(),
+		"request_id": id,
+		"duration":   time.Since(started).String(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "api request failed", fields)
+		return nil, fmt.Errorf("%w (request id %s)", err, id)
+	}
+	fields["status"] = resp.StatusCode
+	tflog.Debug(ctx, "api request", fields)
+	return resp, nil
+}
+
+// requestID returns the X-Request-Id sent with the request behind resp.
+func requestID(resp *http.Response) string {
+	if resp == nil || resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(RequestIDHeader)
+}
+
+// APIError is an error status returned by the API.
+type APIError struct {
+	StatusCode int
+	RequestID  string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("API error %d (request id %s): %s", e.StatusCode, e.RequestID, e.Body)
+	}
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body)
+}
+
+// statusError reports an unexpected HTTP status with the request id.
+func statusError(resp *http.Response) error {
+	if id := requestID(resp); id != "" {
+		return fmt.Errorf("status %d (request id %s)", resp.StatusCode, id)
+	}
+	return fmt.Errorf("status %d", resp.StatusCode)
+}
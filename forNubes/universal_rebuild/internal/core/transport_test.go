@@ -0,0 +1,121 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestUserAgent(t *testing.T) {
+	for _, tc := range []struct {
+		provider, terraform string
+		want                string
+	}{
+		{"1.2.0", "1.9.5", "terraform-provider-nubes/1.2.0 terraform/1.9.5"},
+		{"", "", "terraform-provider-nubes/dev terraform/unknown"},
+	} {
+		if got := UserAgent(tc.provider, tc.terraform); got != tc.want {
+			t.Errorf("UserAgent(%q, %q) = %q, want %q", tc.provider, tc.terraform, got, tc.want)
+		}
+	}
+}
+
+func TestRequestIDTransport(t *testing.T) {
+	var ids []string
+	var agents []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+		agents = append(agents, r.Header.Get("User-Agent"))
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte("busy"))
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	c := &UniversalClient{
+		HttpClient:  &http.Client{Transport: RequestIDTransport(srv.Client().Transport, "terraform-provider-nubes/test terraform/1.9.5")},
+		ApiEndpoint: srv.URL,
+	}
+
+	if _, _, err := c.doRequest(ctx, "GET", "/ok", nil); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := c.doRequest(ctx, "GET", "/fail", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("got %v, want an APIError", err)
+	}
+
+	if len(ids) != 2 {
+		t.Fatalf("got %d requests, want 2", len(ids))
+	}
+	for i, id := range ids {
+		if _, err := uuid.ParseUUID(id); err != nil {
+			t.Errorf("request %d: X-Request-Id %q is not a UUID", i, id)
+		}
+		if agents[i] != "terraform-provider-nubes/test terraform/1.9.5" {
+			t.Errorf("request %d: User-Agent %q", i, agents[i])
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("requests share the id %s", ids[0])
+	}
+	// The failing request's id is in the error, for support.
+	if apiErr.RequestID != ids[1] || !strings.Contains(err.Error(), ids[1]) {
+		t.Errorf("error %q does not carry request id %s", err, ids[1])
+	}
+	for _, id := range ids {
+		if !strings.Contains(logs.String(), `"request_id":"`+id+`"`) {
+			t.Errorf("request %s not logged:\n%s", id, logs.String())
+		}
+	}
+}
+
+func TestRequestIDTransportKeepsCallerID(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(RequestIDHeader)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	req.Header.Set(RequestIDHeader, "caller-id")
+	resp, err := (&http.Client{Transport: RequestIDTransport(srv.Client().Transport, "")}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got != "caller-id" {
+		t.Errorf("X-Request-Id %q, want caller-id", got)
+	}
+	if requestID(resp) != "caller-id" {
+		t.Errorf("requestID(resp) = %q, want caller-id", requestID(resp))
+	}
+	// The caller's request is not modified.
+	if req.Header.Get("User-Agent") != "" {
+		t.Error("caller request modified")
+	}
+}
+
+func TestRequestIDTransportErrorCarriesID(t *testing.T) {
+	failing := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	req, _ := http.NewRequest("GET", "http://nubes.test/instances", nil)
+	_, err := RequestIDTransport(failing, "").RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "connection refused (request id ") {
+		t.Errorf("got %v", err)
+	}
+}
@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -11,11 +13,15 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_gen"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/http/httpproxy"
 )
 
 var _ provider.Provider = &NubesProvider{}
@@ -27,6 +33,18 @@ type NubesProvider struct {
 type NubesProviderModel struct {
 	ApiEndpoint types.String `tfsdk:"api_endpoint"`
 	ApiToken    types.String `tfsdk:"api_token"`
+
+	VerifyUniqueNames types.Bool `tfsdk:"verify_unique_names"`
+
+	MaxRequestsPerSecond  types.Float64 `tfsdk:"max_requests_per_second"`
+	RequestBurst          types.Int64   `tfsdk:"request_burst"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	ProxyURL types.String `tfsdk:"proxy_url"`
+	NoProxy  types.List   `tfsdk:"no_proxy"`
+
+	TracingExporter types.String `tfsdk:"tracing_exporter"`
+	TracingEndpoint types.String `tfsdk:"tracing_endpoint"`
 }
 
 func New(version string) func() provider.Provider {
@@ -52,6 +70,42 @@ func (p *NubesProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"verify_unique_names": schema.BoolAttribute{
+				MarkdownDescription: "After create, check that no other instance of the service has the same resource_name and fail if one appeared (default false)",
+				Optional:            true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Rate limit for API requests shared by all resources, per second. Unset or 0 means no limit",
+				Optional:            true,
+			},
+			"request_burst": schema.Int64Attribute{
+				MarkdownDescription: "Number of API requests allowed in a burst above max_requests_per_second (defaults to the rate rounded up)",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum API requests in flight at once. Unset or 0 means no limit",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "HTTP(S) proxy for API requests. Defaults to the HTTPS_PROXY/HTTP_PROXY environment variables",
+				Optional:            true,
+			},
+			"no_proxy": schema.ListAttribute{
+				MarkdownDescription: "Hosts, domains (.example.com) or CIDRs reached without a proxy, in addition to the NO_PROXY environment variable. Applies to proxy_url and to the environment proxy",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"tracing_exporter": schema.StringAttribute{
+				MarkdownDescription: "OpenTelemetry trace exporter: `otlp`, `stdout` (written to stderr) or `none`. Defaults to OTEL_TRACES_EXPORTER; tracing is off when neither is set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tracingExporterOTLP, tracingExporterStdout, tracingExporterNone),
+				},
+			},
+			"tracing_endpoint": schema.StringAttribute{
+				MarkdownDescription: "OTLP/HTTP endpoint URL for traces. Defaults to OTEL_EXPORTER_OTLP_TRACES_ENDPOINT/OTEL_EXPORTER_OTLP_ENDPOINT",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -88,23 +142,82 @@ func (p *NubesProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 	transport.ForceAttemptHTTP2 = false
 
+	var noProxy []string
+	resp.Diagnostics.Append(config.NoProxy.ElementsAs(ctx, &noProxy, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := configureProxy(transport, strings.TrimSpace(config.ProxyURL.ValueString()), noProxy); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL", err.Error())
+		return
+	}
+
+	tracingExporter := tracingExporterFromEnv()
+	if !config.TracingExporter.IsNull() {
+		tracingExporter = config.TracingExporter.ValueString()
+	}
+	resp.Diagnostics.Append(setupTracing(ctx, tracingExporter, strings.TrimSpace(config.TracingEndpoint.ValueString()), p.version)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Request limits are opt-in; unset attributes leave requests unthrottled.
+	limits := core.RequestLimits{
+		RequestsPerSecond: config.MaxRequestsPerSecond.ValueFloat64(),
+		Burst:             int(math.Ceil(config.MaxRequestsPerSecond.ValueFloat64())),
+		MaxConcurrent:     int(config.MaxConcurrentRequests.ValueInt64()),
+	}
+	if !config.RequestBurst.IsNull() {
+		limits.Burst = int(config.RequestBurst.ValueInt64())
+	}
+
 	client := &core.UniversalClient{
 		HttpClient: &http.Client{
-			Transport: transport,
+			Transport: core.LimitTransport(core.RequestIDTransport(transport, core.UserAgent(p.version, req.TerraformVersion)), limits),
 			Timeout:   300 * time.Second,
 		},
-		ApiEndpoint: apiEndpoint,
-		ApiToken:    apiToken,
+		ApiEndpoint:       apiEndpoint,
+		ApiToken:          apiToken,
+		VerifyUniqueNames: config.VerifyUniqueNames.ValueBool(),
 	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// configureProxy applies proxy_url and no_proxy to transport. Without either,
+// the transport keeps http.ProxyFromEnvironment.
+func configureProxy(transport *http.Transport, proxyURL string, noProxy []string) error {
+	if proxyURL == "" && len(noProxy) == 0 {
+		return nil
+	}
+	// Start from the environment so that no_proxy alone narrows
+	// HTTPS_PROXY/HTTP_PROXY instead of being ignored.
+	proxyConfig := httpproxy.FromEnvironment()
+	if proxyURL != "" {
+		if _, err := url.Parse(proxyURL); err != nil {
+			return err
+		}
+		proxyConfig.HTTPProxy = proxyURL
+		proxyConfig.HTTPSProxy = proxyURL
+	}
+	if len(noProxy) > 0 {
+		if proxyConfig.NoProxy != "" {
+			noProxy = append([]string{proxyConfig.NoProxy}, noProxy...)
+		}
+		proxyConfig.NoProxy = strings.Join(noProxy, ",")
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(r *http.Request) (*url.URL, error) {
+		return proxyFunc(r.URL)
+	}
+	return nil
+}
+
 func (p *NubesProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return resources_gen.AllResources()
+	return allResources()
 }
 
 func (p *NubesProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return nil
+	return resources_gen.AllDataSources()
 }
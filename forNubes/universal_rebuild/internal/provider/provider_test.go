@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setProxyEnv(t *testing.T, httpsProxy string, noProxy string) {
+	t.Helper()
+	for _, k := range []string{"HTTP_PROXY", "http_proxy", "https_proxy", "no_proxy", "REQUEST_METHOD"} {
+		t.Setenv(k, "")
+	}
+	t.Setenv("HTTPS_PROXY", httpsProxy)
+	t.Setenv("NO_PROXY", noProxy)
+}
+
+func TestConfigureProxy(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		env      string
+		envNo    string
+		proxyURL string
+		noProxy  []string
+		// want maps a request URL to the proxy used, "" for a direct connection.
+		want map[string]string
+	}{
+		{
+			name:     "proxy_url overrides the environment",
+			env:      "http://env-proxy:3128",
+			proxyURL: "http://tf-proxy:8080",
+			want: map[string]string{
+				"https://api.nubes.test/instances": "http://tf-proxy:8080",
+			},
+		},
+		{
+			name:    "no_proxy narrows the environment proxy",
+			env:     "http://env-proxy:3128",
+			noProxy: []string{".internal.test", "10.0.0.0/8"},
+			want: map[string]string{
+				"https://api.nubes.test/instances":    "http://env-proxy:3128",
+				"https://api.internal.test/instances": "",
+				"https://10.1.2.3/instances":          "",
+			},
+		},
+		{
+			name:    "no_proxy is added to NO_PROXY",
+			env:     "http://env-proxy:3128",
+			envNo:   "env-direct.test",
+			noProxy: []string{"tf-direct.test"},
+			want: map[string]string{
+				"https://env-direct.test/instances": "",
+				"https://tf-direct.test/instances":  "",
+				"https://api.nubes.test/instances":  "http://env-proxy:3128",
+			},
+		},
+		{
+			name:     "NO_PROXY applies to proxy_url",
+			envNo:    "env-direct.test",
+			proxyURL: "http://tf-proxy:8080",
+			want: map[string]string{
+				"https://env-direct.test/instances": "",
+				"https://api.nubes.test/instances":  "http://tf-proxy:8080",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			setProxyEnv(t, tc.env, tc.envNo)
+			transport := &http.Transport{}
+			if err := configureProxy(transport, tc.proxyURL, tc.noProxy); err != nil {
+				t.Fatal(err)
+			}
+			for target, want := range tc.want {
+				req, _ := http.NewRequest("GET", target, nil)
+				got, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotURL := ""
+				if got != nil {
+					gotURL = got.String()
+				}
+				if gotURL != want {
+					t.Errorf("%s: proxy %q, want %q", target, gotURL, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureProxyUnset(t *testing.T) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if err := configureProxy(transport, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if transport.Proxy == nil {
+		t.Error("environment proxy removed")
+	}
+	if err := configureProxy(transport, "http://bad host:80", nil); err == nil {
+		t.Error("invalid proxy_url accepted")
+	}
+}
+
+// Requests reach the API through the configured proxy.
+func TestConfigureProxySendsThroughProxy(t *testing.T) {
+	setProxyEnv(t, "", "")
+	var gotHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		gotHost = r.URL.Host
+		io.WriteString(w, "{}")
+	}))
+	defer proxy.Close()
+
+	transport := &http.Transport{}
+	if err := configureProxy(transport, proxy.URL, []string{"direct.nubes.test"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get("http://api.nubes.test/instances")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if gotHost != "api.nubes.test" {
+		t.Errorf("proxy got host %q, want api.nubes.test", gotHost)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !runtime_resources
+
+package provider
+
+import (
+	"terraform-provider-nubes/internal/resources_gen"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// allResources are the resources generated by tools/gen.
+func allResources() []func() resource.Resource {
+	return resources_gen.AllResources()
+}
@@ -0,0 +1,16 @@
+//go:build runtime_resources
+
+package provider
+
+import (
+	"terraform-provider-nubes/internal/resources_gen"
+	"terraform-provider-nubes/internal/resources_runtime"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// allResources serves the services from the embedded YAML; composites and
+// resources with hooks stay generated.
+func allResources() []func() resource.Resource {
+	return resources_runtime.Replace(resources_gen.AllResources(), resources_gen.ServicesWithHooks())
+}
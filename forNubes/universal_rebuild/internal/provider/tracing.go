@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Tracing exporters accepted by tracing_exporter and OTEL_TRACES_EXPORTER.
+const (
+	tracingExporterNone   = "none"
+	tracingExporterOTLP   = "otlp"
+	tracingExporterStdout = "stdout"
+)
+
+var (
+	tracingOnce     sync.Once
+	tracingProvider *sdktrace.TracerProvider
+	// tracingActive is the configuration of the first setup.
+	tracingActive tracingSettings
+	// tracingErr is the outcome of the first setup, returned to every caller
+	// with the same configuration.
+	tracingErr error
+)
+
+type tracingSettings struct {
+	exporter string
+	endpoint string
+}
+
+func (s tracingSettings) String() string {
+	if s.endpoint == "" {
+		return fmt.Sprintf("%q", s.exporter)
+	}
+	return fmt.Sprintf("%q (endpoint %s)", s.exporter, s.endpoint)
+}
+
+// tracingExporterFromEnv maps OTEL_TRACES_EXPORTER to an exporter name.
+// Tracing stays off when the variable is unset.
+func tracingExporterFromEnv() string {
+	switch v := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))); v {
+	case "", tracingExporterNone:
+		return tracingExporterNone
+	case "console":
+		return tracingExporterStdout
+	default:
+		return v
+	}
+}
+
+// setupTracing installs the global tracer provider once per process. The
+// OTLP exporter reads OTEL_EXPORTER_OTLP_* variables; endpoint, when set,
+// overrides them. The stdout exporter writes to stderr, since stdout carries
+// the plugin handshake. A later Configure (another provider block) asking
+// for a different exporter or endpoint gets a warning: the first setup stays.
+func setupTracing(ctx context.Context, exporter, endpoint, version string) diag.Diagnostics {
+	requested := tracingSettings{exporter: exporter, endpoint: endpoint}
+	tracingOnce.Do(func() {
+		tracingActive = requested
+		tracingErr = installTracing(ctx, exporter, endpoint, version)
+	})
+
+	var diags diag.Diagnostics
+	switch {
+	case requested != tracingActive:
+		diags.AddAttributeWarning(path.Root("tracing_exporter"), "Tracing Already Configured",
+			fmt.Sprintf("Tracing is set up once per provider process. It stays %s as configured first; "+
+				"%s from this provider configuration is ignored.", tracingActive, requested))
+	case tracingErr != nil:
+		diags.AddAttributeError(path.Root("tracing_exporter"), "Tracing Setup Failed", tracingErr.Error())
+	}
+	return diags
+}
+
+// installTracing builds the exporter and sets the global tracer provider.
+func installTracing(ctx context.Context, exporter, endpoint, version string) error {
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case tracingExporterNone:
+		return nil
+	case tracingExporterOTLP:
+		var opts []otlptracehttp.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		exp, err = otlptracehttp.New(ctx, opts...)
+	case tracingExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	default:
+		err = fmt.Errorf("unsupported tracing exporter %q (expected otlp, stdout or none)", exporter)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("terraform-provider-nubes"),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return fmt.Errorf("tracing resource: %w", err)
+	}
+	tracingProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp, sdktrace.WithBatchTimeout(time.Second)),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return nil
+}
+
+// ShutdownTracing flushes pending spans. It is a no-op when tracing is off.
+func ShutdownTracing(ctx context.Context) error {
+	if tracingProvider == nil {
+		return nil
+	}
+	return tracingProvider.Shutdown(ctx)
+}
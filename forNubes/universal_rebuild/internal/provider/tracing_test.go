@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+)
+
+// resetTracing undoes setupTracing for the test.
+func resetTracing(t *testing.T) {
+	t.Helper()
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		if tracingProvider != nil {
+			_ = tracingProvider.Shutdown(context.Background())
+			otel.SetTracerProvider(prev)
+		}
+		tracingOnce = sync.Once{}
+		tracingProvider, tracingActive, tracingErr = nil, tracingSettings{}, nil
+	})
+	tracingOnce = sync.Once{}
+	tracingProvider, tracingActive, tracingErr = nil, tracingSettings{}, nil
+}
+
+func TestSetupTracingOncePerProcess(t *testing.T) {
+	resetTracing(t)
+	ctx := context.Background()
+
+	if diags := setupTracing(ctx, tracingExporterStdout, "", "test"); diags.HasError() || diags.WarningsCount() != 0 {
+		t.Fatalf("first setup: %v", diags)
+	}
+	if tracingProvider == nil {
+		t.Fatal("tracer provider not installed")
+	}
+	first := tracingProvider
+
+	// Same settings, e.g. a second provider block without tracing options.
+	if diags := setupTracing(ctx, tracingExporterStdout, "", "test"); len(diags) != 0 {
+		t.Errorf("same settings: %v", diags)
+	}
+
+	for _, tc := range []struct {
+		exporter, endpoint string
+		want               string
+	}{
+		{tracingExporterNone, "", `It stays "stdout" as configured first; "none" from this provider configuration is ignored.`},
+		{tracingExporterOTLP, "http://collector:4318", `"otlp" (endpoint http://collector:4318) from this provider configuration is ignored.`},
+		{tracingExporterStdout, "http://collector:4318", `"stdout" (endpoint http://collector:4318) from this provider configuration is ignored.`},
+	} {
+		diags := setupTracing(ctx, tc.exporter, tc.endpoint, "test")
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Errorf("%s %s: got %v, want one warning", tc.exporter, tc.endpoint, diags)
+			continue
+		}
+		w := diags.Warnings()[0]
+		if w.Summary() != "Tracing Already Configured" || !strings.Contains(w.Detail(), tc.want) {
+			t.Errorf("%s %s: got %q: %q", tc.exporter, tc.endpoint, w.Summary(), w.Detail())
+		}
+		if _, ok := w.(diag.DiagnosticWithPath); !ok {
+			t.Errorf("%s %s: warning not attached to tracing_exporter", tc.exporter, tc.endpoint)
+		}
+	}
+	if tracingProvider != first {
+		t.Error("tracer provider replaced by a later setup")
+	}
+}
+
+func TestSetupTracingError(t *testing.T) {
+	resetTracing(t)
+	ctx := context.Background()
+
+	diags := setupTracing(ctx, "jaeger", "", "test")
+	if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), `unsupported tracing exporter "jaeger"`) {
+		t.Fatalf("got %v", diags)
+	}
+	// Every provider block with the failing settings gets the error.
+	if diags := setupTracing(ctx, "jaeger", "", "test"); !diags.HasError() {
+		t.Error("repeated setup: no error")
+	}
+}
@@ -0,0 +1,156 @@
+package resources_core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// CompositeChild is one service instance of a composite resource
+// (YAML `kind: composite`). Children are processed in declaration order.
+type CompositeChild struct {
+	Name        string
+	ServiceID   int
+	DisplayName string
+	Params      map[int]string
+	// Wiring maps a param ID to the earlier child whose instance id it receives.
+	Wiring map[int]string
+	// Skip leaves the child untouched on update (none of its modify params changed).
+	Skip bool
+	// PollPolicy is the child service's YAML `polling`; the zero value keeps
+	// the policy of the context.
+	PollPolicy core.PollPolicy
+}
+
+func (c CompositeChild) context(ctx context.Context) context.Context {
+	if c.PollPolicy == (core.PollPolicy{}) {
+		return ctx
+	}
+	return core.WithPollPolicy(ctx, c.PollPolicy)
+}
+
+// CompositeID returns the id of the first of children present in ids, or
+// null when none is.
+func CompositeID(ids map[string]string, children ...string) types.String {
+	for _, child := range children {
+		if id := ids[child]; id != "" {
+			return types.StringValue(id)
+		}
+	}
+	return types.StringNull()
+}
+
+// CompositeDisplayName is the display name of a child instance.
+func CompositeDisplayName(resourceName string, child string) string {
+	return resourceName + "-" + child
+}
+
+// CreateComposite creates (or adopts) the children in order and returns their
+// instance ids keyed by child name. If a child fails, the children created by
+// this call are deleted in reverse order; adopted instances are left alone.
+// The returned ids then hold the children the rollback could not delete.
+func CreateComposite(ctx context.Context, client *core.UniversalClient, resumeIfExists bool, children []CompositeChild) (map[string]string, error) {
+	ids := make(map[string]string, len(children))
+	var created []CompositeChild
+	for _, child := range children {
+		params, err := wireParams(child, ids)
+		if err != nil {
+			return ids, err
+		}
+
+		id, adopted, err := createOrAdopt(child.context(ctx), client, child.ServiceID, child.DisplayName, resumeIfExists, params)
+		if id != "" {
+			ids[child.Name] = id
+			if !adopted {
+				created = append(created, child)
+			}
+		}
+		if err != nil {
+			return ids, rollbackComposite(ctx, client, ids, created, fmt.Errorf("%s: %w", child.Name, err))
+		}
+	}
+	return ids, nil
+}
+
+// compositeRollbackTimeout bounds the rollback of a failed composite create.
+// It runs without the caller's cancellation: an interrupted apply is a common
+// cause of the failure, and the created children must still be removed.
+const compositeRollbackTimeout = 30 * time.Minute
+
+func rollbackComposite(ctx context.Context, client *core.UniversalClient, ids map[string]string, created []CompositeChild, cause error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), compositeRollbackTimeout)
+	defer cancel()
+
+	errs := []error{cause}
+	for i := len(created) - 1; i >= 0; i-- {
+		child := created[i]
+		tflog.Warn(ctx, "rolling back composite child", map[string]interface{}{
+			"child":       child.Name,
+			"instanceUid": ids[child.Name],
+		})
+		if err := DeleteResource(child.context(ctx), client, ids[child.Name], "delete"); err != nil {
+			errs = append(errs, fmt.Errorf("rollback %s (%s): %w", child.Name, ids[child.Name], err))
+			continue
+		}
+		delete(ids, child.Name)
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateComposite runs modify on the changed children in order.
+func UpdateComposite(ctx context.Context, client *core.UniversalClient, ids map[string]string, children []CompositeChild) error {
+	for _, child := range children {
+		if child.Skip {
+			continue
+		}
+		params, err := wireParams(child, ids)
+		if err != nil {
+			return err
+		}
+		if err := UpdateResource(child.context(ctx), client, ids[child.Name], params); err != nil {
+			return fmt.Errorf("%s: %w", child.Name, err)
+		}
+	}
+	return nil
+}
+
+// DeleteComposite deletes the children in reverse order. Children that are
+// already gone are skipped, so a failed delete can be retried.
+func DeleteComposite(ctx context.Context, client *core.UniversalClient, ids map[string]string, children []CompositeChild, deleteMode string) error {
+	for i := len(children) - 1; i >= 0; i-- {
+		id := ids[children[i].Name]
+		if strings.TrimSpace(id) == "" {
+			continue
+		}
+		ctx := children[i].context(ctx)
+		if state, err := client.GetInstanceState(ctx, id); err == nil && state != nil && state.IsDeleted {
+			continue
+		}
+		if err := DeleteResource(ctx, client, id, deleteMode); err != nil {
+			return fmt.Errorf("%s: %w", children[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func wireParams(child CompositeChild, ids map[string]string) (map[int]string, error) {
+	params := make(map[int]string, len(child.Params)+len(child.Wiring))
+	for id, v := range child.Params {
+		params[id] = v
+	}
+	for paramID, from := range child.Wiring {
+		uid, ok := ids[from]
+		if !ok || uid == "" {
+			return nil, fmt.Errorf("%s: param %d needs the id of %s, which is not created", child.Name, paramID, from)
+		}
+		params[paramID] = uid
+	}
+	return params, nil
+}
@@ -0,0 +1,264 @@
+package resources_core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-nubes/internal/core"
+)
+
+// fakeNubes is an in-memory API where every operation finishes at once.
+type fakeNubes struct {
+	// onCreate, if set, may fail POST /instances with a status code.
+	onCreate func(serviceID int) int
+	// onOperation, if set, may fail POST /instanceOperations with a status code.
+	onOperation func(instanceUID string, operation string) int
+
+	mu        sync.Mutex
+	next      int
+	instances map[string]*fakeInstance
+	ops       map[string]string
+	log       []string
+}
+
+type fakeInstance struct {
+	uid         string
+	serviceID   int
+	displayName string
+	deleted     bool
+}
+
+func newFakeNubes() *fakeNubes {
+	return &fakeNubes{instances: map[string]*fakeInstance{}, ops: map[string]string{}}
+}
+
+func (f *fakeNubes) uid() string {
+	f.next++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", f.next)
+}
+
+func (f *fakeNubes) add(serviceID int, displayName string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	uid := f.uid()
+	f.instances[uid] = &fakeInstance{uid: uid, serviceID: serviceID, displayName: displayName}
+	return uid
+}
+
+// count returns how many requests start with prefix ("GET /instances?").
+func (f *fakeNubes) count(prefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, l := range f.log {
+		if strings.HasPrefix(l, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *fakeNubes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ServiceID   int    `json:"serviceId"`
+		DisplayName string `json:"displayName"`
+		InstanceUID string `json:"instanceUid"`
+		Operation   string `json:"operation"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	f.mu.Lock()
+	f.log = append(f.log, r.Method+" "+r.URL.RequestURI())
+	onCreate, onOperation := f.onCreate, f.onOperation
+	f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/instances":
+		results := []interface{}{}
+		f.mu.Lock()
+		if r.URL.Query().Get("page") == "1" {
+			for _, inst := range f.instances {
+				results = append(results, map[string]interface{}{"instanceUid": inst.uid, "displayName": inst.displayName, "serviceId": inst.serviceID})
+			}
+		}
+		f.mu.Unlock()
+		writeJSON(w, map[string]interface{}{"results": results})
+	case r.Method == "GET" && parts[0] == "instances" && len(parts) == 2:
+		f.mu.Lock()
+		inst, ok := f.instances[parts[1]]
+		f.mu.Unlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]interface{}{"instance": map[string]interface{}{
+			"instanceUid": inst.uid, "serviceId": inst.serviceID, "displayName": inst.displayName,
+			"explainedStatus": "running", "isDeleted": inst.deleted,
+			"availableOperations": []interface{}{map[string]interface{}{"svcOperationId": 3, "operation": "delete"}},
+		}})
+	case r.Method == "POST" && r.URL.Path == "/instances":
+		if onCreate != nil {
+			if code := onCreate(payload.ServiceID); code != 0 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+		uid := f.add(payload.ServiceID, payload.DisplayName)
+		w.Header().Set("Location", "./"+uid)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "POST" && r.URL.Path == "/instanceOperations":
+		if onOperation != nil {
+			if code := onOperation(payload.InstanceUID, payload.Operation); code != 0 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+		f.mu.Lock()
+		uid := f.uid()
+		f.ops[uid] = payload.InstanceUID + "/" + payload.Operation
+		f.mu.Unlock()
+		w.Header().Set("Location", "./"+uid)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "POST" && len(parts) == 3 && parts[2] == "run":
+		f.mu.Lock()
+		instanceUID, op, _ := strings.Cut(f.ops[parts[1]], "/")
+		if inst, ok := f.instances[instanceUID]; ok && op == "delete" {
+			inst.deleted = true
+		}
+		f.mu.Unlock()
+		writeJSON(w, map[string]interface{}{})
+	case r.Method == "GET" && parts[0] == "instanceOperations" && len(parts) == 2:
+		writeJSON(w, map[string]interface{}{"instanceOperation": map[string]interface{}{
+			"dtFinish": "2024-01-01T00:00:00Z", "isSuccessful": true, "cfsParams": []interface{}{},
+		}})
+	default:
+		writeJSON(w, map[string]interface{}{})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newFakeClient(t *testing.T, api *fakeNubes) *core.UniversalClient {
+	t.Helper()
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	return &core.UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+}
+
+var compositeChildren = []CompositeChild{
+	{Name: "db", ServiceID: 1, DisplayName: "app-db"},
+	{Name: "web", ServiceID: 2, DisplayName: "app-web", Wiring: map[int]string{201: "db"}},
+}
+
+// An interrupted apply cancels the context while a child is created; the
+// children created before it must still be deleted.
+func TestCreateCompositeRollbackOnInterrupt(t *testing.T) {
+	api := newFakeNubes()
+	client := newFakeClient(t, api)
+	ctx, cancel := context.WithCancel(core.WithPollPolicy(context.Background(), core.PollPolicy{Initial: time.Millisecond}))
+	defer cancel()
+	api.onCreate = func(serviceID int) int {
+		if serviceID == 2 {
+			cancel()
+			return http.StatusBadRequest
+		}
+		return 0
+	}
+
+	ids, err := CreateComposite(ctx, client, true, compositeChildren)
+	if err == nil || strings.Contains(err.Error(), "rollback") {
+		t.Fatalf("got %v, want the create error only", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("ids %v remain after rollback", ids)
+	}
+	for _, inst := range api.instances {
+		if !inst.deleted {
+			t.Errorf("%s (%s) was not rolled back", inst.displayName, inst.uid)
+		}
+	}
+}
+
+func TestCreateCompositeRollbackKeepsAdopted(t *testing.T) {
+	api := newFakeNubes()
+	client := newFakeClient(t, api)
+	ctx := core.WithPollPolicy(context.Background(), core.PollPolicy{Initial: time.Millisecond})
+	adopted := api.add(1, "app-db")
+	api.onCreate = func(int) int { return http.StatusBadRequest }
+
+	ids, err := CreateComposite(ctx, client, true, compositeChildren)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if ids["db"] != adopted || api.instances[adopted].deleted {
+		t.Errorf("adopted db was rolled back: ids %v", ids)
+	}
+	// One lookup per child: db is on page 1, web is searched up to page 2.
+	if n := api.count("GET /instances?"); n != 3 {
+		t.Errorf("%d instance list requests, want 3", n)
+	}
+}
+
+// Children the rollback cannot delete are returned, so that the resource keeps
+// them in state.
+func TestCreateCompositeRollbackFailureKeepsIDs(t *testing.T) {
+	api := newFakeNubes()
+	client := newFakeClient(t, api)
+	ctx := core.WithPollPolicy(context.Background(), core.PollPolicy{Initial: time.Millisecond})
+	api.onCreate = func(serviceID int) int {
+		if serviceID == 2 {
+			return http.StatusBadRequest
+		}
+		return 0
+	}
+	api.onOperation = func(_ string, operation string) int {
+		if operation == "delete" {
+			return http.StatusConflict
+		}
+		return 0
+	}
+
+	ids, err := CreateComposite(ctx, client, false, compositeChildren)
+	if err == nil || !strings.Contains(err.Error(), "rollback db") {
+		t.Fatalf("got %v, want a rollback error", err)
+	}
+	if len(ids) != 1 || ids["db"] == "" {
+		t.Fatalf("got ids %v, want db only", ids)
+	}
+	if got := CompositeID(ids, "db", "web"); got.ValueString() != ids["db"] {
+		t.Errorf("CompositeID = %s, want the db id", got)
+	}
+	if got := CompositeID(ids, "web"); !got.IsNull() {
+		t.Errorf("CompositeID of a missing child = %s, want null", got)
+	}
+}
+
+// Each child polls with its own service policy, not the context's.
+func TestCreateCompositeUsesChildPollPolicy(t *testing.T) {
+	api := newFakeNubes()
+	client := newFakeClient(t, api)
+	children := []CompositeChild{
+		{Name: "db", ServiceID: 1, DisplayName: "app-db", PollPolicy: core.PollPolicy{Initial: time.Millisecond}},
+		// Times out at the first poll.
+		{Name: "web", ServiceID: 2, DisplayName: "app-web", PollPolicy: core.PollPolicy{Initial: time.Millisecond, Timeout: time.Nanosecond}},
+	}
+
+	ids, err := CreateComposite(context.Background(), client, false, children)
+	if err == nil || !strings.Contains(err.Error(), "web: ") || !strings.Contains(err.Error(), "timeout waiting for operation") {
+		t.Fatalf("got %v, want the web poll timeout", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("ids %v remain after rollback", ids)
+	}
+}
@@ -11,35 +11,74 @@ import (
 
 // CreateResource uses the universal client flow for create/resume/adopt.
 func CreateResource(ctx context.Context, client *core.UniversalClient, serviceID int, displayName string, resumeIfExists bool, params map[int]string) (string, error) {
+	instanceUid, _, err := createOrAdopt(ctx, client, serviceID, displayName, resumeIfExists, params)
+	return instanceUid, err
+}
+
+// createOrAdopt is CreateResource that also reports whether the instance
+// already existed and was adopted rather than created.
+func createOrAdopt(ctx context.Context, client *core.UniversalClient, serviceID int, displayName string, resumeIfExists bool, params map[int]string) (_ string, adopted bool, _ error) {
+	unlock := lockInstanceName(serviceID, displayName)
+	defer unlock()
+
 	existing, err := client.FindInstanceByDisplayName(ctx, serviceID, displayName)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 	if existing != nil {
 		if !resumeIfExists {
-			return "", fmt.Errorf("resource with resource_name already exists: %s", displayName)
+			return "", false, fmt.Errorf("resource with resource_name already exists: %s", displayName)
 		}
 		status := strings.ToLower(strings.TrimSpace(existing.ExplainedStatus))
 		if isStatusNonAdoptable(status) {
-			return "", fmt.Errorf("resource exists but not ready for adopt: %s", existing.ExplainedStatus)
+			return "", false, fmt.Errorf("resource exists but not ready for adopt: %s", existing.ExplainedStatus)
 		}
 		if isStatusSuspended(status) {
 			if err := client.RunInstanceOperationUniversal(ctx, existing.InstanceUid, "resume", nil); err != nil {
-				return "", err
+				return "", false, err
 			}
 			resumed, err := client.GetInstanceState(ctx, existing.InstanceUid)
 			if err != nil {
-				return "", err
+				return "", false, err
 			}
 			resumedStatus := strings.ToLower(strings.TrimSpace(resumed.ExplainedStatus))
 			if isStatusNonAdoptable(resumedStatus) || isStatusSuspended(resumedStatus) {
-				return "", fmt.Errorf("resource not ready after resume: %s", resumed.ExplainedStatus)
+				return "", false, fmt.Errorf("resource not ready after resume: %s", resumed.ExplainedStatus)
 			}
 		}
-		return existing.InstanceUid, nil
+		return existing.InstanceUid, true, nil
+	}
+
+	instanceUid, err := client.CreateGenericInstanceUniversalV6(ctx, serviceID, displayName, params)
+	if err != nil {
+		return "", false, err
+	}
+	if client.VerifyUniqueNames {
+		if err := checkDuplicateInstances(ctx, client, serviceID, displayName, instanceUid); err != nil {
+			return instanceUid, false, err
+		}
 	}
+	return instanceUid, false, nil
+}
 
-	return client.CreateGenericInstanceUniversalV6(ctx, serviceID, displayName, params)
+// checkDuplicateInstances fails when another instance with the same name was
+// created concurrently, e.g. from another workspace sharing the account.
+func checkDuplicateInstances(ctx context.Context, client *core.UniversalClient, serviceID int, displayName string, instanceUid string) error {
+	uids, err := client.FindInstanceUidsByDisplayName(ctx, serviceID, displayName)
+	if err != nil {
+		return fmt.Errorf("duplicate check failed for %s: %w", displayName, err)
+	}
+	var others []string
+	for _, uid := range uids {
+		if uid != instanceUid {
+			others = append(others, uid)
+		}
+	}
+	if len(others) > 0 {
+		return fmt.Errorf("duplicate instances with resource_name %s (serviceId=%d): created %s, also found %s; remove the extra instances manually",
+			displayName, serviceID, instanceUid, strings.Join(others, ", "))
+	}
+	return nil
 }
 
 // UpdateResource uses universal modify flow with defaults.
@@ -0,0 +1,70 @@
+package resources_core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DataParam is a computed data source attribute filled from the current
+// value of an API param.
+type DataParam struct {
+	Attribute string
+	ID        int
+}
+
+// ReadInstance finds the instance a data source refers to (by id, else by
+// resource_name) and returns it with its attribute values keyed by attribute,
+// in the format of ReadOutputs. Only `from: instance` outputs are available.
+func ReadInstance(ctx context.Context, client *core.UniversalClient, serviceID int, id types.String, name types.String, params []DataParam, outputs []Output) (*core.InstanceInfo, map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if client == nil {
+		diags.AddError("Unconfigured Client", "the provider is not configured")
+		return nil, nil, diags
+	}
+
+	attr := "id"
+	if FormatString(id) == "" {
+		attr = "resource_name"
+	}
+	info, err := client.LookupInstance(ctx, serviceID, FormatString(id), FormatString(name))
+	switch {
+	case err == nil:
+	case errors.Is(err, core.ErrInstanceNotFound):
+		diags.AddAttributeError(path.Root(attr), "Instance Not Found",
+			fmt.Sprintf("no instance of service %d matches %s: %s", serviceID, attr, err))
+		return nil, nil, diags
+	case errors.Is(err, core.ErrWrongService):
+		diags.AddAttributeError(path.Root(attr), "Instance Has Wrong Service",
+			fmt.Sprintf("%s must refer to an instance of service %d: %s", attr, serviceID, err))
+		return nil, nil, diags
+	case errors.Is(err, core.ErrAmbiguousInstance):
+		diags.AddAttributeError(path.Root(attr), "Ambiguous resource_name",
+			fmt.Sprintf("set id to choose one instance: %s", err))
+		return nil, nil, diags
+	default:
+		diags.AddError("Client Error", err.Error())
+		return nil, nil, diags
+	}
+
+	values := make(map[string]string, len(params)+len(outputs))
+	for _, p := range params {
+		if v, ok := info.Params[p.ID]; ok {
+			values[p.Attribute] = v
+		}
+	}
+	read, err := ReadOutputs(ctx, client, info.InstanceUid, "", outputs...)
+	if err != nil {
+		diags.AddWarning("Unable to Read Outputs", err.Error())
+	}
+	for k, v := range read {
+		values[k] = v
+	}
+	return info, values, diags
+}
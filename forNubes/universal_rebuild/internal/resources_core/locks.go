@@ -0,0 +1,52 @@
+package resources_core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// keyedMutex serializes work per key and drops a key's lock once no caller
+// holds or waits for it.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	refs int
+}
+
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = map[string]*keyedLock{}
+	}
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// createLocks guards find-then-create per (serviceId, displayName) so parallel
+// creates in one provider process cannot both miss the existing instance.
+var createLocks keyedMutex
+
+func lockInstanceName(serviceID int, displayName string) func() {
+	return createLocks.lock(fmt.Sprintf("%d/%s", serviceID, strings.TrimSpace(displayName)))
+}
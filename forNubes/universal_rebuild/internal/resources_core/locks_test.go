@@ -0,0 +1,52 @@
+package resources_core
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	var k keyedMutex
+	var inside, maxInside int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := k.lock("1/db")
+			defer unlock()
+			n := atomic.AddInt32(&inside, 1)
+			for {
+				m := atomic.LoadInt32(&maxInside)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInside, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&inside, -1)
+		}()
+	}
+	wg.Wait()
+	if maxInside != 1 {
+		t.Errorf("%d holders of one key at once", maxInside)
+	}
+	if len(k.locks) != 0 {
+		t.Errorf("%d locks left after all released", len(k.locks))
+	}
+
+	// Other keys are not blocked.
+	unlock := k.lock("1/db")
+	done := make(chan struct{})
+	go func() {
+		k.lock("2/db")()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("a different key waited for 1/db")
+	}
+	unlock()
+}
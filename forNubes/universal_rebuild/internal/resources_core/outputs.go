@@ -0,0 +1,129 @@
+package resources_core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Output is a computed attribute filled from instance details or from the
+// results of the create operation (YAML `outputs`).
+type Output struct {
+	Attribute string
+	// From is "instance" (GET /instances/{uid}) or "operation" (the create
+	// instanceOperation, available only right after create).
+	From string
+	Path string
+}
+
+// ReadOutputs fetches the sources the outputs need and returns their values
+// formatted as strings, keyed by attribute. Outputs whose path is absent are
+// left out so callers keep the previous value, and reported in the error
+// together with the values found.
+func ReadOutputs(ctx context.Context, client *core.UniversalClient, instanceUid string, createOpUid string, outputs ...Output) (map[string]string, error) {
+	values := make(map[string]string, len(outputs))
+	if client == nil || len(outputs) == 0 {
+		return values, nil
+	}
+
+	var instance, operation map[string]interface{}
+	var missing []string
+	for _, out := range outputs {
+		var src map[string]interface{}
+		switch out.From {
+		case "operation":
+			if createOpUid == "" {
+				continue
+			}
+			if operation == nil {
+				details, err := client.GetOperationDetails(ctx, createOpUid)
+				if err != nil {
+					return values, err
+				}
+				operation = details
+			}
+			src = operation
+		default:
+			if instance == nil {
+				details, err := client.GetInstanceDetails(ctx, instanceUid)
+				if err != nil {
+					return values, err
+				}
+				instance = details
+			}
+			src = instance
+		}
+
+		raw, ok := core.LookupPath(src, out.Path)
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%s (%s %s)", out.Attribute, sourceName(out.From), out.Path))
+			continue
+		}
+		values[out.Attribute] = formatOutputValue(raw)
+	}
+	if len(missing) > 0 {
+		return values, fmt.Errorf("paths not found in the API response, check the service outputs: %s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+func sourceName(from string) string {
+	if from == "operation" {
+		return "operation"
+	}
+	return "instance"
+}
+
+func formatOutputValue(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// OutputString returns the output value, or prev when the output was not
+// found (null if prev is unknown).
+func OutputString(values map[string]string, name string, prev types.String) types.String {
+	if v, ok := values[name]; ok {
+		return types.StringValue(v)
+	}
+	if prev.IsUnknown() {
+		return types.StringNull()
+	}
+	return prev
+}
+
+func OutputInt64(values map[string]string, name string, prev types.Int64) types.Int64 {
+	if v, ok := values[name]; ok {
+		if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			return types.Int64Value(n)
+		}
+	}
+	if prev.IsUnknown() {
+		return types.Int64Null()
+	}
+	return prev
+}
+
+func OutputBool(values map[string]string, name string, prev types.Bool) types.Bool {
+	if v, ok := values[name]; ok {
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return types.BoolValue(b)
+		}
+	}
+	if prev.IsUnknown() {
+		return types.BoolNull()
+	}
+	return prev
+}
@@ -0,0 +1,53 @@
+package resources_core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"terraform-provider-nubes/internal/core"
+)
+
+func TestReadOutputs(t *testing.T) {
+	const (
+		instanceUID = "00000000-0000-0000-0000-000000000001"
+		opUID       = "00000000-0000-0000-0000-000000000002"
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances/" + instanceUID:
+			fmt.Fprint(w, `{"instance":{"endpoint":"db.nubes.test","ports":[{"name":"main","value":5432}]}}`)
+		case "/instanceOperations/" + opUID:
+			fmt.Fprint(w, `{"instanceOperation":{"cfsParams":[{"svcOperationCfsParam":"adminPassword","paramValue":"s3cret"}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := &core.UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	outputs := []Output{
+		{Attribute: "endpoint", From: "instance", Path: "endpoint"},
+		{Attribute: "port", From: "instance", Path: "ports[name=main].value"},
+		{Attribute: "admin_password", From: "operation", Path: "cfsParams[svcOperationCfsParam=adminPassword].paramValue"},
+		{Attribute: "user", From: "instance", Path: "connection.user"},
+	}
+	values, err := ReadOutputs(context.Background(), client, instanceUID, opUID, outputs...)
+	want := map[string]string{"endpoint": "db.nubes.test", "port": "5432", "admin_password": "s3cret"}
+	if fmt.Sprint(values) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", values, want)
+	}
+	// A missing path is reported, not silently left null.
+	if err == nil || !strings.Contains(err.Error(), "user (instance connection.user)") {
+		t.Errorf("got error %v, want the missing path of user", err)
+	}
+
+	// Operation outputs are not read without the create operation.
+	values, err = ReadOutputs(context.Background(), client, instanceUID, "", outputs[2])
+	if err != nil || len(values) != 0 {
+		t.Errorf("without operation: got %v, %v", values, err)
+	}
+}
@@ -0,0 +1,40 @@
+package resources_core
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ReconcilePendingOperation checks the operation recorded in pending_operation
+// (left running when Terraform was interrupted) and returns the value to keep:
+// null once the operation has finished.
+func ReconcilePendingOperation(ctx context.Context, client *core.UniversalClient, pending types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opUid := FormatString(pending)
+	if client == nil || opUid == "" {
+		return pending, diags
+	}
+
+	status, err := client.GetOperationStatus(ctx, opUid)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("pending_operation"), "Unable to Check Pending Operation",
+			fmt.Sprintf("operation %s: %s", opUid, err))
+		return pending, diags
+	}
+	switch {
+	case !status.Finished:
+		diags.AddAttributeWarning(path.Root("pending_operation"), "Operation Still Running",
+			fmt.Sprintf("operation %s started by an interrupted apply has not finished yet; refresh again later.", opUid))
+		return pending, diags
+	case !status.Successful:
+		diags.AddAttributeWarning(path.Root("pending_operation"), "Pending Operation Failed",
+			fmt.Sprintf("operation %s started by an interrupted apply failed: %s", opUid, status.ErrorLog))
+	}
+	return types.StringNull(), diags
+}
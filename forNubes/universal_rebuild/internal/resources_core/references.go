@@ -0,0 +1,55 @@
+package resources_core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Reference is an attribute that must point at an instance of another
+// service (YAML `ref_service_id`), given as a UUID or a resource_name.
+type Reference struct {
+	Attribute string
+	ServiceID int
+	Value     types.String
+}
+
+// ValidateReferences checks at plan time that every known reference resolves
+// to an existing instance of the expected service. Unknown values (e.g. the
+// id of a resource created in the same apply) are checked at apply time, and
+// so are names not found yet: the instance may be created in the same apply.
+func ValidateReferences(ctx context.Context, client *core.UniversalClient, refs ...Reference) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil {
+		return diags
+	}
+	for _, ref := range refs {
+		value := FormatString(ref.Value)
+		if value == "" {
+			continue
+		}
+		_, err := client.ResolveInstanceRef(ctx, ref.ServiceID, value)
+		switch {
+		case err == nil:
+		case errors.Is(err, core.ErrInstanceNotFound) && !core.IsUUID(value):
+			diags.AddAttributeWarning(path.Root(ref.Attribute), "Referenced Instance Not Found Yet",
+				fmt.Sprintf("%s: no instance of service %d named %q exists yet. It is resolved again at apply time, when it may have been created by this configuration.", ref.Attribute, ref.ServiceID, value))
+		case errors.Is(err, core.ErrInstanceNotFound):
+			diags.AddAttributeError(path.Root(ref.Attribute), "Referenced Instance Not Found",
+				fmt.Sprintf("%s must reference an existing instance of service %d (UUID or resource_name): %s", ref.Attribute, ref.ServiceID, err))
+		case errors.Is(err, core.ErrWrongService):
+			diags.AddAttributeError(path.Root(ref.Attribute), "Referenced Instance Has Wrong Service",
+				fmt.Sprintf("%s must reference an instance of service %d: %s", ref.Attribute, ref.ServiceID, err))
+		default:
+			diags.AddAttributeWarning(path.Root(ref.Attribute), "Unable to Verify Reference",
+				fmt.Sprintf("%s could not be checked at plan time: %s", ref.Attribute, err))
+		}
+	}
+	return diags
+}
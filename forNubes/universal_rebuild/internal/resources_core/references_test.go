@@ -0,0 +1,69 @@
+package resources_core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateReferences(t *testing.T) {
+	const (
+		bucketUID  = "00000000-0000-0000-0000-000000000001"
+		missingUID = "00000000-0000-0000-0000-000000000009"
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances":
+			if r.URL.Query().Get("page") == "1" {
+				fmt.Fprintf(w, `{"results":[{"instanceUid":%q,"displayName":"bucket","serviceId":13}]}`, bucketUID)
+				return
+			}
+			fmt.Fprint(w, `{"results":[]}`)
+		case "/instances/" + bucketUID:
+			fmt.Fprintf(w, `{"instance":{"instanceUid":%q,"serviceId":13}}`, bucketUID)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := &core.UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL}
+
+	for _, tc := range []struct {
+		name     string
+		value    string
+		severity diag.Severity
+		summary  string
+	}{
+		{"existing name", "bucket", 0, ""},
+		{"existing uuid", bucketUID, 0, ""},
+		// May be created in the same apply.
+		{"unknown name", "new-user", diag.SeverityWarning, "Referenced Instance Not Found Yet"},
+		{"unknown uuid", missingUID, diag.SeverityError, "Referenced Instance Not Found"},
+		{"wrong service", bucketUID, diag.SeverityError, "Referenced Instance Has Wrong Service"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceID := 13
+			if tc.name == "wrong service" {
+				serviceID = 12
+			}
+			diags := ValidateReferences(context.Background(), client,
+				Reference{Attribute: "s3_user_uid", ServiceID: serviceID, Value: types.StringValue(tc.value)})
+			if tc.summary == "" {
+				if len(diags) != 0 {
+					t.Fatalf("got %v, want no diagnostics", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity() != tc.severity || diags[0].Summary() != tc.summary {
+				t.Fatalf("got %v, want one %s %q", diags, tc.severity, tc.summary)
+			}
+		})
+	}
+}
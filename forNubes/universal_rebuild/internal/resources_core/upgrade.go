@@ -0,0 +1,146 @@
+package resources_core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// StateMigration describes how stored attributes change between two
+// consecutive schema versions.
+type StateMigration struct {
+	// Rename maps an attribute name of the older version to its new name.
+	Rename map[string]string
+	// Drop lists attributes (older names) that no longer exist.
+	Drop []string
+	// Convert maps an attribute (new name) to its new type: string, int64 or bool.
+	Convert map[string]string
+}
+
+// MigrateState returns a StateUpgrader that applies migrations in order to the
+// raw JSON state, so one upgrader can move state across several versions.
+// Attributes missing after migration are decoded as null by the framework.
+func MigrateState(migrations ...StateMigration) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", "missing raw state JSON")
+				return
+			}
+
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(req.RawState.JSON, &attrs); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("failed to parse prior state: %s", err))
+				return
+			}
+
+			for _, m := range migrations {
+				var err error
+				attrs, err = m.apply(attrs)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+					return
+				}
+			}
+
+			b, err := json.Marshal(attrs)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: b}
+		},
+	}
+}
+
+func (m StateMigration) apply(attrs map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	dropped := make(map[string]bool, len(m.Drop))
+	for _, name := range m.Drop {
+		dropped[name] = true
+	}
+
+	out := make(map[string]json.RawMessage, len(attrs))
+	for name, value := range attrs {
+		if dropped[name] {
+			continue
+		}
+		if newName, ok := m.Rename[name]; ok {
+			name = newName
+		}
+		out[name] = value
+	}
+
+	for name, typ := range m.Convert {
+		value, ok := out[name]
+		if !ok {
+			continue
+		}
+		converted, err := convertStateValue(value, typ)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		out[name] = converted
+	}
+	return out, nil
+}
+
+// convertStateValue converts a stored JSON scalar to the given type. Values
+// that cannot be represented in the new type become null, so the next plan
+// shows the configured value as a change instead of failing the upgrade.
+func convertStateValue(value json.RawMessage, typ string) (json.RawMessage, error) {
+	var raw interface{}
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return value, nil
+	}
+
+	text := ""
+	switch v := raw.(type) {
+	case string:
+		text = strings.TrimSpace(v)
+	case json.Number:
+		text = v.String()
+	case bool:
+		text = strconv.FormatBool(v)
+	default:
+		return json.RawMessage("null"), nil
+	}
+
+	var out interface{}
+	switch typ {
+	case "string":
+		out = text
+	case "int64":
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			b, errBool := strconv.ParseBool(text)
+			if errBool != nil {
+				return json.RawMessage("null"), nil
+			}
+			n = 0
+			if b {
+				n = 1
+			}
+		}
+		out = n
+	case "bool":
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return json.RawMessage("null"), nil
+		}
+		out = b
+	default:
+		return nil, fmt.Errorf("unsupported target type %q", typ)
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,188 @@
+package resources_core
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgrade runs the upgrader on prior state JSON and returns the new state
+// JSON, or the diagnostics summary on error.
+func upgrade(t *testing.T, prior string, migrations ...StateMigration) (string, string) {
+	t.Helper()
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(prior)}}
+	var resp resource.UpgradeStateResponse
+	MigrateState(migrations...).StateUpgrader(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		return "", resp.Diagnostics.Errors()[0].Detail()
+	}
+	return string(resp.DynamicValue.JSON), ""
+}
+
+func assertJSON(t *testing.T, got string, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("invalid JSON %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid JSON %s: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMigrateState(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		prior      string
+		migrations []StateMigration
+		want       string
+	}{
+		{
+			name:       "rename",
+			prior:      `{"id":"x","resource_c_p_u":2}`,
+			migrations: []StateMigration{{Rename: map[string]string{"resource_c_p_u": "resource_cpu"}}},
+			want:       `{"id":"x","resource_cpu":2}`,
+		},
+		{
+			name:       "drop",
+			prior:      `{"id":"x","host":"db","port":5432}`,
+			migrations: []StateMigration{{Drop: []string{"host", "port"}}},
+			want:       `{"id":"x"}`,
+		},
+		{
+			name:       "drop of an attribute not in state",
+			prior:      `{"id":"x"}`,
+			migrations: []StateMigration{{Drop: []string{"host"}}},
+			want:       `{"id":"x"}`,
+		},
+		{
+			name:       "convert after rename uses the new name",
+			prior:      `{"disk_g_b":"20"}`,
+			migrations: []StateMigration{{Rename: map[string]string{"disk_g_b": "disk_gb"}, Convert: map[string]string{"disk_gb": "int64"}}},
+			want:       `{"disk_gb":20}`,
+		},
+		{
+			name:       "convert of an attribute not in state",
+			prior:      `{"id":"x"}`,
+			migrations: []StateMigration{{Convert: map[string]string{"size": "int64"}}},
+			want:       `{"id":"x"}`,
+		},
+		{
+			name:  "v0 to v2 chain",
+			prior: `{"id":"x","resource_c_p_u":"4","host":"db","enable_s_s_l":"true"}`,
+			migrations: []StateMigration{
+				// v0 -> v1: acronym-aware names.
+				{Rename: map[string]string{"resource_c_p_u": "resource_cpu", "enable_s_s_l": "enable_ssl"}},
+				// v1 -> v2: typed params, host removed.
+				{Drop: []string{"host"}, Convert: map[string]string{"resource_cpu": "int64", "enable_ssl": "bool"}},
+			},
+			want: `{"id":"x","resource_cpu":4,"enable_ssl":true}`,
+		},
+		{
+			name:       "no migrations",
+			prior:      `{"id":"x","a":null}`,
+			migrations: nil,
+			want:       `{"id":"x","a":null}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diag := upgrade(t, tc.prior, tc.migrations...)
+			if diag != "" {
+				t.Fatal(diag)
+			}
+			assertJSON(t, got, tc.want)
+		})
+	}
+}
+
+func TestMigrateStateErrors(t *testing.T) {
+	if _, diag := upgrade(t, `{"a":1}`, StateMigration{Convert: map[string]string{"a": "list"}}); !strings.Contains(diag, `unsupported target type "list"`) {
+		t.Errorf("unsupported type: got %q", diag)
+	}
+	if _, diag := upgrade(t, `not json`); !strings.Contains(diag, "failed to parse prior state") {
+		t.Errorf("invalid JSON: got %q", diag)
+	}
+	var resp resource.UpgradeStateResponse
+	MigrateState().StateUpgrader(context.Background(), resource.UpgradeStateRequest{}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("missing raw state: no error")
+	}
+}
+
+func TestConvertStateValue(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		typ   string
+		want  string
+	}{
+		// to string
+		{`42`, "string", `"42"`},
+		{`true`, "string", `"true"`},
+		{`" padded "`, "string", `"padded"`},
+		// to int64
+		{`"42"`, "int64", `42`},
+		{`"-7"`, "int64", `-7`},
+		{`true`, "int64", `1`},
+		{`"false"`, "int64", `0`},
+		{`"10G"`, "int64", `null`},
+		{`1.5`, "int64", `null`},
+		// to bool
+		{`"true"`, "bool", `true`},
+		{`1`, "bool", `true`},
+		{`0`, "bool", `false`},
+		{`"yes"`, "bool", `null`},
+		{`2`, "bool", `null`},
+		// null and non-scalars
+		{`null`, "int64", `null`},
+		{`null`, "string", `null`},
+		{`[1]`, "string", `null`},
+		{`{"a":1}`, "int64", `null`},
+	} {
+		got, err := convertStateValue(json.RawMessage(tc.value), tc.typ)
+		if err != nil {
+			t.Errorf("convert %s to %s: %v", tc.value, tc.typ, err)
+			continue
+		}
+		if string(got) != tc.want {
+			t.Errorf("convert %s to %s = %s, want %s", tc.value, tc.typ, got, tc.want)
+		}
+	}
+}
+
+// Attributes missing from the upgraded JSON (new in the schema, or dropped
+// and not re-added) decode as null against the current schema.
+func TestMigratedStateDecodesMissingAsNull(t *testing.T) {
+	got, diag := upgrade(t, `{"id":"x","resource_c_p_u":2,"host":"db"}`,
+		StateMigration{Rename: map[string]string{"resource_c_p_u": "resource_cpu"}, Drop: []string{"host"}})
+	if diag != "" {
+		t.Fatal(diag)
+	}
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":           tftypes.String,
+		"resource_cpu": tftypes.Number,
+		"password":     tftypes.String,
+	}}
+	v, err := (&tfprotov6.DynamicValue{JSON: []byte(got)}).Unmarshal(typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["password"].IsNull() {
+		t.Errorf("password = %v, want null", attrs["password"])
+	}
+	if attrs["resource_cpu"].IsNull() {
+		t.Error("resource_cpu is null after rename")
+	}
+}
@@ -0,0 +1,144 @@
+package resources_core
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/core"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// String format validators used by generated schemas (YAML `format` key).
+
+func CronValidator() validator.String {
+	return stringFormatValidator{
+		description: "value must be a cron expression with 5 fields (minute hour day month weekday)",
+		check:       checkCron,
+	}
+}
+
+func UUIDValidator() validator.String {
+	return stringFormatValidator{
+		description: "value must be a UUID",
+		check: func(v string) error {
+			if !core.IsUUID(v) {
+				return fmt.Errorf("%q is not a UUID", v)
+			}
+			return nil
+		},
+	}
+}
+
+func CIDRValidator() validator.String {
+	return stringFormatValidator{
+		description: "value must be a CIDR block, e.g. 10.0.0.0/24",
+		check: func(v string) error {
+			_, _, err := net.ParseCIDR(strings.TrimSpace(v))
+			return err
+		},
+	}
+}
+
+func IPValidator() validator.String {
+	return stringFormatValidator{
+		description: "value must be an IPv4 or IPv6 address",
+		check: func(v string) error {
+			if net.ParseIP(strings.TrimSpace(v)) == nil {
+				return fmt.Errorf("%q is not an IP address", v)
+			}
+			return nil
+		},
+	}
+}
+
+type stringFormatValidator struct {
+	description string
+	check       func(string) error
+}
+
+func (v stringFormatValidator) Description(_ context.Context) string {
+	return v.description
+}
+
+func (v stringFormatValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringFormatValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if value == "" {
+		return
+	}
+	if err := v.check(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", fmt.Sprintf("%s: %s", v.Description(ctx), err))
+	}
+}
+
+var cronFieldRanges = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func checkCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		switch fields[0] {
+		case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+			return nil
+		}
+		return fmt.Errorf("unknown cron macro %q", fields[0])
+	}
+	if len(fields) != len(cronFieldRanges) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFieldRanges), len(fields))
+	}
+	for i, field := range fields {
+		r := cronFieldRanges[i]
+		if err := checkCronField(field, r.min, r.max); err != nil {
+			return fmt.Errorf("%s field %q: %w", r.name, field, err)
+		}
+	}
+	return nil
+}
+
+func checkCronField(field string, min, max int) error {
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+		if rangePart == "*" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(rangePart, "-")
+		bounds := []string{lo}
+		if isRange {
+			bounds = append(bounds, hi)
+		}
+		for _, b := range bounds {
+			n, err := strconv.Atoi(b)
+			if err != nil {
+				return fmt.Errorf("invalid value %q", b)
+			}
+			if n < min || n > max {
+				return fmt.Errorf("value %d out of range %d-%d", n, min, max)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package resources_core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckCron(t *testing.T) {
+	for _, tc := range []struct {
+		expr    string
+		wantErr string
+	}{
+		{"0 3 * * *", ""},
+		{"*/15 * * * *", ""},
+		{"0 0-6/2 1,15 * 1-5", ""},
+		{"59 23 31 12 7", ""},
+		{"  0   3 * * 0 ", ""},
+		{"@daily", ""},
+		{"@hourly", ""},
+		{"@every", `unknown cron macro "@every"`},
+		{"0 3 * *", "expected 5 fields, got 4"},
+		{"0 3 * * * *", "expected 5 fields, got 6"},
+		{"", "expected 5 fields, got 0"},
+		{"60 * * * *", `minute field "60": value 60 out of range 0-59`},
+		{"* 24 * * *", `hour field "24": value 24 out of range 0-23`},
+		{"* * 0 * *", `day of month field "0": value 0 out of range 1-31`},
+		{"* * * 13 *", `month field "13": value 13 out of range 1-12`},
+		{"* * * * 8", `day of week field "8": value 8 out of range 0-7`},
+		{"*/0 * * * *", `minute field "*/0": invalid step "0"`},
+		{"*/x * * * *", `minute field "*/x": invalid step "x"`},
+		{"a * * * *", `minute field "a": invalid value "a"`},
+		{"1-x * * * *", `minute field "1-x": invalid value "x"`},
+		{"* * * JAN *", `month field "JAN": invalid value "JAN"`},
+	} {
+		err := checkCron(tc.expr)
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("checkCron(%q): unexpected error %v", tc.expr, err)
+		case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+			t.Errorf("checkCron(%q) = %v, want %q", tc.expr, err, tc.wantErr)
+		}
+	}
+}
+
+func TestFormatValidators(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		v     validator.String
+		value types.String
+		valid bool
+	}{
+		{"cron", CronValidator(), types.StringValue("0 3 * * *"), true},
+		{"cron", CronValidator(), types.StringValue("every day"), false},
+		{"uuid", UUIDValidator(), types.StringValue("0f9e7c1a-2b3d-4e5f-8a9b-0c1d2e3f4a5b"), true},
+		{"uuid", UUIDValidator(), types.StringValue("pg-main"), false},
+		{"cidr", CIDRValidator(), types.StringValue("10.0.0.0/24"), true},
+		{"cidr", CIDRValidator(), types.StringValue("10.0.0.0"), false},
+		{"ip", IPValidator(), types.StringValue("192.168.1.10"), true},
+		{"ip", IPValidator(), types.StringValue("::1"), true},
+		{"ip", IPValidator(), types.StringValue("300.1.1.1"), false},
+		// Unset values are left to required/optional handling.
+		{"cron", CronValidator(), types.StringValue(""), true},
+		{"cron", CronValidator(), types.StringNull(), true},
+		{"cron", CronValidator(), types.StringUnknown(), true},
+	} {
+		req := validator.StringRequest{Path: path.Root("attr"), ConfigValue: tc.value}
+		var resp validator.StringResponse
+		tc.v.ValidateString(context.Background(), req, &resp)
+		if got := !resp.Diagnostics.HasError(); got != tc.valid {
+			t.Errorf("%s %s: valid = %t, want %t (%v)", tc.name, tc.value, got, tc.valid, resp.Diagnostics)
+		}
+		if !tc.valid && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tc.v.Description(context.Background())) {
+			t.Errorf("%s %s: diagnostic %q does not describe the format", tc.name, tc.value, resp.Diagnostics.Errors()[0].Detail())
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: GiteaComplex
+// Service ID: 114
+
+var _ datasource.DataSource = &GiteaComplexDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &GiteaComplexDataSource{}
+
+type GiteaComplexDataSource struct {
+	client *core.UniversalClient
+}
+
+type GiteaComplexDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ResourceName types.String `tfsdk:"resource_name"`
+}
+
+func NewGiteaComplexDataSource() datasource.DataSource {
+	return &GiteaComplexDataSource{}
+}
+
+func (d *GiteaComplexDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_GiteaComplex"
+}
+
+func (d *GiteaComplexDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `GiteaComplex` (service ID 114) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+		},
+	}
+}
+
+func (d *GiteaComplexDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *GiteaComplexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GiteaComplexDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, _, diags := resources_core.ReadInstance(ctx, d.client, 114, data.ID, data.ResourceName, nil, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *GiteaComplexDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -20,16 +20,18 @@ import (
 
 var _ resource.Resource = &GiteaComplexResource{}
 var _ resource.ResourceWithModifyPlan = &GiteaComplexResource{}
+var _ resource.ResourceWithUpgradeState = &GiteaComplexResource{}
 
 type GiteaComplexResource struct {
 	client *core.UniversalClient
 }
 
 type GiteaComplexModel struct {
-	ID             types.String `tfsdk:"id"`
-	ResourceName   types.String `tfsdk:"resource_name"`
-	DeleteMode     types.String `tfsdk:"delete_mode"`
-	ResumeIfExists types.Bool   `tfsdk:"resume_if_exists"`
+	ID               types.String `tfsdk:"id"`
+	ResourceName     types.String `tfsdk:"resource_name"`
+	PendingOperation types.String `tfsdk:"pending_operation"`
+	DeleteMode       types.String `tfsdk:"delete_mode"`
+	ResumeIfExists   types.Bool   `tfsdk:"resume_if_exists"`
 }
 
 func NewGiteaComplexResource() resource.Resource {
@@ -44,6 +46,10 @@ func (r *GiteaComplexResource) Schema(ctx context.Context, req resource.SchemaRe
 	attrs := map[string]schema.Attribute{
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -56,7 +62,14 @@ func (r *GiteaComplexResource) Schema(ctx context.Context, req resource.SchemaRe
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *GiteaComplexResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *GiteaComplexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -125,6 +138,8 @@ func (r *GiteaComplexResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 114, resourceName)
@@ -149,10 +164,22 @@ func (r *GiteaComplexResource) Create(ctx context.Context, req resource.CreateRe
 	id, err := resources_core.CreateResource(ctx, r.client, 114, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -178,6 +205,10 @@ func (r *GiteaComplexResource) Read(ctx context.Context, req resource.ReadReques
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -202,12 +233,18 @@ func (r *GiteaComplexResource) Update(ctx context.Context, req resource.UpdateRe
 
 	params := map[int]string{}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
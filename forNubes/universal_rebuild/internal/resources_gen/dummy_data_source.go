@@ -0,0 +1,170 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: dummy
+// Service ID: 1
+
+var _ datasource.DataSource = &DummyDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &DummyDataSource{}
+
+type DummyDataSource struct {
+	client *core.UniversalClient
+}
+
+type DummyDataSourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	ResourceName         types.String `tfsdk:"resource_name"`
+	DurationMs           types.Int64  `tfsdk:"duration_ms"`
+	FailAtStart          types.Bool   `tfsdk:"fail_at_start"`
+	FailInProgress       types.Bool   `tfsdk:"fail_in_progress"`
+	WhereFail            types.Int64  `tfsdk:"where_fail"`
+	ResourceRealm        types.String `tfsdk:"resource_realm"`
+	Bodymessage          types.String `tfsdk:"bodymessage"`
+	MapExample           types.String `tfsdk:"map_example"`
+	JsonExample          types.String `tfsdk:"json_example"`
+	NestedRefExample     types.String `tfsdk:"nested_ref_example"`
+	YamlExample          types.String `tfsdk:"yaml_example"`
+	MapFixed             types.String `tfsdk:"map_fixed"`
+	ArrayMapFixedExample types.String `tfsdk:"array_map_fixed_example"`
+}
+
+// dummyDataParams map attributes to the params they show.
+var dummyDataParams = []resources_core.DataParam{
+	{Attribute: "duration_ms", ID: 198},
+	{Attribute: "fail_at_start", ID: 199},
+	{Attribute: "fail_in_progress", ID: 200},
+	{Attribute: "where_fail", ID: 201},
+	{Attribute: "resource_realm", ID: 242},
+	{Attribute: "bodymessage", ID: 286},
+	{Attribute: "map_example", ID: 321},
+	{Attribute: "json_example", ID: 322},
+	{Attribute: "nested_ref_example", ID: 396},
+	{Attribute: "yaml_example", ID: 447},
+	{Attribute: "map_fixed", ID: 647},
+	{Attribute: "array_map_fixed_example", ID: 654},
+}
+
+func NewDummyDataSource() datasource.DataSource {
+	return &DummyDataSource{}
+}
+
+func (d *DummyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dummy"
+}
+
+func (d *DummyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `dummy` (service ID 1) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"duration_ms": schema.Int64Attribute{
+				Computed: true,
+			},
+			"fail_at_start": schema.BoolAttribute{
+				Computed: true,
+			},
+			"fail_in_progress": schema.BoolAttribute{
+				Computed: true,
+			},
+			"where_fail": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"bodymessage": schema.StringAttribute{
+				Computed: true,
+			},
+			"map_example": schema.StringAttribute{
+				Computed: true,
+			},
+			"json_example": schema.StringAttribute{
+				Computed: true,
+			},
+			"nested_ref_example": schema.StringAttribute{
+				Computed: true,
+			},
+			"yaml_example": schema.StringAttribute{
+				Computed: true,
+			},
+			"map_fixed": schema.StringAttribute{
+				Computed: true,
+			},
+			"array_map_fixed_example": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *DummyDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *DummyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DummyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 1, data.ID, data.ResourceName, dummyDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.DurationMs = resources_core.OutputInt64(values, "duration_ms", types.Int64Null())
+	data.FailAtStart = resources_core.OutputBool(values, "fail_at_start", types.BoolNull())
+	data.FailInProgress = resources_core.OutputBool(values, "fail_in_progress", types.BoolNull())
+	data.WhereFail = resources_core.OutputInt64(values, "where_fail", types.Int64Null())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.Bodymessage = resources_core.OutputString(values, "bodymessage", types.StringNull())
+	data.MapExample = resources_core.OutputString(values, "map_example", types.StringNull())
+	data.JsonExample = resources_core.OutputString(values, "json_example", types.StringNull())
+	data.NestedRefExample = resources_core.OutputString(values, "nested_ref_example", types.StringNull())
+	data.YamlExample = resources_core.OutputString(values, "yaml_example", types.StringNull())
+	data.MapFixed = resources_core.OutputString(values, "map_fixed", types.StringNull())
+	data.ArrayMapFixedExample = resources_core.OutputString(values, "array_map_fixed_example", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *DummyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -3,6 +3,7 @@ package resources_gen
 import (
 	"context"
 	"strings"
+	"time"
 
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
@@ -21,6 +22,7 @@ import (
 
 var _ resource.Resource = &DummyResource{}
 var _ resource.ResourceWithModifyPlan = &DummyResource{}
+var _ resource.ResourceWithUpgradeState = &DummyResource{}
 
 type DummyResource struct {
 	client *core.UniversalClient
@@ -41,10 +43,17 @@ type DummyModel struct {
 	YamlExample          types.String `tfsdk:"yaml_example"`
 	MapFixed             types.String `tfsdk:"map_fixed"`
 	ArrayMapFixedExample types.String `tfsdk:"array_map_fixed_example"`
+	PendingOperation     types.String `tfsdk:"pending_operation"`
 	DeleteMode           types.String `tfsdk:"delete_mode"`
 	ResumeIfExists       types.Bool   `tfsdk:"resume_if_exists"`
 }
 
+// dummyPollPolicy overrides operation polling for this service.
+var dummyPollPolicy = core.PollPolicy{
+	Initial: time.Second,
+	Max:     5 * time.Second,
+}
+
 func NewDummyResource() resource.Resource {
 	return &DummyResource{}
 }
@@ -95,6 +104,10 @@ func (r *DummyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"array_map_fixed_example": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -107,7 +120,14 @@ func (r *DummyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *DummyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *DummyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -176,6 +196,9 @@ func (r *DummyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithPollPolicy(ctx, dummyPollPolicy)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 1, resourceName)
@@ -213,10 +236,22 @@ func (r *DummyResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 1, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -242,6 +277,10 @@ func (r *DummyResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -264,6 +303,8 @@ func (r *DummyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = core.WithPollPolicy(ctx, dummyPollPolicy)
+
 	params := map[int]string{
 		287: resources_core.FormatInt64(plan.DurationMs),
 		288: resources_core.FormatBool(plan.FailAtStart),
@@ -274,12 +315,18 @@ func (r *DummyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		374: resources_core.FormatString(plan.JsonExample),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -292,6 +339,7 @@ func (r *DummyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if state == nil || state.ID.IsNull() || state.ID.IsUnknown() {
 		return
 	}
+	ctx = core.WithPollPolicy(ctx, dummyPollPolicy)
 
 	if err := resources_core.DeleteResource(ctx, r.client, state.ID.ValueString(), state.DeleteMode.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
@@ -0,0 +1,146 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: flask
+// Service ID: 89
+
+var _ datasource.DataSource = &FlaskDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &FlaskDataSource{}
+
+type FlaskDataSource struct {
+	client *core.UniversalClient
+}
+
+type FlaskDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	Domain            types.String `tfsdk:"domain"`
+	ResourceRealm     types.String `tfsdk:"resource_realm"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
+	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
+	GitPath           types.String `tfsdk:"git_path"`
+	JsonEnv           types.String `tfsdk:"json_env"`
+	HealthPath        types.String `tfsdk:"health_path"`
+}
+
+// flaskDataParams map attributes to the params they show.
+var flaskDataParams = []resources_core.DataParam{
+	{Attribute: "domain", ID: 248},
+	{Attribute: "resource_realm", ID: 249},
+	{Attribute: "resource_cpu", ID: 250},
+	{Attribute: "resource_memory", ID: 251},
+	{Attribute: "resource_instances", ID: 252},
+	{Attribute: "git_path", ID: 253},
+	{Attribute: "json_env", ID: 254},
+	{Attribute: "health_path", ID: 255},
+}
+
+func NewFlaskDataSource() datasource.DataSource {
+	return &FlaskDataSource{}
+}
+
+func (d *FlaskDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flask"
+}
+
+func (d *FlaskDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `flask` (service ID 89) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"git_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"json_env": schema.StringAttribute{
+				Computed: true,
+			},
+			"health_path": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *FlaskDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *FlaskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FlaskDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 89, data.ID, data.ResourceName, flaskDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.GitPath = resources_core.OutputString(values, "git_path", types.StringNull())
+	data.JsonEnv = resources_core.OutputString(values, "json_env", types.StringNull())
+	data.HealthPath = resources_core.OutputString(values, "health_path", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *FlaskDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &FlaskResource{}
 var _ resource.ResourceWithModifyPlan = &FlaskResource{}
+var _ resource.ResourceWithUpgradeState = &FlaskResource{}
 
 type FlaskResource struct {
 	client *core.UniversalClient
@@ -30,12 +33,13 @@ type FlaskModel struct {
 	ResourceName      types.String `tfsdk:"resource_name"`
 	Domain            types.String `tfsdk:"domain"`
 	ResourceRealm     types.String `tfsdk:"resource_realm"`
-	ResourceCPU       types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
 	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
 	GitPath           types.String `tfsdk:"git_path"`
 	JsonEnv           types.String `tfsdk:"json_env"`
 	HealthPath        types.String `tfsdk:"health_path"`
+	PendingOperation  types.String `tfsdk:"pending_operation"`
 	DeleteMode        types.String `tfsdk:"delete_mode"`
 	ResumeIfExists    types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -58,14 +62,23 @@ func (r *FlaskResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"resource_realm": schema.StringAttribute{
 			Required: true,
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"git_path": schema.StringAttribute{
 			Required: true,
@@ -78,6 +91,10 @@ func (r *FlaskResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"health_path": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -90,7 +107,22 @@ func (r *FlaskResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *FlaskResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *FlaskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -159,6 +191,8 @@ func (r *FlaskResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 89, resourceName)
@@ -192,10 +226,22 @@ func (r *FlaskResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 89, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -221,6 +267,10 @@ func (r *FlaskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -251,12 +301,18 @@ func (r *FlaskResource) Update(ctx context.Context, req resource.UpdateRequest,
 		455: resources_core.FormatString(plan.JsonEnv),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
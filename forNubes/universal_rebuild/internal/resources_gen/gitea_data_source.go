@@ -0,0 +1,136 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: gitea
+// Service ID: 99
+
+var _ datasource.DataSource = &GiteaDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &GiteaDataSource{}
+
+type GiteaDataSource struct {
+	client *core.UniversalClient
+}
+
+type GiteaDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
+	ResourceDisk      types.Int64  `tfsdk:"resource_disk"`
+	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
+	Domain            types.String `tfsdk:"domain"`
+	PsqlUid           types.String `tfsdk:"psql_uid"`
+}
+
+// giteaDataParams map attributes to the params they show.
+var giteaDataParams = []resources_core.DataParam{
+	{Attribute: "resource_cpu", ID: 232},
+	{Attribute: "resource_memory", ID: 233},
+	{Attribute: "resource_disk", ID: 234},
+	{Attribute: "resource_instances", ID: 235},
+	{Attribute: "domain", ID: 237},
+	{Attribute: "psql_uid", ID: 238},
+}
+
+func NewGiteaDataSource() datasource.DataSource {
+	return &GiteaDataSource{}
+}
+
+func (d *GiteaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gitea"
+}
+
+func (d *GiteaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `gitea` (service ID 99) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"psql_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_postgres instance (service 90): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_postgres` instance (service 90): its id (UUID) or resource_name.",
+			},
+		},
+	}
+}
+
+func (d *GiteaDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *GiteaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GiteaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 99, data.ID, data.ResourceName, giteaDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputInt64(values, "resource_disk", types.Int64Null())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.PsqlUid = resources_core.OutputString(values, "psql_uid", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *GiteaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
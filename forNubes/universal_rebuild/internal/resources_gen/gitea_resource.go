@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &GiteaResource{}
 var _ resource.ResourceWithModifyPlan = &GiteaResource{}
+var _ resource.ResourceWithUpgradeState = &GiteaResource{}
 
 type GiteaResource struct {
 	client *core.UniversalClient
@@ -28,12 +31,13 @@ type GiteaResource struct {
 type GiteaModel struct {
 	ID                types.String `tfsdk:"id"`
 	ResourceName      types.String `tfsdk:"resource_name"`
-	ResourceCPU       types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
 	ResourceDisk      types.Int64  `tfsdk:"resource_disk"`
 	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
 	Domain            types.String `tfsdk:"domain"`
 	PsqlUid           types.String `tfsdk:"psql_uid"`
+	PendingOperation  types.String `tfsdk:"pending_operation"`
 	DeleteMode        types.String `tfsdk:"delete_mode"`
 	ResumeIfExists    types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -50,23 +54,38 @@ func (r *GiteaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	attrs := map[string]schema.Attribute{
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.Int64Attribute{
 			Required: true,
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"domain": schema.StringAttribute{
 			Required: true,
 		},
 		"psql_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_postgres instance (service 90): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_postgres` instance (service 90): its id (UUID) or resource_name.",
+		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
 		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
@@ -80,7 +99,22 @@ func (r *GiteaResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *GiteaResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *GiteaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -97,6 +131,13 @@ func (r *GiteaResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "psql_uid", ServiceID: 90, Value: config.PsqlUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *GiteaModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -149,6 +190,9 @@ func (r *GiteaResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{238: 90})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 99, resourceName)
@@ -180,10 +224,22 @@ func (r *GiteaResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 99, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -209,6 +265,10 @@ func (r *GiteaResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -231,6 +291,8 @@ func (r *GiteaResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{238: 90})
+
 	params := map[int]string{
 		259: resources_core.FormatInt64(plan.ResourceCPU),
 		260: resources_core.FormatInt64(plan.ResourceMemory),
@@ -238,12 +300,18 @@ func (r *GiteaResource) Update(ctx context.Context, req resource.UpdateRequest,
 		262: resources_core.FormatInt64(plan.ResourceInstances),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
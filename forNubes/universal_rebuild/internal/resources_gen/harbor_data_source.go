@@ -0,0 +1,142 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: harbor
+// Service ID: 82
+
+var _ datasource.DataSource = &HarborDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &HarborDataSource{}
+
+type HarborDataSource struct {
+	client *core.UniversalClient
+}
+
+type HarborDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	Domain            types.String `tfsdk:"domain"`
+	Emails            types.String `tfsdk:"emails"`
+	ResourceRealm     types.String `tfsdk:"resource_realm"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
+	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
+	S3Uid             types.String `tfsdk:"s3_uid"`
+}
+
+// harborDataParams map attributes to the params they show.
+var harborDataParams = []resources_core.DataParam{
+	{Attribute: "domain", ID: 16},
+	{Attribute: "emails", ID: 17},
+	{Attribute: "resource_realm", ID: 110},
+	{Attribute: "resource_cpu", ID: 228},
+	{Attribute: "resource_memory", ID: 229},
+	{Attribute: "resource_instances", ID: 230},
+	{Attribute: "s3_uid", ID: 231},
+}
+
+func NewHarborDataSource() datasource.DataSource {
+	return &HarborDataSource{}
+}
+
+func (d *HarborDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_harbor"
+}
+
+func (d *HarborDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `harbor` (service ID 82) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"emails": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"s3_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+			},
+		},
+	}
+}
+
+func (d *HarborDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *HarborDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HarborDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 82, data.ID, data.ResourceName, harborDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.Emails = resources_core.OutputString(values, "emails", types.StringNull())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.S3Uid = resources_core.OutputString(values, "s3_uid", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *HarborDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
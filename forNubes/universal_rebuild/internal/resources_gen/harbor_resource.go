@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &HarborResource{}
 var _ resource.ResourceWithModifyPlan = &HarborResource{}
+var _ resource.ResourceWithUpgradeState = &HarborResource{}
 
 type HarborResource struct {
 	client *core.UniversalClient
@@ -31,10 +34,11 @@ type HarborModel struct {
 	Domain            types.String `tfsdk:"domain"`
 	Emails            types.String `tfsdk:"emails"`
 	ResourceRealm     types.String `tfsdk:"resource_realm"`
-	ResourceCPU       types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
 	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
 	S3Uid             types.String `tfsdk:"s3_uid"`
+	PendingOperation  types.String `tfsdk:"pending_operation"`
 	DeleteMode        types.String `tfsdk:"delete_mode"`
 	ResumeIfExists    types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -60,17 +64,32 @@ func (r *HarborResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"resource_realm": schema.StringAttribute{
 			Required: true,
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"s3_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
 		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
@@ -84,7 +103,22 @@ func (r *HarborResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *HarborResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *HarborResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -101,6 +135,13 @@ func (r *HarborResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "s3_uid", ServiceID: 12, Value: config.S3Uid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *HarborModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -153,6 +194,9 @@ func (r *HarborResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{231: 12})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 82, resourceName)
@@ -185,10 +229,22 @@ func (r *HarborResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 82, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,6 +270,10 @@ func (r *HarborResource) Read(ctx context.Context, req resource.ReadRequest, res
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -236,14 +296,22 @@ func (r *HarborResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{231: 12})
+
 	params := map[int]string{}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -0,0 +1,140 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: kafka
+// Service ID: 116
+
+var _ datasource.DataSource = &KafkaDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &KafkaDataSource{}
+
+type KafkaDataSource struct {
+	client *core.UniversalClient
+}
+
+type KafkaDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ResourceName              types.String `tfsdk:"resource_name"`
+	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
+	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
+	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
+	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
+	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
+	ResourceRealm             types.String `tfsdk:"resource_realm"`
+}
+
+// kafkaDataParams map attributes to the params they show.
+var kafkaDataParams = []resources_core.DataParam{
+	{Attribute: "resource_instances", ID: 461},
+	{Attribute: "resource_memory", ID: 462},
+	{Attribute: "resource_cpu", ID: 463},
+	{Attribute: "resource_disk", ID: 464},
+	{Attribute: "need_external_address_master", ID: 465},
+	{Attribute: "ip_space_name_master", ID: 466},
+	{Attribute: "resource_realm", ID: 469},
+}
+
+func NewKafkaDataSource() datasource.DataSource {
+	return &KafkaDataSource{}
+}
+
+func (d *KafkaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kafka"
+}
+
+func (d *KafkaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `kafka` (service ID 116) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"need_external_address_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ip_space_name_master": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *KafkaDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *KafkaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KafkaDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 116, data.ID, data.ResourceName, kafkaDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputInt64(values, "resource_disk", types.Int64Null())
+	data.NeedExternalAddressMaster = resources_core.OutputBool(values, "need_external_address_master", types.BoolNull())
+	data.IpSpaceNameMaster = resources_core.OutputString(values, "ip_space_name_master", types.StringNull())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *KafkaDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,152 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: lucee
+// Service ID: 94
+
+var _ datasource.DataSource = &LuceeDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &LuceeDataSource{}
+
+type LuceeDataSource struct {
+	client *core.UniversalClient
+}
+
+type LuceeDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	Domain            types.String `tfsdk:"domain"`
+	GitPath           types.String `tfsdk:"git_path"`
+	JsonEnv           types.String `tfsdk:"json_env"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
+	ResourceRealm     types.String `tfsdk:"resource_realm"`
+	HealthPath        types.String `tfsdk:"health_path"`
+	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
+	AppVersion        types.String `tfsdk:"app_version"`
+}
+
+// luceeDataParams map attributes to the params they show.
+var luceeDataParams = []resources_core.DataParam{
+	{Attribute: "domain", ID: 103},
+	{Attribute: "git_path", ID: 104},
+	{Attribute: "json_env", ID: 105},
+	{Attribute: "resource_cpu", ID: 106},
+	{Attribute: "resource_memory", ID: 107},
+	{Attribute: "resource_realm", ID: 108},
+	{Attribute: "health_path", ID: 132},
+	{Attribute: "resource_instances", ID: 139},
+	{Attribute: "app_version", ID: 263},
+}
+
+func NewLuceeDataSource() datasource.DataSource {
+	return &LuceeDataSource{}
+}
+
+func (d *LuceeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lucee"
+}
+
+func (d *LuceeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `lucee` (service ID 94) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"git_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"json_env": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"health_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"app_version": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *LuceeDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *LuceeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LuceeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 94, data.ID, data.ResourceName, luceeDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.GitPath = resources_core.OutputString(values, "git_path", types.StringNull())
+	data.JsonEnv = resources_core.OutputString(values, "json_env", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.HealthPath = resources_core.OutputString(values, "health_path", types.StringNull())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.AppVersion = resources_core.OutputString(values, "app_version", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *LuceeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &LuceeResource{}
 var _ resource.ResourceWithModifyPlan = &LuceeResource{}
+var _ resource.ResourceWithUpgradeState = &LuceeResource{}
 
 type LuceeResource struct {
 	client *core.UniversalClient
@@ -31,12 +34,13 @@ type LuceeModel struct {
 	Domain            types.String `tfsdk:"domain"`
 	GitPath           types.String `tfsdk:"git_path"`
 	JsonEnv           types.String `tfsdk:"json_env"`
-	ResourceCPU       types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
 	ResourceRealm     types.String `tfsdk:"resource_realm"`
 	HealthPath        types.String `tfsdk:"health_path"`
 	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
 	AppVersion        types.String `tfsdk:"app_version"`
+	PendingOperation  types.String `tfsdk:"pending_operation"`
 	DeleteMode        types.String `tfsdk:"delete_mode"`
 	ResumeIfExists    types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -64,11 +68,17 @@ func (r *LuceeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			Computed: true,
 			Default:  stringdefault.StaticString("{ \"param\": \"value\" }"),
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_realm": schema.StringAttribute{
 			Required: true,
@@ -78,10 +88,17 @@ func (r *LuceeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"app_version": schema.StringAttribute{
 			Required: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -94,7 +111,22 @@ func (r *LuceeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *LuceeResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *LuceeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -163,6 +195,8 @@ func (r *LuceeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 94, resourceName)
@@ -197,10 +231,22 @@ func (r *LuceeResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 94, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -226,6 +272,10 @@ func (r *LuceeResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -258,12 +308,18 @@ func (r *LuceeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		264: resources_core.FormatString(plan.AppVersion),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -0,0 +1,184 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: mariadb
+// Service ID: 115
+
+var _ datasource.DataSource = &MariadbDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &MariadbDataSource{}
+
+type MariadbDataSource struct {
+	client *core.UniversalClient
+}
+
+type MariadbDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ResourceName              types.String `tfsdk:"resource_name"`
+	ResourceRealm             types.String `tfsdk:"resource_realm"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
+	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
+	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
+	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
+	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
+	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext_backup_schedule"`
+	AppVersion                types.String `tfsdk:"app_version"`
+	AutoScale                 types.Bool   `tfsdk:"auto_scale"`
+	AutoScalePercentage       types.Int64  `tfsdk:"auto_scale_percentage"`
+	AutoScaleTechWindow       types.Int64  `tfsdk:"auto_scale_tech_window"`
+	AutoScaleQuotaGb          types.Int64  `tfsdk:"auto_scale_quota_gb"`
+	S3Uid                     types.String `tfsdk:"s3_uid"`
+}
+
+// mariadbDataParams map attributes to the params they show.
+var mariadbDataParams = []resources_core.DataParam{
+	{Attribute: "resource_realm", ID: 422},
+	{Attribute: "resource_cpu", ID: 424},
+	{Attribute: "resource_memory", ID: 425},
+	{Attribute: "resource_disk", ID: 426},
+	{Attribute: "resource_instances", ID: 427},
+	{Attribute: "need_external_address_master", ID: 428},
+	{Attribute: "ip_space_name_master", ID: 429},
+	{Attribute: "ext_backup_schedule", ID: 430},
+	{Attribute: "app_version", ID: 431},
+	{Attribute: "auto_scale", ID: 432},
+	{Attribute: "auto_scale_percentage", ID: 433},
+	{Attribute: "auto_scale_tech_window", ID: 434},
+	{Attribute: "auto_scale_quota_gb", ID: 435},
+	{Attribute: "s3_uid", ID: 459},
+}
+
+func NewMariadbDataSource() datasource.DataSource {
+	return &MariadbDataSource{}
+}
+
+func (d *MariadbDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mariadb"
+}
+
+func (d *MariadbDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `mariadb` (service ID 115) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"need_external_address_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ip_space_name_master": schema.StringAttribute{
+				Computed: true,
+			},
+			"ext_backup_schedule": schema.StringAttribute{
+				Computed: true,
+			},
+			"app_version": schema.StringAttribute{
+				Computed: true,
+			},
+			"auto_scale": schema.BoolAttribute{
+				Computed: true,
+			},
+			"auto_scale_percentage": schema.Int64Attribute{
+				Computed: true,
+			},
+			"auto_scale_tech_window": schema.Int64Attribute{
+				Computed: true,
+			},
+			"auto_scale_quota_gb": schema.Int64Attribute{
+				Computed: true,
+			},
+			"s3_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+			},
+		},
+	}
+}
+
+func (d *MariadbDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *MariadbDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MariadbDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 115, data.ID, data.ResourceName, mariadbDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputInt64(values, "resource_disk", types.Int64Null())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.NeedExternalAddressMaster = resources_core.OutputBool(values, "need_external_address_master", types.BoolNull())
+	data.IpSpaceNameMaster = resources_core.OutputString(values, "ip_space_name_master", types.StringNull())
+	data.ExtBACKUPSCHEDULE = resources_core.OutputString(values, "ext_backup_schedule", types.StringNull())
+	data.AppVersion = resources_core.OutputString(values, "app_version", types.StringNull())
+	data.AutoScale = resources_core.OutputBool(values, "auto_scale", types.BoolNull())
+	data.AutoScalePercentage = resources_core.OutputInt64(values, "auto_scale_percentage", types.Int64Null())
+	data.AutoScaleTechWindow = resources_core.OutputInt64(values, "auto_scale_tech_window", types.Int64Null())
+	data.AutoScaleQuotaGb = resources_core.OutputInt64(values, "auto_scale_quota_gb", types.Int64Null())
+	data.S3Uid = resources_core.OutputString(values, "s3_uid", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *MariadbDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -7,11 +7,13 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +23,7 @@ import (
 
 var _ resource.Resource = &MariadbResource{}
 var _ resource.ResourceWithModifyPlan = &MariadbResource{}
+var _ resource.ResourceWithUpgradeState = &MariadbResource{}
 
 type MariadbResource struct {
 	client *core.UniversalClient
@@ -30,19 +33,20 @@ type MariadbModel struct {
 	ID                        types.String `tfsdk:"id"`
 	ResourceName              types.String `tfsdk:"resource_name"`
 	ResourceRealm             types.String `tfsdk:"resource_realm"`
-	ResourceCPU               types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
 	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
 	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
 	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
 	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
-	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e"`
+	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext_backup_schedule"`
 	AppVersion                types.String `tfsdk:"app_version"`
 	AutoScale                 types.Bool   `tfsdk:"auto_scale"`
 	AutoScalePercentage       types.Int64  `tfsdk:"auto_scale_percentage"`
 	AutoScaleTechWindow       types.Int64  `tfsdk:"auto_scale_tech_window"`
 	AutoScaleQuotaGb          types.Int64  `tfsdk:"auto_scale_quota_gb"`
 	S3Uid                     types.String `tfsdk:"s3_uid"`
+	PendingOperation          types.String `tfsdk:"pending_operation"`
 	DeleteMode                types.String `tfsdk:"delete_mode"`
 	ResumeIfExists            types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -62,17 +66,26 @@ func (r *MariadbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		"resource_realm": schema.StringAttribute{
 			Required: true,
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.Int64Attribute{
 			Required: true,
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"need_external_address_master": schema.BoolAttribute{
 			Required: true,
@@ -80,10 +93,13 @@ func (r *MariadbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		"ip_space_name_master": schema.StringAttribute{
 			Optional: true,
 		},
-		"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e": schema.StringAttribute{
+		"ext_backup_schedule": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
 			Default:  stringdefault.StaticString("0 * * * *"),
+			Validators: []validator.String{
+				resources_core.CronValidator(),
+			},
 		},
 		"app_version": schema.StringAttribute{
 			Required: true,
@@ -95,6 +111,9 @@ func (r *MariadbResource) Schema(ctx context.Context, req resource.SchemaRequest
 			Optional: true,
 			Computed: true,
 			Default:  int64default.StaticInt64(10),
+			Validators: []validator.Int64{
+				int64validator.Between(1, 100),
+			},
 		},
 		"auto_scale_tech_window": schema.Int64Attribute{
 			Optional: true,
@@ -107,7 +126,13 @@ func (r *MariadbResource) Schema(ctx context.Context, req resource.SchemaRequest
 			Default:  int64default.StaticInt64(1),
 		},
 		"s3_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
 		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
@@ -121,7 +146,23 @@ func (r *MariadbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *MariadbResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e": "ext_backup_schedule",
+					"resource_c_p_u":                    "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *MariadbResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -138,6 +179,13 @@ func (r *MariadbResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "s3_uid", ServiceID: 12, Value: config.S3Uid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *MariadbModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -190,6 +238,9 @@ func (r *MariadbResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{459: 12})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 115, resourceName)
@@ -229,10 +280,22 @@ func (r *MariadbResource) Create(ctx context.Context, req resource.CreateRequest
 	id, err := resources_core.CreateResource(ctx, r.client, 115, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -258,6 +321,10 @@ func (r *MariadbResource) Read(ctx context.Context, req resource.ReadRequest, re
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -280,6 +347,8 @@ func (r *MariadbResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{459: 12})
+
 	params := map[int]string{
 		436: resources_core.FormatInt64(plan.ResourceCPU),
 		437: resources_core.FormatInt64(plan.ResourceMemory),
@@ -294,12 +363,18 @@ func (r *MariadbResource) Update(ctx context.Context, req resource.UpdateRequest
 		446: resources_core.FormatInt64(plan.AutoScaleQuotaGb),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
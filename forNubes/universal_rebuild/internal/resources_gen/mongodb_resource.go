@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &MongodbResource{}
 var _ resource.ResourceWithModifyPlan = &MongodbResource{}
+var _ resource.ResourceWithUpgradeState = &MongodbResource{}
 
 type MongodbResource struct {
 	client *core.UniversalClient
@@ -30,11 +33,12 @@ type MongodbModel struct {
 	ResourceName              types.String `tfsdk:"resource_name"`
 	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
 	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
-	ResourceCPU               types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
 	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
 	ResourceRealm             types.String `tfsdk:"resource_realm"`
 	NeedExternalAddressMaster types.String `tfsdk:"need_external_address_master"`
 	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
+	PendingOperation          types.String `tfsdk:"pending_operation"`
 	DeleteMode                types.String `tfsdk:"delete_mode"`
 	ResumeIfExists            types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -53,12 +57,21 @@ func (r *MongodbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		"resource_name": schema.StringAttribute{Required: true},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.Int64Attribute{
 			Required: true,
@@ -72,6 +85,10 @@ func (r *MongodbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		"ip_space_name_master": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -84,7 +101,22 @@ func (r *MongodbResource) Schema(ctx context.Context, req resource.SchemaRequest
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *MongodbResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *MongodbResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -153,6 +185,8 @@ func (r *MongodbResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 92, resourceName)
@@ -185,10 +219,22 @@ func (r *MongodbResource) Create(ctx context.Context, req resource.CreateRequest
 	id, err := resources_core.CreateResource(ctx, r.client, 92, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,6 +260,10 @@ func (r *MongodbResource) Read(ctx context.Context, req resource.ReadRequest, re
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -238,12 +288,18 @@ func (r *MongodbResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	params := map[int]string{}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
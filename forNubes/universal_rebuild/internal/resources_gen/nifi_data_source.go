@@ -0,0 +1,124 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: nifi
+// Service ID: 117
+
+var _ datasource.DataSource = &NifiDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &NifiDataSource{}
+
+type NifiDataSource struct {
+	client *core.UniversalClient
+}
+
+type NifiDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	KafkaUid     types.String `tfsdk:"kafka_uid"`
+	Partitions   types.Int64  `tfsdk:"partitions"`
+	Replicas     types.Int64  `tfsdk:"replicas"`
+	NameTopic    types.String `tfsdk:"name_topic"`
+}
+
+// nifiDataParams map attributes to the params they show.
+var nifiDataParams = []resources_core.DataParam{
+	{Attribute: "kafka_uid", ID: 471},
+	{Attribute: "partitions", ID: 472},
+	{Attribute: "replicas", ID: 473},
+	{Attribute: "name_topic", ID: 474},
+}
+
+func NewNifiDataSource() datasource.DataSource {
+	return &NifiDataSource{}
+}
+
+func (d *NifiDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nifi"
+}
+
+func (d *NifiDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `nifi` (service ID 117) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"kafka_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_kafka instance (service 116): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_kafka` instance (service 116): its id (UUID) or resource_name.",
+			},
+			"partitions": schema.Int64Attribute{
+				Computed: true,
+			},
+			"replicas": schema.Int64Attribute{
+				Computed: true,
+			},
+			"name_topic": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *NifiDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *NifiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NifiDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 117, data.ID, data.ResourceName, nifiDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.KafkaUid = resources_core.OutputString(values, "kafka_uid", types.StringNull())
+	data.Partitions = resources_core.OutputInt64(values, "partitions", types.Int64Null())
+	data.Replicas = resources_core.OutputInt64(values, "replicas", types.Int64Null())
+	data.NameTopic = resources_core.OutputString(values, "name_topic", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *NifiDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -20,20 +20,22 @@ import (
 
 var _ resource.Resource = &NifiResource{}
 var _ resource.ResourceWithModifyPlan = &NifiResource{}
+var _ resource.ResourceWithUpgradeState = &NifiResource{}
 
 type NifiResource struct {
 	client *core.UniversalClient
 }
 
 type NifiModel struct {
-	ID             types.String `tfsdk:"id"`
-	ResourceName   types.String `tfsdk:"resource_name"`
-	KafkaUid       types.String `tfsdk:"kafka_uid"`
-	Partitions     types.Int64  `tfsdk:"partitions"`
-	Replicas       types.Int64  `tfsdk:"replicas"`
-	NameTopic      types.String `tfsdk:"name_topic"`
-	DeleteMode     types.String `tfsdk:"delete_mode"`
-	ResumeIfExists types.Bool   `tfsdk:"resume_if_exists"`
+	ID               types.String `tfsdk:"id"`
+	ResourceName     types.String `tfsdk:"resource_name"`
+	KafkaUid         types.String `tfsdk:"kafka_uid"`
+	Partitions       types.Int64  `tfsdk:"partitions"`
+	Replicas         types.Int64  `tfsdk:"replicas"`
+	NameTopic        types.String `tfsdk:"name_topic"`
+	PendingOperation types.String `tfsdk:"pending_operation"`
+	DeleteMode       types.String `tfsdk:"delete_mode"`
+	ResumeIfExists   types.Bool   `tfsdk:"resume_if_exists"`
 }
 
 func NewNifiResource() resource.Resource {
@@ -49,7 +51,9 @@ func (r *NifiResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"kafka_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_kafka instance (service 116): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_kafka` instance (service 116): its id (UUID) or resource_name.",
 		},
 		"partitions": schema.Int64Attribute{
 			Required: true,
@@ -60,6 +64,10 @@ func (r *NifiResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		"name_topic": schema.StringAttribute{
 			Required: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -72,7 +80,14 @@ func (r *NifiResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *NifiResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *NifiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -89,6 +104,13 @@ func (r *NifiResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "kafka_uid", ServiceID: 116, Value: config.KafkaUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *NifiModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -141,6 +163,9 @@ func (r *NifiResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{471: 116})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 117, resourceName)
@@ -170,10 +195,22 @@ func (r *NifiResource) Create(ctx context.Context, req resource.CreateRequest, r
 	id, err := resources_core.CreateResource(ctx, r.client, 117, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -199,6 +236,10 @@ func (r *NifiResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -221,17 +262,25 @@ func (r *NifiResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{471: 116})
+
 	params := map[int]string{
 		477: resources_core.FormatInt64(plan.Partitions),
 		478: resources_core.FormatInt64(plan.Replicas),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
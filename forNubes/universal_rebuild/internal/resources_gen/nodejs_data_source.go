@@ -0,0 +1,152 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: nodejs
+// Service ID: 95
+
+var _ datasource.DataSource = &NodejsDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &NodejsDataSource{}
+
+type NodejsDataSource struct {
+	client *core.UniversalClient
+}
+
+type NodejsDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ResourceName      types.String `tfsdk:"resource_name"`
+	Domain            types.String `tfsdk:"domain"`
+	GitPath           types.String `tfsdk:"git_path"`
+	HealthPath        types.String `tfsdk:"health_path"`
+	JsonEnv           types.String `tfsdk:"json_env"`
+	ResourceCPU       types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory    types.Int64  `tfsdk:"resource_memory"`
+	ResourceInstances types.Int64  `tfsdk:"resource_instances"`
+	ResourceRealm     types.String `tfsdk:"resource_realm"`
+	AppVersion        types.String `tfsdk:"app_version"`
+}
+
+// nodejsDataParams map attributes to the params they show.
+var nodejsDataParams = []resources_core.DataParam{
+	{Attribute: "domain", ID: 149},
+	{Attribute: "git_path", ID: 150},
+	{Attribute: "health_path", ID: 151},
+	{Attribute: "json_env", ID: 152},
+	{Attribute: "resource_cpu", ID: 153},
+	{Attribute: "resource_memory", ID: 154},
+	{Attribute: "resource_instances", ID: 155},
+	{Attribute: "resource_realm", ID: 156},
+	{Attribute: "app_version", ID: 270},
+}
+
+func NewNodejsDataSource() datasource.DataSource {
+	return &NodejsDataSource{}
+}
+
+func (d *NodejsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodejs"
+}
+
+func (d *NodejsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `nodejs` (service ID 95) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"git_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"health_path": schema.StringAttribute{
+				Computed: true,
+			},
+			"json_env": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"app_version": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *NodejsDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *NodejsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NodejsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 95, data.ID, data.ResourceName, nodejsDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.GitPath = resources_core.OutputString(values, "git_path", types.StringNull())
+	data.HealthPath = resources_core.OutputString(values, "health_path", types.StringNull())
+	data.JsonEnv = resources_core.OutputString(values, "json_env", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.AppVersion = resources_core.OutputString(values, "app_version", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *NodejsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,134 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: pgadmin
+// Service ID: 96
+
+var _ datasource.DataSource = &PgadminDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &PgadminDataSource{}
+
+type PgadminDataSource struct {
+	client *core.UniversalClient
+}
+
+type PgadminDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	ResourceName   types.String `tfsdk:"resource_name"`
+	Domain         types.String `tfsdk:"domain"`
+	ResourceCPU    types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory types.Int64  `tfsdk:"resource_memory"`
+	ResourceDisk   types.Int64  `tfsdk:"resource_disk"`
+	ResourceRealm  types.String `tfsdk:"resource_realm"`
+	Login          types.String `tfsdk:"login"`
+}
+
+// pgadminDataParams map attributes to the params they show.
+var pgadminDataParams = []resources_core.DataParam{
+	{Attribute: "domain", ID: 164},
+	{Attribute: "resource_cpu", ID: 165},
+	{Attribute: "resource_memory", ID: 166},
+	{Attribute: "resource_disk", ID: 167},
+	{Attribute: "resource_realm", ID: 169},
+	{Attribute: "login", ID: 170},
+}
+
+func NewPgadminDataSource() datasource.DataSource {
+	return &PgadminDataSource{}
+}
+
+func (d *PgadminDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pgadmin"
+}
+
+func (d *PgadminDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `pgadmin` (service ID 96) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"domain": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"login": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *PgadminDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *PgadminDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PgadminDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 96, data.ID, data.ResourceName, pgadminDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.Domain = resources_core.OutputString(values, "domain", types.StringNull())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputInt64(values, "resource_disk", types.Int64Null())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.Login = resources_core.OutputString(values, "login", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *PgadminDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -7,10 +7,13 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,23 +23,26 @@ import (
 
 var _ resource.Resource = &PgadminResource{}
 var _ resource.ResourceWithModifyPlan = &PgadminResource{}
+var _ resource.ResourceWithUpgradeState = &PgadminResource{}
 
 type PgadminResource struct {
 	client *core.UniversalClient
 }
 
 type PgadminModel struct {
-	ID             types.String `tfsdk:"id"`
-	ResourceName   types.String `tfsdk:"resource_name"`
-	Domain         types.String `tfsdk:"domain"`
-	ResourceCPU    types.Int64  `tfsdk:"resource_c_p_u"`
-	ResourceMemory types.Int64  `tfsdk:"resource_memory"`
-	ResourceDisk   types.Int64  `tfsdk:"resource_disk"`
-	ResourceRealm  types.String `tfsdk:"resource_realm"`
-	Login          types.String `tfsdk:"login"`
-	Password       types.String `tfsdk:"password"`
-	DeleteMode     types.String `tfsdk:"delete_mode"`
-	ResumeIfExists types.Bool   `tfsdk:"resume_if_exists"`
+	ID               types.String `tfsdk:"id"`
+	ResourceName     types.String `tfsdk:"resource_name"`
+	Domain           types.String `tfsdk:"domain"`
+	ResourceCPU      types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory   types.Int64  `tfsdk:"resource_memory"`
+	ResourceDisk     types.Int64  `tfsdk:"resource_disk"`
+	ResourceRealm    types.String `tfsdk:"resource_realm"`
+	Login            types.String `tfsdk:"login"`
+	Password         types.String `tfsdk:"password"`
+	PasswordVersion  types.Int64  `tfsdk:"password_version"`
+	PendingOperation types.String `tfsdk:"pending_operation"`
+	DeleteMode       types.String `tfsdk:"delete_mode"`
+	ResumeIfExists   types.Bool   `tfsdk:"resume_if_exists"`
 }
 
 func NewPgadminResource() resource.Resource {
@@ -54,11 +60,17 @@ func (r *PgadminResource) Schema(ctx context.Context, req resource.SchemaRequest
 		"domain": schema.StringAttribute{
 			Required: true,
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.Int64Attribute{
 			Required: true,
@@ -70,7 +82,16 @@ func (r *PgadminResource) Schema(ctx context.Context, req resource.SchemaRequest
 			Required: true,
 		},
 		"password": schema.StringAttribute{
-			Required: true,
+			Required:  true,
+			Sensitive: true,
+			WriteOnly: true,
+		},
+		"password_version": schema.Int64Attribute{
+			Optional: true,
+		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
 		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
@@ -84,7 +105,22 @@ func (r *PgadminResource) Schema(ctx context.Context, req resource.SchemaRequest
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *PgadminResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *PgadminResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -149,10 +185,14 @@ func (r *PgadminResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 func (r *PgadminResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data PgadminModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &data.Password)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithSensitiveParams(ctx, 171)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 96, resourceName)
@@ -185,10 +225,24 @@ func (r *PgadminResource) Create(ctx context.Context, req resource.CreateRequest
 	id, err := resources_core.CreateResource(ctx, r.client, 96, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			data.Password = types.StringNull()
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
+	data.Password = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,6 +268,10 @@ func (r *PgadminResource) Read(ctx context.Context, req resource.ReadRequest, re
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -236,18 +294,27 @@ func (r *PgadminResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	ctx = core.WithSensitiveParams(ctx, 171)
+
 	params := map[int]string{
 		172: resources_core.FormatInt64(plan.ResourceCPU),
 		173: resources_core.FormatInt64(plan.ResourceMemory),
 		174: resources_core.FormatInt64(plan.ResourceDisk),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
+	plan.Password = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -0,0 +1,226 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: postgres
+// Service ID: 90
+
+var _ datasource.DataSource = &PostgresDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &PostgresDataSource{}
+
+type PostgresDataSource struct {
+	client *core.UniversalClient
+}
+
+type PostgresDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ResourceName              types.String `tfsdk:"resource_name"`
+	S3Uid                     types.String `tfsdk:"s3_uid"`
+	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
+	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
+	ResourceDisk              types.String `tfsdk:"resource_disk"`
+	ResourceRealm             types.String `tfsdk:"resource_realm"`
+	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
+	NeedExternalAddressSlave  types.Bool   `tfsdk:"need_external_address_slave"`
+	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext_backup_schedule"`
+	ExtBACKUPNUMTORETAIN      types.Int64  `tfsdk:"ext_backup_num_to_retain"`
+	AppVersion                types.String `tfsdk:"app_version"`
+	JsonParameters            types.String `tfsdk:"json_parameters"`
+	EnablePgPoolerMaster      types.Bool   `tfsdk:"enable_pg_pooler_master"`
+	EnablePgPoolerSlave       types.Bool   `tfsdk:"enable_pg_pooler_slave"`
+	AllowNoSSL                types.Bool   `tfsdk:"allow_no_ssl"`
+	AutoScale                 types.Bool   `tfsdk:"auto_scale"`
+	AutoScalePercentage       types.Int64  `tfsdk:"auto_scale_percentage"`
+	AutoScaleTechWindow       types.Int64  `tfsdk:"auto_scale_tech_window"`
+	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
+	IpSpaceNameSlave          types.String `tfsdk:"ip_space_name_slave"`
+	AutoScaleQuotaGb          types.String `tfsdk:"auto_scale_quota_gb"`
+}
+
+// postgresDataParams map attributes to the params they show.
+var postgresDataParams = []resources_core.DataParam{
+	{Attribute: "s3_uid", ID: 23},
+	{Attribute: "resource_instances", ID: 80},
+	{Attribute: "resource_memory", ID: 81},
+	{Attribute: "resource_cpu", ID: 82},
+	{Attribute: "resource_disk", ID: 83},
+	{Attribute: "resource_realm", ID: 102},
+	{Attribute: "need_external_address_master", ID: 145},
+	{Attribute: "need_external_address_slave", ID: 146},
+	{Attribute: "ext_backup_schedule", ID: 265},
+	{Attribute: "ext_backup_num_to_retain", ID: 266},
+	{Attribute: "app_version", ID: 310},
+	{Attribute: "json_parameters", ID: 311},
+	{Attribute: "enable_pg_pooler_master", ID: 312},
+	{Attribute: "enable_pg_pooler_slave", ID: 313},
+	{Attribute: "allow_no_ssl", ID: 314},
+	{Attribute: "auto_scale", ID: 329},
+	{Attribute: "auto_scale_percentage", ID: 330},
+	{Attribute: "auto_scale_tech_window", ID: 331},
+	{Attribute: "ip_space_name_master", ID: 337},
+	{Attribute: "ip_space_name_slave", ID: 338},
+	{Attribute: "auto_scale_quota_gb", ID: 339},
+}
+
+func NewPostgresDataSource() datasource.DataSource {
+	return &PostgresDataSource{}
+}
+
+func (d *PostgresDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_postgres"
+}
+
+func (d *PostgresDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `postgres` (service ID 90) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"s3_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"need_external_address_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"need_external_address_slave": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ext_backup_schedule": schema.StringAttribute{
+				Computed: true,
+			},
+			"ext_backup_num_to_retain": schema.Int64Attribute{
+				Computed: true,
+			},
+			"app_version": schema.StringAttribute{
+				Computed: true,
+			},
+			"json_parameters": schema.StringAttribute{
+				Computed: true,
+			},
+			"enable_pg_pooler_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"enable_pg_pooler_slave": schema.BoolAttribute{
+				Computed: true,
+			},
+			"allow_no_ssl": schema.BoolAttribute{
+				Computed: true,
+			},
+			"auto_scale": schema.BoolAttribute{
+				Computed: true,
+			},
+			"auto_scale_percentage": schema.Int64Attribute{
+				Computed: true,
+			},
+			"auto_scale_tech_window": schema.Int64Attribute{
+				Computed: true,
+			},
+			"ip_space_name_master": schema.StringAttribute{
+				Computed: true,
+			},
+			"ip_space_name_slave": schema.StringAttribute{
+				Computed: true,
+			},
+			"auto_scale_quota_gb": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *PostgresDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *PostgresDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PostgresDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 90, data.ID, data.ResourceName, postgresDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.S3Uid = resources_core.OutputString(values, "s3_uid", types.StringNull())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputString(values, "resource_disk", types.StringNull())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.NeedExternalAddressMaster = resources_core.OutputBool(values, "need_external_address_master", types.BoolNull())
+	data.NeedExternalAddressSlave = resources_core.OutputBool(values, "need_external_address_slave", types.BoolNull())
+	data.ExtBACKUPSCHEDULE = resources_core.OutputString(values, "ext_backup_schedule", types.StringNull())
+	data.ExtBACKUPNUMTORETAIN = resources_core.OutputInt64(values, "ext_backup_num_to_retain", types.Int64Null())
+	data.AppVersion = resources_core.OutputString(values, "app_version", types.StringNull())
+	data.JsonParameters = resources_core.OutputString(values, "json_parameters", types.StringNull())
+	data.EnablePgPoolerMaster = resources_core.OutputBool(values, "enable_pg_pooler_master", types.BoolNull())
+	data.EnablePgPoolerSlave = resources_core.OutputBool(values, "enable_pg_pooler_slave", types.BoolNull())
+	data.AllowNoSSL = resources_core.OutputBool(values, "allow_no_ssl", types.BoolNull())
+	data.AutoScale = resources_core.OutputBool(values, "auto_scale", types.BoolNull())
+	data.AutoScalePercentage = resources_core.OutputInt64(values, "auto_scale_percentage", types.Int64Null())
+	data.AutoScaleTechWindow = resources_core.OutputInt64(values, "auto_scale_tech_window", types.Int64Null())
+	data.IpSpaceNameMaster = resources_core.OutputString(values, "ip_space_name_master", types.StringNull())
+	data.IpSpaceNameSlave = resources_core.OutputString(values, "ip_space_name_slave", types.StringNull())
+	data.AutoScaleQuotaGb = resources_core.OutputString(values, "auto_scale_quota_gb", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *PostgresDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
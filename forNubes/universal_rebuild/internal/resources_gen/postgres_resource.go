@@ -7,11 +7,13 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -21,6 +23,7 @@ import (
 
 var _ resource.Resource = &PostgresResource{}
 var _ resource.ResourceWithModifyPlan = &PostgresResource{}
+var _ resource.ResourceWithUpgradeState = &PostgresResource{}
 
 type PostgresResource struct {
 	client *core.UniversalClient
@@ -32,24 +35,25 @@ type PostgresModel struct {
 	S3Uid                     types.String `tfsdk:"s3_uid"`
 	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
 	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
-	ResourceCPU               types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
 	ResourceDisk              types.String `tfsdk:"resource_disk"`
 	ResourceRealm             types.String `tfsdk:"resource_realm"`
 	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
 	NeedExternalAddressSlave  types.Bool   `tfsdk:"need_external_address_slave"`
-	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e"`
-	ExtBACKUPNUMTORETAIN      types.Int64  `tfsdk:"ext__b_a_c_k_u_p__n_u_m__t_o__r_e_t_a_i_n"`
+	ExtBACKUPSCHEDULE         types.String `tfsdk:"ext_backup_schedule"`
+	ExtBACKUPNUMTORETAIN      types.Int64  `tfsdk:"ext_backup_num_to_retain"`
 	AppVersion                types.String `tfsdk:"app_version"`
 	JsonParameters            types.String `tfsdk:"json_parameters"`
 	EnablePgPoolerMaster      types.Bool   `tfsdk:"enable_pg_pooler_master"`
 	EnablePgPoolerSlave       types.Bool   `tfsdk:"enable_pg_pooler_slave"`
-	AllowNoSSL                types.Bool   `tfsdk:"allow_no_s_s_l"`
+	AllowNoSSL                types.Bool   `tfsdk:"allow_no_ssl"`
 	AutoScale                 types.Bool   `tfsdk:"auto_scale"`
 	AutoScalePercentage       types.Int64  `tfsdk:"auto_scale_percentage"`
 	AutoScaleTechWindow       types.Int64  `tfsdk:"auto_scale_tech_window"`
 	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
 	IpSpaceNameSlave          types.String `tfsdk:"ip_space_name_slave"`
 	AutoScaleQuotaGb          types.String `tfsdk:"auto_scale_quota_gb"`
+	PendingOperation          types.String `tfsdk:"pending_operation"`
 	DeleteMode                types.String `tfsdk:"delete_mode"`
 	ResumeIfExists            types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -67,16 +71,27 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"s3_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.StringAttribute{
 			Required: true,
@@ -94,12 +109,15 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 			Computed: true,
 			Default:  booldefault.StaticBool(false),
 		},
-		"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e": schema.StringAttribute{
+		"ext_backup_schedule": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
 			Default:  stringdefault.StaticString("0 0 * * *"),
+			Validators: []validator.String{
+				resources_core.CronValidator(),
+			},
 		},
-		"ext__b_a_c_k_u_p__n_u_m__t_o__r_e_t_a_i_n": schema.Int64Attribute{
+		"ext_backup_num_to_retain": schema.Int64Attribute{
 			Optional: true,
 			Computed: true,
 			Default:  int64default.StaticInt64(7),
@@ -116,7 +134,7 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 		"enable_pg_pooler_slave": schema.BoolAttribute{
 			Required: true,
 		},
-		"allow_no_s_s_l": schema.BoolAttribute{
+		"allow_no_ssl": schema.BoolAttribute{
 			Required: true,
 		},
 		"auto_scale": schema.BoolAttribute{
@@ -124,6 +142,9 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 		},
 		"auto_scale_percentage": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.Between(1, 100),
+			},
 		},
 		"auto_scale_tech_window": schema.Int64Attribute{
 			Required: true,
@@ -137,6 +158,10 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 		"auto_scale_quota_gb": schema.StringAttribute{
 			Required: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -149,7 +174,25 @@ func (r *PostgresResource) Schema(ctx context.Context, req resource.SchemaReques
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *PostgresResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"allow_no_s_s_l": "allow_no_ssl",
+					"ext__b_a_c_k_u_p__n_u_m__t_o__r_e_t_a_i_n": "ext_backup_num_to_retain",
+					"ext__b_a_c_k_u_p__s_c_h_e_d_u_l_e":         "ext_backup_schedule",
+					"resource_c_p_u":                            "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *PostgresResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -166,6 +209,13 @@ func (r *PostgresResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "s3_uid", ServiceID: 12, Value: config.S3Uid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *PostgresModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -218,6 +268,9 @@ func (r *PostgresResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{23: 12})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 90, resourceName)
@@ -264,10 +317,22 @@ func (r *PostgresResource) Create(ctx context.Context, req resource.CreateReques
 	id, err := resources_core.CreateResource(ctx, r.client, 90, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -293,6 +358,10 @@ func (r *PostgresResource) Read(ctx context.Context, req resource.ReadRequest, r
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -315,6 +384,8 @@ func (r *PostgresResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{23: 12})
+
 	params := map[int]string{
 		91:  resources_core.FormatInt64(plan.ResourceInstances),
 		92:  resources_core.FormatInt64(plan.ResourceMemory),
@@ -333,12 +404,18 @@ func (r *PostgresResource) Update(ctx context.Context, req resource.UpdateReques
 		542: resources_core.FormatString(plan.IpSpaceNameSlave),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
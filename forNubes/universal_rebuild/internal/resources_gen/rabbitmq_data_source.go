@@ -0,0 +1,152 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: rabbitmq
+// Service ID: 93
+
+var _ datasource.DataSource = &RabbitmqDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &RabbitmqDataSource{}
+
+type RabbitmqDataSource struct {
+	client *core.UniversalClient
+}
+
+type RabbitmqDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ResourceName              types.String `tfsdk:"resource_name"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
+	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
+	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
+	ResourceRealm             types.String `tfsdk:"resource_realm"`
+	ResourceInstances         types.Int64  `tfsdk:"resource_instances"`
+	NeedExternalAddressMaster types.Bool   `tfsdk:"need_external_address_master"`
+	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
+	NeedExternalAddressSlave  types.Bool   `tfsdk:"need_external_address_slave"`
+	IpSpaceNameSlave          types.String `tfsdk:"ip_space_name_slave"`
+}
+
+// rabbitmqDataParams map attributes to the params they show.
+var rabbitmqDataParams = []resources_core.DataParam{
+	{Attribute: "resource_cpu", ID: 116},
+	{Attribute: "resource_memory", ID: 117},
+	{Attribute: "resource_disk", ID: 118},
+	{Attribute: "resource_realm", ID: 119},
+	{Attribute: "resource_instances", ID: 224},
+	{Attribute: "need_external_address_master", ID: 225},
+	{Attribute: "ip_space_name_master", ID: 543},
+	{Attribute: "need_external_address_slave", ID: 309},
+	{Attribute: "ip_space_name_slave", ID: 546},
+}
+
+func NewRabbitmqDataSource() datasource.DataSource {
+	return &RabbitmqDataSource{}
+}
+
+func (d *RabbitmqDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rabbitmq"
+}
+
+func (d *RabbitmqDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `rabbitmq` (service ID 93) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_memory": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_instances": schema.Int64Attribute{
+				Computed: true,
+			},
+			"need_external_address_master": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ip_space_name_master": schema.StringAttribute{
+				Computed: true,
+			},
+			"need_external_address_slave": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ip_space_name_slave": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *RabbitmqDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *RabbitmqDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RabbitmqDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 93, data.ID, data.ResourceName, rabbitmqDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ResourceCPU = resources_core.OutputInt64(values, "resource_cpu", types.Int64Null())
+	data.ResourceMemory = resources_core.OutputInt64(values, "resource_memory", types.Int64Null())
+	data.ResourceDisk = resources_core.OutputInt64(values, "resource_disk", types.Int64Null())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.ResourceInstances = resources_core.OutputInt64(values, "resource_instances", types.Int64Null())
+	data.NeedExternalAddressMaster = resources_core.OutputBool(values, "need_external_address_master", types.BoolNull())
+	data.IpSpaceNameMaster = resources_core.OutputString(values, "ip_space_name_master", types.StringNull())
+	data.NeedExternalAddressSlave = resources_core.OutputBool(values, "need_external_address_slave", types.BoolNull())
+	data.IpSpaceNameSlave = resources_core.OutputString(values, "ip_space_name_slave", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *RabbitmqDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -7,10 +7,12 @@ import (
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +22,7 @@ import (
 
 var _ resource.Resource = &RabbitmqResource{}
 var _ resource.ResourceWithModifyPlan = &RabbitmqResource{}
+var _ resource.ResourceWithUpgradeState = &RabbitmqResource{}
 
 type RabbitmqResource struct {
 	client *core.UniversalClient
@@ -28,7 +31,7 @@ type RabbitmqResource struct {
 type RabbitmqModel struct {
 	ID                        types.String `tfsdk:"id"`
 	ResourceName              types.String `tfsdk:"resource_name"`
-	ResourceCPU               types.Int64  `tfsdk:"resource_c_p_u"`
+	ResourceCPU               types.Int64  `tfsdk:"resource_cpu"`
 	ResourceMemory            types.Int64  `tfsdk:"resource_memory"`
 	ResourceDisk              types.Int64  `tfsdk:"resource_disk"`
 	ResourceRealm             types.String `tfsdk:"resource_realm"`
@@ -37,6 +40,7 @@ type RabbitmqModel struct {
 	IpSpaceNameMaster         types.String `tfsdk:"ip_space_name_master"`
 	NeedExternalAddressSlave  types.Bool   `tfsdk:"need_external_address_slave"`
 	IpSpaceNameSlave          types.String `tfsdk:"ip_space_name_slave"`
+	PendingOperation          types.String `tfsdk:"pending_operation"`
 	DeleteMode                types.String `tfsdk:"delete_mode"`
 	ResumeIfExists            types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -53,11 +57,17 @@ func (r *RabbitmqResource) Schema(ctx context.Context, req resource.SchemaReques
 	attrs := map[string]schema.Attribute{
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
-		"resource_c_p_u": schema.Int64Attribute{
+		"resource_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_memory": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"resource_disk": schema.Int64Attribute{
 			Required: true,
@@ -67,6 +77,9 @@ func (r *RabbitmqResource) Schema(ctx context.Context, req resource.SchemaReques
 		},
 		"resource_instances": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"need_external_address_master": schema.BoolAttribute{
 			Required: true,
@@ -82,6 +95,10 @@ func (r *RabbitmqResource) Schema(ctx context.Context, req resource.SchemaReques
 		"ip_space_name_slave": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -94,7 +111,22 @@ func (r *RabbitmqResource) Schema(ctx context.Context, req resource.SchemaReques
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *RabbitmqResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"resource_c_p_u": "resource_cpu",
+				},
+			},
+		),
+	}
 }
 
 func (r *RabbitmqResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -163,6 +195,8 @@ func (r *RabbitmqResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 93, resourceName)
@@ -195,10 +229,22 @@ func (r *RabbitmqResource) Create(ctx context.Context, req resource.CreateReques
 	id, err := resources_core.CreateResource(ctx, r.client, 93, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -224,6 +270,10 @@ func (r *RabbitmqResource) Read(ctx context.Context, req resource.ReadRequest, r
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -257,12 +307,18 @@ func (r *RabbitmqResource) Update(ctx context.Context, req resource.UpdateReques
 		546: resources_core.FormatString(plan.IpSpaceNameSlave),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
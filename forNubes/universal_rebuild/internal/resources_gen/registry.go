@@ -1,6 +1,9 @@
 package resources_gen
 
-import "github.com/hashicorp/terraform-plugin-framework/resource"
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
 
 // Code generated by tools/gen. DO NOT EDIT.
 func AllResources() []func() resource.Resource {
@@ -29,5 +32,41 @@ func AllResources() []func() resource.Resource {
 		NewVcVdcResource,
 		NewVcVmV3Resource,
 		NewVcexternalipResource,
+		NewVmStackResource,
 	}
 }
+
+func AllDataSources() []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewGiteaComplexDataSource,
+		NewDummyDataSource,
+		NewFlaskDataSource,
+		NewGiteaDataSource,
+		NewHarborDataSource,
+		NewKafkaDataSource,
+		NewLuceeDataSource,
+		NewMariadbDataSource,
+		NewMongodbDataSource,
+		NewNifiDataSource,
+		NewNodejsDataSource,
+		NewNoderedDataSource,
+		NewPgadminDataSource,
+		NewPostgresDataSource,
+		NewRabbitmqDataSource,
+		NewRedisDataSource,
+		NewS3DataSource,
+		NewS3bucketDataSource,
+		NewSupersetDataSource,
+		NewVappDataSource,
+		NewVcNsxtDataSource,
+		NewVcVdcDataSource,
+		NewVcVmV3DataSource,
+		NewVcexternalipDataSource,
+	}
+}
+
+// ServicesWithHooks lists the resources that have a hooks file; the runtime
+// resources (build tag runtime_resources) leave them to the generated code.
+func ServicesWithHooks() []string {
+	return nil
+}
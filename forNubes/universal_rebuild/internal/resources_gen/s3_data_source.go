@@ -0,0 +1,128 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: s3
+// Service ID: 12
+
+var _ datasource.DataSource = &S3DataSource{}
+var _ datasource.DataSourceWithConfigValidators = &S3DataSource{}
+
+type S3DataSource struct {
+	client *core.UniversalClient
+}
+
+type S3DataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ResourceName        types.String `tfsdk:"resource_name"`
+	ResourceRealm       types.String `tfsdk:"resource_realm"`
+	DisplayName         types.String `tfsdk:"display_name"`
+	MaxSizeGbPerUser    types.Int64  `tfsdk:"max_size_gb_per_user"`
+	MaxObjectsPerBucket types.Int64  `tfsdk:"max_objects_per_bucket"`
+	MaxBucketsPerUser   types.Int64  `tfsdk:"max_buckets_per_user"`
+}
+
+// s3DataParams map attributes to the params they show.
+var s3DataParams = []resources_core.DataParam{
+	{Attribute: "resource_realm", ID: 163},
+	{Attribute: "display_name", ID: 320},
+	{Attribute: "max_size_gb_per_user", ID: 50},
+	{Attribute: "max_objects_per_bucket", ID: 51},
+	{Attribute: "max_buckets_per_user", ID: 52},
+}
+
+func NewS3DataSource() datasource.DataSource {
+	return &S3DataSource{}
+}
+
+func (d *S3DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3"
+}
+
+func (d *S3DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `s3` (service ID 12) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"display_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"max_size_gb_per_user": schema.Int64Attribute{
+				Computed: true,
+			},
+			"max_objects_per_bucket": schema.Int64Attribute{
+				Computed: true,
+			},
+			"max_buckets_per_user": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *S3DataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *S3DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data S3DataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 12, data.ID, data.ResourceName, s3DataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.DisplayName = resources_core.OutputString(values, "display_name", types.StringNull())
+	data.MaxSizeGbPerUser = resources_core.OutputInt64(values, "max_size_gb_per_user", types.Int64Null())
+	data.MaxObjectsPerBucket = resources_core.OutputInt64(values, "max_objects_per_bucket", types.Int64Null())
+	data.MaxBucketsPerUser = resources_core.OutputInt64(values, "max_buckets_per_user", types.Int64Null())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *S3DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -20,6 +20,7 @@ import (
 
 var _ resource.Resource = &S3Resource{}
 var _ resource.ResourceWithModifyPlan = &S3Resource{}
+var _ resource.ResourceWithUpgradeState = &S3Resource{}
 
 type S3Resource struct {
 	client *core.UniversalClient
@@ -33,6 +34,7 @@ type S3Model struct {
 	MaxSizeGbPerUser    types.Int64  `tfsdk:"max_size_gb_per_user"`
 	MaxObjectsPerBucket types.Int64  `tfsdk:"max_objects_per_bucket"`
 	MaxBucketsPerUser   types.Int64  `tfsdk:"max_buckets_per_user"`
+	PendingOperation    types.String `tfsdk:"pending_operation"`
 	DeleteMode          types.String `tfsdk:"delete_mode"`
 	ResumeIfExists      types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -64,6 +66,10 @@ func (r *S3Resource) Schema(ctx context.Context, req resource.SchemaRequest, res
 		"max_buckets_per_user": schema.Int64Attribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -76,7 +82,14 @@ func (r *S3Resource) Schema(ctx context.Context, req resource.SchemaRequest, res
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *S3Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *S3Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -145,6 +158,8 @@ func (r *S3Resource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 12, resourceName)
@@ -172,10 +187,22 @@ func (r *S3Resource) Create(ctx context.Context, req resource.CreateRequest, res
 	id, err := resources_core.CreateResource(ctx, r.client, 12, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -201,6 +228,10 @@ func (r *S3Resource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -229,12 +260,18 @@ func (r *S3Resource) Update(ctx context.Context, req resource.UpdateRequest, res
 		52: resources_core.FormatInt64(plan.MaxBucketsPerUser),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -0,0 +1,142 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: s3bucket
+// Service ID: 13
+
+var _ datasource.DataSource = &S3bucketDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &S3bucketDataSource{}
+
+type S3bucketDataSource struct {
+	client *core.UniversalClient
+}
+
+type S3bucketDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	S3UserUid    types.String `tfsdk:"s3_user_uid"`
+	BucketName   types.String `tfsdk:"bucket_name"`
+	MaxSize      types.String `tfsdk:"max_size"`
+	ReadAll      types.Bool   `tfsdk:"read_all"`
+	ListAll      types.Bool   `tfsdk:"list_all"`
+	CorsAll      types.Bool   `tfsdk:"cors_all"`
+	Placement    types.String `tfsdk:"placement"`
+}
+
+// s3bucketDataParams map attributes to the params they show.
+var s3bucketDataParams = []resources_core.DataParam{
+	{Attribute: "s3_user_uid", ID: 124},
+	{Attribute: "bucket_name", ID: 125},
+	{Attribute: "max_size", ID: 126},
+	{Attribute: "read_all", ID: 127},
+	{Attribute: "list_all", ID: 128},
+	{Attribute: "cors_all", ID: 129},
+	{Attribute: "placement", ID: 130},
+}
+
+func NewS3bucketDataSource() datasource.DataSource {
+	return &S3bucketDataSource{}
+}
+
+func (d *S3bucketDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3bucket"
+}
+
+func (d *S3bucketDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `s3bucket` (service ID 13) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"s3_user_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
+			},
+			"bucket_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"max_size": schema.StringAttribute{
+				Computed: true,
+			},
+			"read_all": schema.BoolAttribute{
+				Computed: true,
+			},
+			"list_all": schema.BoolAttribute{
+				Computed: true,
+			},
+			"cors_all": schema.BoolAttribute{
+				Computed: true,
+			},
+			"placement": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *S3bucketDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *S3bucketDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data S3bucketDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 13, data.ID, data.ResourceName, s3bucketDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.S3UserUid = resources_core.OutputString(values, "s3_user_uid", types.StringNull())
+	data.BucketName = resources_core.OutputString(values, "bucket_name", types.StringNull())
+	data.MaxSize = resources_core.OutputString(values, "max_size", types.StringNull())
+	data.ReadAll = resources_core.OutputBool(values, "read_all", types.BoolNull())
+	data.ListAll = resources_core.OutputBool(values, "list_all", types.BoolNull())
+	data.CorsAll = resources_core.OutputBool(values, "cors_all", types.BoolNull())
+	data.Placement = resources_core.OutputString(values, "placement", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *S3bucketDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
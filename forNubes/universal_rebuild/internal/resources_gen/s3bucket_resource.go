@@ -20,23 +20,25 @@ import (
 
 var _ resource.Resource = &S3bucketResource{}
 var _ resource.ResourceWithModifyPlan = &S3bucketResource{}
+var _ resource.ResourceWithUpgradeState = &S3bucketResource{}
 
 type S3bucketResource struct {
 	client *core.UniversalClient
 }
 
 type S3bucketModel struct {
-	ID             types.String `tfsdk:"id"`
-	ResourceName   types.String `tfsdk:"resource_name"`
-	S3UserUid      types.String `tfsdk:"s3_user_uid"`
-	BucketName     types.String `tfsdk:"bucket_name"`
-	MaxSize        types.String `tfsdk:"max_size"`
-	ReadAll        types.Bool   `tfsdk:"read_all"`
-	ListAll        types.Bool   `tfsdk:"list_all"`
-	CorsAll        types.Bool   `tfsdk:"cors_all"`
-	Placement      types.String `tfsdk:"placement"`
-	DeleteMode     types.String `tfsdk:"delete_mode"`
-	ResumeIfExists types.Bool   `tfsdk:"resume_if_exists"`
+	ID               types.String `tfsdk:"id"`
+	ResourceName     types.String `tfsdk:"resource_name"`
+	S3UserUid        types.String `tfsdk:"s3_user_uid"`
+	BucketName       types.String `tfsdk:"bucket_name"`
+	MaxSize          types.String `tfsdk:"max_size"`
+	ReadAll          types.Bool   `tfsdk:"read_all"`
+	ListAll          types.Bool   `tfsdk:"list_all"`
+	CorsAll          types.Bool   `tfsdk:"cors_all"`
+	Placement        types.String `tfsdk:"placement"`
+	PendingOperation types.String `tfsdk:"pending_operation"`
+	DeleteMode       types.String `tfsdk:"delete_mode"`
+	ResumeIfExists   types.Bool   `tfsdk:"resume_if_exists"`
 }
 
 func NewS3bucketResource() resource.Resource {
@@ -52,7 +54,9 @@ func (r *S3bucketResource) Schema(ctx context.Context, req resource.SchemaReques
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"s3_user_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_s3 instance (service 12): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_s3` instance (service 12): its id (UUID) or resource_name.",
 		},
 		"bucket_name": schema.StringAttribute{
 			Required: true,
@@ -72,6 +76,10 @@ func (r *S3bucketResource) Schema(ctx context.Context, req resource.SchemaReques
 		"placement": schema.StringAttribute{
 			Required: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -84,7 +92,14 @@ func (r *S3bucketResource) Schema(ctx context.Context, req resource.SchemaReques
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *S3bucketResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *S3bucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -101,6 +116,13 @@ func (r *S3bucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "s3_user_uid", ServiceID: 12, Value: config.S3UserUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *S3bucketModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -153,6 +175,9 @@ func (r *S3bucketResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{124: 12})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 13, resourceName)
@@ -185,10 +210,22 @@ func (r *S3bucketResource) Create(ctx context.Context, req resource.CreateReques
 	id, err := resources_core.CreateResource(ctx, r.client, 13, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -214,6 +251,10 @@ func (r *S3bucketResource) Read(ctx context.Context, req resource.ReadRequest, r
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -236,14 +277,22 @@ func (r *S3bucketResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{124: 12})
+
 	params := map[int]string{}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -0,0 +1,120 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: vapp
+// Service ID: 26
+
+var _ datasource.DataSource = &VappDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &VappDataSource{}
+
+type VappDataSource struct {
+	client *core.UniversalClient
+}
+
+type VappDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	NsxtUid      types.String `tfsdk:"nsxt_uid"`
+	VappName     types.String `tfsdk:"vapp_name"`
+	VdcUid       types.String `tfsdk:"vdc_uid"`
+}
+
+// vappDataParams map attributes to the params they show.
+var vappDataParams = []resources_core.DataParam{
+	{Attribute: "nsxt_uid", ID: 190},
+	{Attribute: "vapp_name", ID: 191},
+	{Attribute: "vdc_uid", ID: 623},
+}
+
+func NewVappDataSource() datasource.DataSource {
+	return &VappDataSource{}
+}
+
+func (d *VappDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vapp"
+}
+
+func (d *VappDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `vapp` (service ID 26) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"nsxt_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_vc_nsxt instance (service 22): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_vc_nsxt` instance (service 22): its id (UUID) or resource_name.",
+			},
+			"vapp_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"vdc_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_vc_vdc instance (service 21): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_vc_vdc` instance (service 21): its id (UUID) or resource_name.",
+			},
+		},
+	}
+}
+
+func (d *VappDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *VappDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VappDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 26, data.ID, data.ResourceName, vappDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.NsxtUid = resources_core.OutputString(values, "nsxt_uid", types.StringNull())
+	data.VappName = resources_core.OutputString(values, "vapp_name", types.StringNull())
+	data.VdcUid = resources_core.OutputString(values, "vdc_uid", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *VappDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
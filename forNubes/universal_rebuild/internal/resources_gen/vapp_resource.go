@@ -20,19 +20,21 @@ import (
 
 var _ resource.Resource = &VappResource{}
 var _ resource.ResourceWithModifyPlan = &VappResource{}
+var _ resource.ResourceWithUpgradeState = &VappResource{}
 
 type VappResource struct {
 	client *core.UniversalClient
 }
 
 type VappModel struct {
-	ID             types.String `tfsdk:"id"`
-	ResourceName   types.String `tfsdk:"resource_name"`
-	NsxtUid        types.String `tfsdk:"nsxt_uid"`
-	VappName       types.String `tfsdk:"vapp_name"`
-	VdcUid         types.String `tfsdk:"vdc_uid"`
-	DeleteMode     types.String `tfsdk:"delete_mode"`
-	ResumeIfExists types.Bool   `tfsdk:"resume_if_exists"`
+	ID               types.String `tfsdk:"id"`
+	ResourceName     types.String `tfsdk:"resource_name"`
+	NsxtUid          types.String `tfsdk:"nsxt_uid"`
+	VappName         types.String `tfsdk:"vapp_name"`
+	VdcUid           types.String `tfsdk:"vdc_uid"`
+	PendingOperation types.String `tfsdk:"pending_operation"`
+	DeleteMode       types.String `tfsdk:"delete_mode"`
+	ResumeIfExists   types.Bool   `tfsdk:"resume_if_exists"`
 }
 
 func NewVappResource() resource.Resource {
@@ -48,13 +50,21 @@ func (r *VappResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"nsxt_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_vc_nsxt instance (service 22): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_vc_nsxt` instance (service 22): its id (UUID) or resource_name.",
 		},
 		"vapp_name": schema.StringAttribute{
 			Required: true,
 		},
 		"vdc_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_vc_vdc instance (service 21): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_vc_vdc` instance (service 21): its id (UUID) or resource_name.",
+		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
 		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
@@ -68,7 +78,14 @@ func (r *VappResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *VappResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *VappResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -85,6 +102,14 @@ func (r *VappResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "nsxt_uid", ServiceID: 22, Value: config.NsxtUid},
+		resources_core.Reference{Attribute: "vdc_uid", ServiceID: 21, Value: config.VdcUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *VappModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -137,6 +162,9 @@ func (r *VappResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{190: 22, 623: 21})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 26, resourceName)
@@ -165,10 +193,22 @@ func (r *VappResource) Create(ctx context.Context, req resource.CreateRequest, r
 	id, err := resources_core.CreateResource(ctx, r.client, 26, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -194,6 +234,10 @@ func (r *VappResource) Read(ctx context.Context, req resource.ReadRequest, resp
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -216,14 +260,22 @@ func (r *VappResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{190: 22, 623: 21})
+
 	params := map[int]string{}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
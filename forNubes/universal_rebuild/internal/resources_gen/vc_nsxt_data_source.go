@@ -0,0 +1,148 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: vc_nsxt
+// Service ID: 22
+
+var _ datasource.DataSource = &VcNsxtDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &VcNsxtDataSource{}
+
+type VcNsxtDataSource struct {
+	client *core.UniversalClient
+}
+
+type VcNsxtDataSourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	ResourceName            types.String `tfsdk:"resource_name"`
+	VdcUid                  types.String `tfsdk:"vdc_uid"`
+	NeedEnableAVI           types.Bool   `tfsdk:"need_enable_avi"`
+	VirtualServicesCount    types.Int64  `tfsdk:"virtual_services_count"`
+	SegroupName             types.String `tfsdk:"segroup_name"`
+	VdcType                 types.String `tfsdk:"vdc_type"`
+	VdcGroupUid             types.String `tfsdk:"vdc_group_uid"`
+	NeedExternalAddressSNAT types.Bool   `tfsdk:"need_external_address_snat"`
+	IpSpaceName             types.String `tfsdk:"ip_space_name"`
+}
+
+// vcNsxtDataParams map attributes to the params they show.
+var vcNsxtDataParams = []resources_core.DataParam{
+	{Attribute: "vdc_uid", ID: 8},
+	{Attribute: "need_enable_avi", ID: 340},
+	{Attribute: "virtual_services_count", ID: 341},
+	{Attribute: "segroup_name", ID: 367},
+	{Attribute: "vdc_type", ID: 621},
+	{Attribute: "vdc_group_uid", ID: 622},
+	{Attribute: "need_external_address_snat", ID: 371},
+	{Attribute: "ip_space_name", ID: 372},
+}
+
+func NewVcNsxtDataSource() datasource.DataSource {
+	return &VcNsxtDataSource{}
+}
+
+func (d *VcNsxtDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vc_nsxt"
+}
+
+func (d *VcNsxtDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `vc_nsxt` (service ID 22) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"vdc_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_vc_vdc instance (service 21): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_vc_vdc` instance (service 21): its id (UUID) or resource_name.",
+			},
+			"need_enable_avi": schema.BoolAttribute{
+				Computed: true,
+			},
+			"virtual_services_count": schema.Int64Attribute{
+				Computed: true,
+			},
+			"segroup_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"vdc_type": schema.StringAttribute{
+				Computed: true,
+			},
+			"vdc_group_uid": schema.StringAttribute{
+				Computed: true,
+			},
+			"need_external_address_snat": schema.BoolAttribute{
+				Computed: true,
+			},
+			"ip_space_name": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *VcNsxtDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *VcNsxtDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VcNsxtDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 22, data.ID, data.ResourceName, vcNsxtDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.VdcUid = resources_core.OutputString(values, "vdc_uid", types.StringNull())
+	data.NeedEnableAVI = resources_core.OutputBool(values, "need_enable_avi", types.BoolNull())
+	data.VirtualServicesCount = resources_core.OutputInt64(values, "virtual_services_count", types.Int64Null())
+	data.SegroupName = resources_core.OutputString(values, "segroup_name", types.StringNull())
+	data.VdcType = resources_core.OutputString(values, "vdc_type", types.StringNull())
+	data.VdcGroupUid = resources_core.OutputString(values, "vdc_group_uid", types.StringNull())
+	data.NeedExternalAddressSNAT = resources_core.OutputBool(values, "need_external_address_snat", types.BoolNull())
+	data.IpSpaceName = resources_core.OutputString(values, "ip_space_name", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *VcNsxtDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -21,6 +21,7 @@ import (
 
 var _ resource.Resource = &VcNsxtResource{}
 var _ resource.ResourceWithModifyPlan = &VcNsxtResource{}
+var _ resource.ResourceWithUpgradeState = &VcNsxtResource{}
 
 type VcNsxtResource struct {
 	client *core.UniversalClient
@@ -30,13 +31,14 @@ type VcNsxtModel struct {
 	ID                      types.String `tfsdk:"id"`
 	ResourceName            types.String `tfsdk:"resource_name"`
 	VdcUid                  types.String `tfsdk:"vdc_uid"`
-	NeedEnableAVI           types.Bool   `tfsdk:"need_enable_a_v_i"`
+	NeedEnableAVI           types.Bool   `tfsdk:"need_enable_avi"`
 	VirtualServicesCount    types.Int64  `tfsdk:"virtual_services_count"`
 	SegroupName             types.String `tfsdk:"segroup_name"`
 	VdcType                 types.String `tfsdk:"vdc_type"`
 	VdcGroupUid             types.String `tfsdk:"vdc_group_uid"`
-	NeedExternalAddressSNAT types.Bool   `tfsdk:"need_external_address_s_n_a_t"`
+	NeedExternalAddressSNAT types.Bool   `tfsdk:"need_external_address_snat"`
 	IpSpaceName             types.String `tfsdk:"ip_space_name"`
+	PendingOperation        types.String `tfsdk:"pending_operation"`
 	DeleteMode              types.String `tfsdk:"delete_mode"`
 	ResumeIfExists          types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -54,9 +56,11 @@ func (r *VcNsxtResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"vdc_uid": schema.StringAttribute{
-			Optional: true,
+			Optional:            true,
+			Description:         "Reference to a nubes_vc_vdc instance (service 21): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_vc_vdc` instance (service 21): its id (UUID) or resource_name.",
 		},
-		"need_enable_a_v_i": schema.BoolAttribute{
+		"need_enable_avi": schema.BoolAttribute{
 			Required: true,
 		},
 		"virtual_services_count": schema.Int64Attribute{
@@ -73,12 +77,16 @@ func (r *VcNsxtResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"vdc_group_uid": schema.StringAttribute{
 			Optional: true,
 		},
-		"need_external_address_s_n_a_t": schema.BoolAttribute{
+		"need_external_address_snat": schema.BoolAttribute{
 			Optional: true,
 		},
 		"ip_space_name": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -91,7 +99,23 @@ func (r *VcNsxtResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    1,
+		Attributes: attrs,
+	}
+}
+
+func (r *VcNsxtResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: resources_core.MigrateState(
+			resources_core.StateMigration{
+				Rename: map[string]string{
+					"need_enable_a_v_i":             "need_enable_avi",
+					"need_external_address_s_n_a_t": "need_external_address_snat",
+				},
+			},
+		),
+	}
 }
 
 func (r *VcNsxtResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -108,6 +132,13 @@ func (r *VcNsxtResource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "vdc_uid", ServiceID: 21, Value: config.VdcUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *VcNsxtModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -160,6 +191,9 @@ func (r *VcNsxtResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithParamRefs(ctx, map[int]int{8: 21})
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 22, resourceName)
@@ -191,10 +225,22 @@ func (r *VcNsxtResource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 22, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -220,6 +266,10 @@ func (r *VcNsxtResource) Read(ctx context.Context, req resource.ReadRequest, res
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -242,6 +292,8 @@ func (r *VcNsxtResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = core.WithParamRefs(ctx, map[int]int{8: 21})
+
 	params := map[int]string{
 		368: resources_core.FormatBool(plan.NeedEnableAVI),
 		369: resources_core.FormatInt64(plan.VirtualServicesCount),
@@ -250,12 +302,18 @@ func (r *VcNsxtResource) Update(ctx context.Context, req resource.UpdateRequest,
 		372: resources_core.FormatString(plan.IpSpaceName),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
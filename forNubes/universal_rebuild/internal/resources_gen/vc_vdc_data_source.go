@@ -0,0 +1,146 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: vc_vdc
+// Service ID: 21
+
+var _ datasource.DataSource = &VcVdcDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &VcVdcDataSource{}
+
+type VcVdcDataSource struct {
+	client *core.UniversalClient
+}
+
+type VcVdcDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ResourceName       types.String `tfsdk:"resource_name"`
+	OrganizationUid    types.String `tfsdk:"organization_uid"`
+	VdcProviderGateway types.String `tfsdk:"vdc_provider_gateway"`
+	StorageConfig      types.String `tfsdk:"storage_config"`
+	VdcNetworkPool     types.String `tfsdk:"vdc_network_pool"`
+	CpuGuaranteed      types.Int64  `tfsdk:"cpu_guaranteed"`
+	MemGuaranteed      types.Int64  `tfsdk:"mem_guaranteed"`
+	CpuAllocated       types.Int64  `tfsdk:"cpu_allocated"`
+	MemAllocated       types.Int64  `tfsdk:"mem_allocated"`
+}
+
+// vcVdcDataParams map attributes to the params they show.
+var vcVdcDataParams = []resources_core.DataParam{
+	{Attribute: "organization_uid", ID: 30},
+	{Attribute: "vdc_provider_gateway", ID: 335},
+	{Attribute: "storage_config", ID: 361},
+	{Attribute: "vdc_network_pool", ID: 366},
+	{Attribute: "cpu_guaranteed", ID: 397},
+	{Attribute: "mem_guaranteed", ID: 398},
+	{Attribute: "cpu_allocated", ID: 557},
+	{Attribute: "mem_allocated", ID: 558},
+}
+
+func NewVcVdcDataSource() datasource.DataSource {
+	return &VcVdcDataSource{}
+}
+
+func (d *VcVdcDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vc_vdc"
+}
+
+func (d *VcVdcDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `vc_vdc` (service ID 21) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"organization_uid": schema.StringAttribute{
+				Computed: true,
+			},
+			"vdc_provider_gateway": schema.StringAttribute{
+				Computed: true,
+			},
+			"storage_config": schema.StringAttribute{
+				Computed: true,
+			},
+			"vdc_network_pool": schema.StringAttribute{
+				Computed: true,
+			},
+			"cpu_guaranteed": schema.Int64Attribute{
+				Computed: true,
+			},
+			"mem_guaranteed": schema.Int64Attribute{
+				Computed: true,
+			},
+			"cpu_allocated": schema.Int64Attribute{
+				Computed: true,
+			},
+			"mem_allocated": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *VcVdcDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *VcVdcDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VcVdcDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 21, data.ID, data.ResourceName, vcVdcDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.OrganizationUid = resources_core.OutputString(values, "organization_uid", types.StringNull())
+	data.VdcProviderGateway = resources_core.OutputString(values, "vdc_provider_gateway", types.StringNull())
+	data.StorageConfig = resources_core.OutputString(values, "storage_config", types.StringNull())
+	data.VdcNetworkPool = resources_core.OutputString(values, "vdc_network_pool", types.StringNull())
+	data.CpuGuaranteed = resources_core.OutputInt64(values, "cpu_guaranteed", types.Int64Null())
+	data.MemGuaranteed = resources_core.OutputInt64(values, "mem_guaranteed", types.Int64Null())
+	data.CpuAllocated = resources_core.OutputInt64(values, "cpu_allocated", types.Int64Null())
+	data.MemAllocated = resources_core.OutputInt64(values, "mem_allocated", types.Int64Null())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *VcVdcDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
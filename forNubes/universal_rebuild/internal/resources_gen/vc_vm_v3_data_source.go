@@ -0,0 +1,180 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: vc_vm_v3
+// Service ID: 28
+
+var _ datasource.DataSource = &VcVmV3DataSource{}
+var _ datasource.DataSourceWithConfigValidators = &VcVmV3DataSource{}
+
+type VcVmV3DataSource struct {
+	client *core.UniversalClient
+}
+
+type VcVmV3DataSourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	ResourceName          types.String `tfsdk:"resource_name"`
+	VappUid               types.String `tfsdk:"vapp_uid"`
+	VmName                types.String `tfsdk:"vm_name"`
+	VmCpu                 types.Int64  `tfsdk:"vm_cpu"`
+	VmRam                 types.Int64  `tfsdk:"vm_ram"`
+	VmDisk                types.Int64  `tfsdk:"vm_disk"`
+	IpSpaceName           types.String `tfsdk:"ip_space_name"`
+	AccessIpList          types.String `tfsdk:"access_ip_list"`
+	ImageVm               types.String `tfsdk:"image_vm"`
+	CloudInit             types.String `tfsdk:"cloud_init"`
+	UserLogin             types.String `tfsdk:"user_login"`
+	UserPublicKey         types.String `tfsdk:"user_public_key"`
+	AccessPortList        types.String `tfsdk:"access_port_list"`
+	NeedAddZabbixTemplate types.Bool   `tfsdk:"need_add_zabbix_template"`
+}
+
+// vcVmV3DataParams map attributes to the params they show.
+var vcVmV3DataParams = []resources_core.DataParam{
+	{Attribute: "vapp_uid", ID: 407},
+	{Attribute: "vm_name", ID: 408},
+	{Attribute: "vm_cpu", ID: 409},
+	{Attribute: "vm_ram", ID: 410},
+	{Attribute: "vm_disk", ID: 411},
+	{Attribute: "ip_space_name", ID: 412},
+	{Attribute: "access_ip_list", ID: 413},
+	{Attribute: "image_vm", ID: 414},
+	{Attribute: "cloud_init", ID: 415},
+	{Attribute: "user_login", ID: 416},
+	{Attribute: "user_public_key", ID: 417},
+	{Attribute: "access_port_list", ID: 448},
+	{Attribute: "need_add_zabbix_template", ID: 449},
+}
+
+func NewVcVmV3DataSource() datasource.DataSource {
+	return &VcVmV3DataSource{}
+}
+
+func (d *VcVmV3DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vc_vm_v3"
+}
+
+func (d *VcVmV3DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `vc_vm_v3` (service ID 28) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"vapp_uid": schema.StringAttribute{
+				Computed:            true,
+				Description:         "Reference to a nubes_vapp instance (service 26): its id (UUID) or resource_name.",
+				MarkdownDescription: "Reference to a `nubes_vapp` instance (service 26): its id (UUID) or resource_name.",
+			},
+			"vm_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"vm_cpu": schema.Int64Attribute{
+				Computed: true,
+			},
+			"vm_ram": schema.Int64Attribute{
+				Computed: true,
+			},
+			"vm_disk": schema.Int64Attribute{
+				Computed: true,
+			},
+			"ip_space_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"access_ip_list": schema.StringAttribute{
+				Computed: true,
+			},
+			"image_vm": schema.StringAttribute{
+				Computed: true,
+			},
+			"cloud_init": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"user_login": schema.StringAttribute{
+				Computed: true,
+			},
+			"user_public_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+			"access_port_list": schema.StringAttribute{
+				Computed: true,
+			},
+			"need_add_zabbix_template": schema.BoolAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *VcVmV3DataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *VcVmV3DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VcVmV3DataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 28, data.ID, data.ResourceName, vcVmV3DataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.VappUid = resources_core.OutputString(values, "vapp_uid", types.StringNull())
+	data.VmName = resources_core.OutputString(values, "vm_name", types.StringNull())
+	data.VmCpu = resources_core.OutputInt64(values, "vm_cpu", types.Int64Null())
+	data.VmRam = resources_core.OutputInt64(values, "vm_ram", types.Int64Null())
+	data.VmDisk = resources_core.OutputInt64(values, "vm_disk", types.Int64Null())
+	data.IpSpaceName = resources_core.OutputString(values, "ip_space_name", types.StringNull())
+	data.AccessIpList = resources_core.OutputString(values, "access_ip_list", types.StringNull())
+	data.ImageVm = resources_core.OutputString(values, "image_vm", types.StringNull())
+	data.CloudInit = resources_core.OutputString(values, "cloud_init", types.StringNull())
+	data.UserLogin = resources_core.OutputString(values, "user_login", types.StringNull())
+	data.UserPublicKey = resources_core.OutputString(values, "user_public_key", types.StringNull())
+	data.AccessPortList = resources_core.OutputString(values, "access_port_list", types.StringNull())
+	data.NeedAddZabbixTemplate = resources_core.OutputBool(values, "need_add_zabbix_template", types.BoolNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *VcVmV3DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -3,14 +3,17 @@ package resources_gen
 import (
 	"context"
 	"strings"
+	"time"
 
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -20,6 +23,7 @@ import (
 
 var _ resource.Resource = &VcVmV3Resource{}
 var _ resource.ResourceWithModifyPlan = &VcVmV3Resource{}
+var _ resource.ResourceWithUpgradeState = &VcVmV3Resource{}
 
 type VcVmV3Resource struct {
 	client *core.UniversalClient
@@ -41,10 +45,19 @@ type VcVmV3Model struct {
 	UserPublicKey         types.String `tfsdk:"user_public_key"`
 	AccessPortList        types.String `tfsdk:"access_port_list"`
 	NeedAddZabbixTemplate types.Bool   `tfsdk:"need_add_zabbix_template"`
+	PendingOperation      types.String `tfsdk:"pending_operation"`
 	DeleteMode            types.String `tfsdk:"delete_mode"`
 	ResumeIfExists        types.Bool   `tfsdk:"resume_if_exists"`
 }
 
+// vcVmV3PollPolicy overrides operation polling for this service.
+var vcVmV3PollPolicy = core.PollPolicy{
+	Initial:    10 * time.Second,
+	Max:        time.Minute,
+	Multiplier: 2,
+	Timeout:    time.Hour,
+}
+
 func NewVcVmV3Resource() resource.Resource {
 	return &VcVmV3Resource{}
 }
@@ -58,16 +71,24 @@ func (r *VcVmV3Resource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"id":            schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 		"vapp_uid": schema.StringAttribute{
-			Required: true,
+			Required:            true,
+			Description:         "Reference to a nubes_vapp instance (service 26): its id (UUID) or resource_name.",
+			MarkdownDescription: "Reference to a `nubes_vapp` instance (service 26): its id (UUID) or resource_name.",
 		},
 		"vm_name": schema.StringAttribute{
 			Required: true,
 		},
 		"vm_cpu": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"vm_ram": schema.Int64Attribute{
 			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
 		},
 		"vm_disk": schema.Int64Attribute{
 			Optional: true,
@@ -84,13 +105,15 @@ func (r *VcVmV3Resource) Schema(ctx context.Context, req resource.SchemaRequest,
 			Required: true,
 		},
 		"cloud_init": schema.StringAttribute{
-			Optional: true,
+			Optional:  true,
+			Sensitive: true,
 		},
 		"user_login": schema.StringAttribute{
 			Required: true,
 		},
 		"user_public_key": schema.StringAttribute{
-			Required: true,
+			Required:  true,
+			Sensitive: true,
 		},
 		"access_port_list": schema.StringAttribute{
 			Required: true,
@@ -98,6 +121,10 @@ func (r *VcVmV3Resource) Schema(ctx context.Context, req resource.SchemaRequest,
 		"need_add_zabbix_template": schema.BoolAttribute{
 			Required: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -110,7 +137,14 @@ func (r *VcVmV3Resource) Schema(ctx context.Context, req resource.SchemaRequest,
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *VcVmV3Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *VcVmV3Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -127,6 +161,13 @@ func (r *VcVmV3Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 		return
 	}
 
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+		resources_core.Reference{Attribute: "vapp_uid", ServiceID: 26, Value: config.VappUid},
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state *VcVmV3Model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -179,6 +220,11 @@ func (r *VcVmV3Resource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = core.WithSensitiveParams(ctx, 415, 417)
+	ctx = core.WithParamRefs(ctx, map[int]int{407: 26})
+	ctx = core.WithPollPolicy(ctx, vcVmV3PollPolicy)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 28, resourceName)
@@ -217,10 +263,22 @@ func (r *VcVmV3Resource) Create(ctx context.Context, req resource.CreateRequest,
 	id, err := resources_core.CreateResource(ctx, r.client, 28, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -246,6 +304,10 @@ func (r *VcVmV3Resource) Read(ctx context.Context, req resource.ReadRequest, res
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -268,6 +330,10 @@ func (r *VcVmV3Resource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = core.WithSensitiveParams(ctx, 415, 417)
+	ctx = core.WithParamRefs(ctx, map[int]int{407: 26})
+	ctx = core.WithPollPolicy(ctx, vcVmV3PollPolicy)
+
 	params := map[int]string{
 		493: resources_core.FormatInt64(plan.VmCpu),
 		494: resources_core.FormatInt64(plan.VmRam),
@@ -278,12 +344,18 @@ func (r *VcVmV3Resource) Update(ctx context.Context, req resource.UpdateRequest,
 		499: resources_core.FormatBool(plan.NeedAddZabbixTemplate),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -296,6 +368,7 @@ func (r *VcVmV3Resource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if state == nil || state.ID.IsNull() || state.ID.IsUnknown() {
 		return
 	}
+	ctx = core.WithPollPolicy(ctx, vcVmV3PollPolicy)
 
 	if err := resources_core.DeleteResource(ctx, r.client, state.ID.ValueString(), state.DeleteMode.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
@@ -0,0 +1,182 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: vcexternalip
+// Service ID: 25
+
+var _ datasource.DataSource = &VcexternalipDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &VcexternalipDataSource{}
+
+type VcexternalipDataSource struct {
+	client *core.UniversalClient
+}
+
+type VcexternalipDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ResourceName              types.String `tfsdk:"resource_name"`
+	ServiceUid                types.String `tfsdk:"service_uid"`
+	DnatCreate                types.Bool   `tfsdk:"dnat_create"`
+	InternalPortAccess        types.String `tfsdk:"internal_port_access"`
+	SnatCreate                types.Bool   `tfsdk:"snat_create"`
+	InternalAddrAccess        types.String `tfsdk:"internal_addr_access"`
+	FromServiceNamespace      types.String `tfsdk:"from_service_namespace"`
+	FromServiceCloudEdgeName  types.String `tfsdk:"from_service_cloud_edge_name"`
+	FromServiceCloudVdcName   types.String `tfsdk:"from_service_cloud_vdc_name"`
+	FromServiceCloudOrgName   types.String `tfsdk:"from_service_cloud_org_name"`
+	FromServiceCloudVmwareUrl types.String `tfsdk:"from_service_cloud_vmware_url"`
+	IpSpaceName               types.String `tfsdk:"ip_space_name"`
+	ResourceRealm             types.String `tfsdk:"resource_realm"`
+	FromServiceCloudEdgeScope types.String `tfsdk:"from_service_cloud_edge_scope"`
+	FromServiceVdcGroupName   types.String `tfsdk:"from_service_vdc_group_name"`
+}
+
+// vcexternalipDataParams map attributes to the params they show.
+var vcexternalipDataParams = []resources_core.DataParam{
+	{Attribute: "service_uid", ID: 140},
+	{Attribute: "dnat_create", ID: 141},
+	{Attribute: "internal_port_access", ID: 142},
+	{Attribute: "snat_create", ID: 143},
+	{Attribute: "internal_addr_access", ID: 144},
+	{Attribute: "from_service_namespace", ID: 324},
+	{Attribute: "from_service_cloud_edge_name", ID: 325},
+	{Attribute: "from_service_cloud_vdc_name", ID: 326},
+	{Attribute: "from_service_cloud_org_name", ID: 327},
+	{Attribute: "from_service_cloud_vmware_url", ID: 328},
+	{Attribute: "ip_space_name", ID: 336},
+	{Attribute: "resource_realm", ID: 488},
+	{Attribute: "from_service_cloud_edge_scope", ID: 624},
+	{Attribute: "from_service_vdc_group_name", ID: 626},
+}
+
+func NewVcexternalipDataSource() datasource.DataSource {
+	return &VcexternalipDataSource{}
+}
+
+func (d *VcexternalipDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vcexternalip"
+}
+
+func (d *VcexternalipDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service `vcexternalip` (service ID 25) by `id` or `resource_name`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of `id` and `resource_name` must be set.",
+			},
+			"service_uid": schema.StringAttribute{
+				Computed: true,
+			},
+			"dnat_create": schema.BoolAttribute{
+				Computed: true,
+			},
+			"internal_port_access": schema.StringAttribute{
+				Computed: true,
+			},
+			"snat_create": schema.BoolAttribute{
+				Computed: true,
+			},
+			"internal_addr_access": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_namespace": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_cloud_edge_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_cloud_vdc_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_cloud_org_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_cloud_vmware_url": schema.StringAttribute{
+				Computed: true,
+			},
+			"ip_space_name": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_realm": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_cloud_edge_scope": schema.StringAttribute{
+				Computed: true,
+			},
+			"from_service_vdc_group_name": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *VcexternalipDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *VcexternalipDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data VcexternalipDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, values, diags := resources_core.ReadInstance(ctx, d.client, 25, data.ID, data.ResourceName, vcexternalipDataParams, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+	data.ServiceUid = resources_core.OutputString(values, "service_uid", types.StringNull())
+	data.DnatCreate = resources_core.OutputBool(values, "dnat_create", types.BoolNull())
+	data.InternalPortAccess = resources_core.OutputString(values, "internal_port_access", types.StringNull())
+	data.SnatCreate = resources_core.OutputBool(values, "snat_create", types.BoolNull())
+	data.InternalAddrAccess = resources_core.OutputString(values, "internal_addr_access", types.StringNull())
+	data.FromServiceNamespace = resources_core.OutputString(values, "from_service_namespace", types.StringNull())
+	data.FromServiceCloudEdgeName = resources_core.OutputString(values, "from_service_cloud_edge_name", types.StringNull())
+	data.FromServiceCloudVdcName = resources_core.OutputString(values, "from_service_cloud_vdc_name", types.StringNull())
+	data.FromServiceCloudOrgName = resources_core.OutputString(values, "from_service_cloud_org_name", types.StringNull())
+	data.FromServiceCloudVmwareUrl = resources_core.OutputString(values, "from_service_cloud_vmware_url", types.StringNull())
+	data.IpSpaceName = resources_core.OutputString(values, "ip_space_name", types.StringNull())
+	data.ResourceRealm = resources_core.OutputString(values, "resource_realm", types.StringNull())
+	data.FromServiceCloudEdgeScope = resources_core.OutputString(values, "from_service_cloud_edge_scope", types.StringNull())
+	data.FromServiceVdcGroupName = resources_core.OutputString(values, "from_service_vdc_group_name", types.StringNull())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *VcexternalipDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
@@ -20,6 +20,7 @@ import (
 
 var _ resource.Resource = &VcexternalipResource{}
 var _ resource.ResourceWithModifyPlan = &VcexternalipResource{}
+var _ resource.ResourceWithUpgradeState = &VcexternalipResource{}
 
 type VcexternalipResource struct {
 	client *core.UniversalClient
@@ -42,6 +43,7 @@ type VcexternalipModel struct {
 	ResourceRealm             types.String `tfsdk:"resource_realm"`
 	FromServiceCloudEdgeScope types.String `tfsdk:"from_service_cloud_edge_scope"`
 	FromServiceVdcGroupName   types.String `tfsdk:"from_service_vdc_group_name"`
+	PendingOperation          types.String `tfsdk:"pending_operation"`
 	DeleteMode                types.String `tfsdk:"delete_mode"`
 	ResumeIfExists            types.Bool   `tfsdk:"resume_if_exists"`
 }
@@ -100,6 +102,10 @@ func (r *VcexternalipResource) Schema(ctx context.Context, req resource.SchemaRe
 		"from_service_vdc_group_name": schema.StringAttribute{
 			Optional: true,
 		},
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -112,7 +118,14 @@ func (r *VcexternalipResource) Schema(ctx context.Context, req resource.SchemaRe
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *VcexternalipResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
 }
 
 func (r *VcexternalipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -181,6 +194,8 @@ func (r *VcexternalipResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, 25, resourceName)
@@ -220,10 +235,22 @@ func (r *VcexternalipResource) Create(ctx context.Context, req resource.CreateRe
 	id, err := resources_core.CreateResource(ctx, r.client, 25, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -249,6 +276,10 @@ func (r *VcexternalipResource) Read(ctx context.Context, req resource.ReadReques
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -280,12 +311,18 @@ func (r *VcexternalipResource) Update(ctx context.Context, req resource.UpdateRe
 		632: resources_core.FormatString(plan.InternalAddrAccess),
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
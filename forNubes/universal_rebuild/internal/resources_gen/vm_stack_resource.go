@@ -0,0 +1,474 @@
+package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Composite: vm_stack
+// Child vdc: vc_vdc (service 21)
+// Child nsxt: vc_nsxt (service 22)
+// Child vapp: vapp (service 26)
+// Child vm: vc_vm_v3 (service 28)
+
+var _ resource.Resource = &VmStackResource{}
+var _ resource.ResourceWithUpgradeState = &VmStackResource{}
+
+type VmStackResource struct {
+	client *core.UniversalClient
+}
+
+type VmStackModel struct {
+	ID                          types.String `tfsdk:"id"`
+	ResourceName                types.String `tfsdk:"resource_name"`
+	VdcID                       types.String `tfsdk:"vdc_id"`
+	VdcOrganizationUid          types.String `tfsdk:"vdc_organization_uid"`
+	VdcVdcProviderGateway       types.String `tfsdk:"vdc_vdc_provider_gateway"`
+	VdcStorageConfig            types.String `tfsdk:"vdc_storage_config"`
+	VdcVdcNetworkPool           types.String `tfsdk:"vdc_vdc_network_pool"`
+	VdcCpuGuaranteed            types.Int64  `tfsdk:"vdc_cpu_guaranteed"`
+	VdcMemGuaranteed            types.Int64  `tfsdk:"vdc_mem_guaranteed"`
+	VdcCpuAllocated             types.Int64  `tfsdk:"vdc_cpu_allocated"`
+	VdcMemAllocated             types.Int64  `tfsdk:"vdc_mem_allocated"`
+	NsxtID                      types.String `tfsdk:"nsxt_id"`
+	NsxtNeedEnableAVI           types.Bool   `tfsdk:"nsxt_need_enable_avi"`
+	NsxtVirtualServicesCount    types.Int64  `tfsdk:"nsxt_virtual_services_count"`
+	NsxtSegroupName             types.String `tfsdk:"nsxt_segroup_name"`
+	NsxtVdcType                 types.String `tfsdk:"nsxt_vdc_type"`
+	NsxtVdcGroupUid             types.String `tfsdk:"nsxt_vdc_group_uid"`
+	NsxtNeedExternalAddressSNAT types.Bool   `tfsdk:"nsxt_need_external_address_snat"`
+	NsxtIpSpaceName             types.String `tfsdk:"nsxt_ip_space_name"`
+	VappID                      types.String `tfsdk:"vapp_id"`
+	VappVappName                types.String `tfsdk:"vapp_vapp_name"`
+	VmID                        types.String `tfsdk:"vm_id"`
+	VmVmName                    types.String `tfsdk:"vm_vm_name"`
+	VmVmCpu                     types.Int64  `tfsdk:"vm_vm_cpu"`
+	VmVmRam                     types.Int64  `tfsdk:"vm_vm_ram"`
+	VmVmDisk                    types.Int64  `tfsdk:"vm_vm_disk"`
+	VmIpSpaceName               types.String `tfsdk:"vm_ip_space_name"`
+	VmAccessIpList              types.String `tfsdk:"vm_access_ip_list"`
+	VmImageVm                   types.String `tfsdk:"vm_image_vm"`
+	VmCloudInit                 types.String `tfsdk:"vm_cloud_init"`
+	VmUserLogin                 types.String `tfsdk:"vm_user_login"`
+	VmUserPublicKey             types.String `tfsdk:"vm_user_public_key"`
+	VmAccessPortList            types.String `tfsdk:"vm_access_port_list"`
+	VmNeedAddZabbixTemplate     types.Bool   `tfsdk:"vm_need_add_zabbix_template"`
+	DeleteMode                  types.String `tfsdk:"delete_mode"`
+	ResumeIfExists              types.Bool   `tfsdk:"resume_if_exists"`
+}
+
+func NewVmStackResource() resource.Resource {
+	return &VmStackResource{}
+}
+
+func (r *VmStackResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_stack"
+}
+
+func (r *VmStackResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attrs := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"resource_name": schema.StringAttribute{Required: true},
+		"vdc_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Instance id of the `vdc` child (nubes_vc_vdc).",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"vdc_organization_uid": schema.StringAttribute{
+			Required: true,
+		},
+		"vdc_vdc_provider_gateway": schema.StringAttribute{
+			Required: true,
+		},
+		"vdc_storage_config": schema.StringAttribute{
+			Required: true,
+		},
+		"vdc_vdc_network_pool": schema.StringAttribute{
+			Required: true,
+		},
+		"vdc_cpu_guaranteed": schema.Int64Attribute{
+			Required: true,
+		},
+		"vdc_mem_guaranteed": schema.Int64Attribute{
+			Required: true,
+		},
+		"vdc_cpu_allocated": schema.Int64Attribute{
+			Required: true,
+		},
+		"vdc_mem_allocated": schema.Int64Attribute{
+			Required: true,
+		},
+		"nsxt_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Instance id of the `nsxt` child (nubes_vc_nsxt).",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"nsxt_need_enable_avi": schema.BoolAttribute{
+			Required: true,
+		},
+		"nsxt_virtual_services_count": schema.Int64Attribute{
+			Optional: true,
+			Computed: true,
+			Default:  int64default.StaticInt64(1),
+		},
+		"nsxt_segroup_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"nsxt_vdc_type": schema.StringAttribute{
+			Required: true,
+		},
+		"nsxt_vdc_group_uid": schema.StringAttribute{
+			Optional: true,
+		},
+		"nsxt_need_external_address_snat": schema.BoolAttribute{
+			Optional: true,
+		},
+		"nsxt_ip_space_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"vapp_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Instance id of the `vapp` child (nubes_vapp).",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"vapp_vapp_name": schema.StringAttribute{
+			Required: true,
+		},
+		"vm_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Instance id of the `vm` child (nubes_vc_vm_v3).",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"vm_vm_name": schema.StringAttribute{
+			Required: true,
+		},
+		"vm_vm_cpu": schema.Int64Attribute{
+			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"vm_vm_ram": schema.Int64Attribute{
+			Required: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"vm_vm_disk": schema.Int64Attribute{
+			Optional: true,
+		},
+		"vm_ip_space_name": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString("no-needed"),
+		},
+		"vm_access_ip_list": schema.StringAttribute{
+			Optional: true,
+		},
+		"vm_image_vm": schema.StringAttribute{
+			Required: true,
+		},
+		"vm_cloud_init": schema.StringAttribute{
+			Optional:  true,
+			Sensitive: true,
+		},
+		"vm_user_login": schema.StringAttribute{
+			Required: true,
+		},
+		"vm_user_public_key": schema.StringAttribute{
+			Required:  true,
+			Sensitive: true,
+		},
+		"vm_access_port_list": schema.StringAttribute{
+			Required: true,
+		},
+		"vm_need_add_zabbix_template": schema.BoolAttribute{
+			Required: true,
+		},
+		"delete_mode": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString("state_only"),
+		},
+		"resume_if_exists": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Version:    0,
+		Attributes: attrs,
+	}
+}
+
+func (r *VmStackResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+func (r *VmStackResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data VmStackModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = core.WithSensitiveParams(ctx, 415, 417)
+
+	resourceName := data.ResourceName.ValueString()
+	children := []resources_core.CompositeChild{
+		{
+			Name:        "vdc",
+			ServiceID:   21,
+			DisplayName: resources_core.CompositeDisplayName(resourceName, "vdc"),
+			Params: map[int]string{
+				30:  resources_core.FormatString(data.VdcOrganizationUid),
+				335: resources_core.FormatString(data.VdcVdcProviderGateway),
+				361: resources_core.FormatString(data.VdcStorageConfig),
+				366: resources_core.FormatString(data.VdcVdcNetworkPool),
+				397: resources_core.FormatInt64(data.VdcCpuGuaranteed),
+				398: resources_core.FormatInt64(data.VdcMemGuaranteed),
+				557: resources_core.FormatInt64(data.VdcCpuAllocated),
+				558: resources_core.FormatInt64(data.VdcMemAllocated),
+			},
+		},
+		{
+			Name:        "nsxt",
+			ServiceID:   22,
+			DisplayName: resources_core.CompositeDisplayName(resourceName, "nsxt"),
+			Params: map[int]string{
+				340: resources_core.FormatBool(data.NsxtNeedEnableAVI),
+				341: resources_core.FormatInt64(data.NsxtVirtualServicesCount),
+				367: resources_core.FormatString(data.NsxtSegroupName),
+				621: resources_core.FormatString(data.NsxtVdcType),
+				622: resources_core.FormatString(data.NsxtVdcGroupUid),
+			},
+			Wiring: map[int]string{
+				8: "vdc",
+			},
+		},
+		{
+			Name:        "vapp",
+			ServiceID:   26,
+			DisplayName: resources_core.CompositeDisplayName(resourceName, "vapp"),
+			Params: map[int]string{
+				191: resources_core.FormatString(data.VappVappName),
+			},
+			Wiring: map[int]string{
+				190: "nsxt",
+				623: "vdc",
+			},
+		},
+		{
+			Name:        "vm",
+			ServiceID:   28,
+			DisplayName: resources_core.CompositeDisplayName(resourceName, "vm"),
+			Params: map[int]string{
+				408: resources_core.FormatString(data.VmVmName),
+				409: resources_core.FormatInt64(data.VmVmCpu),
+				410: resources_core.FormatInt64(data.VmVmRam),
+				411: resources_core.FormatInt64(data.VmVmDisk),
+				412: resources_core.FormatString(data.VmIpSpaceName),
+				413: resources_core.FormatString(data.VmAccessIpList),
+				414: resources_core.FormatString(data.VmImageVm),
+				415: resources_core.FormatString(data.VmCloudInit),
+				416: resources_core.FormatString(data.VmUserLogin),
+				417: resources_core.FormatString(data.VmUserPublicKey),
+				448: resources_core.FormatString(data.VmAccessPortList),
+				449: resources_core.FormatBool(data.VmNeedAddZabbixTemplate),
+			},
+			Wiring: map[int]string{
+				407: "vapp",
+			},
+			PollPolicy: vcVmV3PollPolicy,
+		},
+	}
+
+	ids, err := resources_core.CreateComposite(ctx, r.client, data.ResumeIfExists.ValueBool(), children)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		if len(ids) == 0 {
+			return
+		}
+		// The rollback left some children; keep them in state so that they
+		// are deleted with the (tainted) resource.
+	}
+
+	data.VdcID = resources_core.CompositeID(ids, "vdc")
+	data.NsxtID = resources_core.CompositeID(ids, "nsxt")
+	data.VappID = resources_core.CompositeID(ids, "vapp")
+	data.VmID = resources_core.CompositeID(ids, "vm")
+	data.ID = resources_core.CompositeID(ids, "vdc", "nsxt", "vapp", "vm")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *VmStackResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *VmStackModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data == nil {
+		return
+	}
+	if r.client != nil {
+		// A composite with a missing child is recreated as a whole.
+		for _, id := range []types.String{
+			data.VdcID,
+			data.NsxtID,
+			data.VappID,
+			data.VmID,
+		} {
+			if id.IsNull() || id.IsUnknown() {
+				continue
+			}
+			state, err := r.client.GetInstanceState(ctx, id.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", err.Error())
+				return
+			}
+			if state != nil && state.IsDeleted {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *VmStackResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan VmStackModel
+	var state VmStackModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = core.WithSensitiveParams(ctx, 415, 417)
+
+	ids := map[string]string{
+		"vdc":  state.VdcID.ValueString(),
+		"nsxt": state.NsxtID.ValueString(),
+		"vapp": state.VappID.ValueString(),
+		"vm":   state.VmID.ValueString(),
+	}
+	children := []resources_core.CompositeChild{
+		{
+			Name: "vdc",
+			Params: map[int]string{
+				560: resources_core.FormatInt64(plan.VdcCpuAllocated),
+				561: resources_core.FormatInt64(plan.VdcMemAllocated),
+				562: resources_core.FormatString(plan.VdcStorageConfig),
+			},
+			Skip: plan.VdcCpuAllocated.Equal(state.VdcCpuAllocated) && plan.VdcMemAllocated.Equal(state.VdcMemAllocated) && plan.VdcStorageConfig.Equal(state.VdcStorageConfig),
+		},
+		{
+			Name: "nsxt",
+			Params: map[int]string{
+				368: resources_core.FormatBool(plan.NsxtNeedEnableAVI),
+				369: resources_core.FormatInt64(plan.NsxtVirtualServicesCount),
+				370: resources_core.FormatString(plan.NsxtSegroupName),
+				371: resources_core.FormatBool(plan.NsxtNeedExternalAddressSNAT),
+				372: resources_core.FormatString(plan.NsxtIpSpaceName),
+			},
+			Skip: plan.NsxtNeedEnableAVI.Equal(state.NsxtNeedEnableAVI) && plan.NsxtVirtualServicesCount.Equal(state.NsxtVirtualServicesCount) && plan.NsxtSegroupName.Equal(state.NsxtSegroupName) && plan.NsxtNeedExternalAddressSNAT.Equal(state.NsxtNeedExternalAddressSNAT) && plan.NsxtIpSpaceName.Equal(state.NsxtIpSpaceName),
+		},
+		{
+			Name: "vm",
+			Params: map[int]string{
+				493: resources_core.FormatInt64(plan.VmVmCpu),
+				494: resources_core.FormatInt64(plan.VmVmRam),
+				495: resources_core.FormatInt64(plan.VmVmDisk),
+				496: resources_core.FormatString(plan.VmIpSpaceName),
+				497: resources_core.FormatString(plan.VmAccessIpList),
+				498: resources_core.FormatString(plan.VmAccessPortList),
+				499: resources_core.FormatBool(plan.VmNeedAddZabbixTemplate),
+			},
+			Skip:       plan.VmVmCpu.Equal(state.VmVmCpu) && plan.VmVmRam.Equal(state.VmVmRam) && plan.VmVmDisk.Equal(state.VmVmDisk) && plan.VmIpSpaceName.Equal(state.VmIpSpaceName) && plan.VmAccessIpList.Equal(state.VmAccessIpList) && plan.VmAccessPortList.Equal(state.VmAccessPortList) && plan.VmNeedAddZabbixTemplate.Equal(state.VmNeedAddZabbixTemplate),
+			PollPolicy: vcVmV3PollPolicy,
+		},
+	}
+
+	if err := resources_core.UpdateComposite(ctx, r.client, ids, children); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	plan.VdcID = state.VdcID
+	plan.NsxtID = state.NsxtID
+	plan.VappID = state.VappID
+	plan.VmID = state.VmID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *VmStackResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *VmStackModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	ids := map[string]string{
+		"vdc":  state.VdcID.ValueString(),
+		"nsxt": state.NsxtID.ValueString(),
+		"vapp": state.VappID.ValueString(),
+		"vm":   state.VmID.ValueString(),
+	}
+	children := []resources_core.CompositeChild{
+		{Name: "vdc"},
+		{Name: "nsxt"},
+		{Name: "vapp"},
+		{Name: "vm", PollPolicy: vcVmV3PollPolicy},
+	}
+	if err := resources_core.DeleteComposite(ctx, r.client, ids, children, state.DeleteMode.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+}
+
+func (r *VmStackResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	r.client = client
+}
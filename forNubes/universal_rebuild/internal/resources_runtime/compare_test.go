@@ -0,0 +1,681 @@
+package resources_runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_gen"
+	"terraform-provider-nubes/internal/servicespec"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The runtime resources must be indistinguishable from the generated ones:
+// same type names and schemas, same API requests and resulting state for
+// every operation.
+
+const (
+	existingUID  = "00000000-0000-0000-0000-0000000000ee"
+	duplicateUID = "00000000-0000-0000-0000-0000000000dd"
+)
+
+type pair struct {
+	svc       *Service
+	generated func() resource.Resource
+}
+
+func pairs(t *testing.T) []pair {
+	t.Helper()
+	svcs, err := Services()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byType := map[string]func() resource.Resource{}
+	for _, newResource := range resources_gen.AllResources() {
+		byType[typeName(newResource())] = newResource
+	}
+	var out []pair
+	for _, svc := range svcs {
+		generated, ok := byType["nubes_"+svc.Name]
+		if !ok {
+			t.Errorf("%s: no generated resource", svc.Name)
+			continue
+		}
+		if got := typeName(NewResource(svc)); got != "nubes_"+svc.Name {
+			t.Errorf("%s: type name %s", svc.Name, got)
+		}
+		out = append(out, pair{svc: svc, generated: generated})
+	}
+	return out
+}
+
+func TestReplace(t *testing.T) {
+	svcs, err := Services()
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated := resources_gen.AllResources()
+	replaced := Replace(generated, []string{svcs[0].Name})
+	if len(replaced) != len(generated) {
+		t.Fatalf("got %d resources, want %d", len(replaced), len(generated))
+	}
+	runtime := 0
+	for i, newResource := range replaced {
+		r := newResource()
+		if typeName(r) != typeName(generated[i]()) {
+			t.Errorf("resource %d: got %s, want %s", i, typeName(r), typeName(generated[i]()))
+		}
+		if _, ok := r.(*serviceResource); ok {
+			runtime++
+			if typeName(r) == "nubes_"+svcs[0].Name {
+				t.Errorf("%s is kept generated but was replaced", svcs[0].Name)
+			}
+		}
+	}
+	if runtime != len(svcs)-1 {
+		t.Errorf("replaced %d resources, want %d", runtime, len(svcs)-1)
+	}
+}
+
+func TestSchemaMatchesGenerated(t *testing.T) {
+	for _, p := range pairs(t) {
+		want := describeSchema(schemaOf(p.generated()))
+		got := describeSchema(schemaOf(NewResource(p.svc)))
+		if got != want {
+			t.Errorf("%s: schema differs\n--- runtime\n%s\n--- generated\n%s", p.svc.Name, got, want)
+		}
+	}
+}
+
+func TestUpgradeStateMatchesGenerated(t *testing.T) {
+	ctx := context.Background()
+	for _, p := range pairs(t) {
+		gen := p.generated().(resource.ResourceWithUpgradeState).UpgradeState(ctx)
+		run := NewResource(p.svc).(resource.ResourceWithUpgradeState).UpgradeState(ctx)
+		if len(gen) != len(run) {
+			t.Errorf("%s: %d upgraders, generated has %d", p.svc.Name, len(run), len(gen))
+			continue
+		}
+		for _, u := range p.svc.Upgraders {
+			want, ok := gen[u.FromVersion]
+			if !ok {
+				t.Errorf("%s: generated has no upgrader from version %d", p.svc.Name, u.FromVersion)
+				continue
+			}
+			raw := priorState(p.svc, u)
+			wantJSON, wantDiags := upgrade(ctx, want, raw)
+			gotJSON, gotDiags := upgrade(ctx, run[u.FromVersion], raw)
+			if gotJSON != wantJSON || !gotDiags.Equal(wantDiags) {
+				t.Errorf("%s: upgrade from %d differs\nruntime:   %s %v\ngenerated: %s %v", p.svc.Name, u.FromVersion, gotJSON, gotDiags, wantJSON, wantDiags)
+			}
+		}
+	}
+}
+
+// priorState is a raw state of the version u upgrades from: the current
+// attributes with the migration steps undone.
+func priorState(svc *Service, u Upgrader) []byte {
+	attrs := map[string]interface{}{}
+	for name, typ := range svc.attrTypes() {
+		attrs[name] = sampleJSON(typ)
+	}
+	for i := len(u.Steps) - 1; i >= 0; i-- {
+		step := u.Steps[i]
+		for name, typ := range step.Convert {
+			if _, ok := attrs[name]; ok && typ != "string" {
+				attrs[name] = "1"
+			}
+		}
+		for from, to := range step.Rename {
+			attrs[from] = attrs[to]
+			delete(attrs, to)
+		}
+		for _, name := range step.Drop {
+			attrs[name] = "dropped"
+		}
+	}
+	b, _ := json.Marshal(attrs)
+	return b
+}
+
+func sampleJSON(typ attr.Type) interface{} {
+	switch {
+	case typ.Equal(types.BoolType):
+		return true
+	case typ.Equal(types.Int64Type):
+		return 3
+	default:
+		return "1"
+	}
+}
+
+func upgrade(ctx context.Context, u resource.StateUpgrader, raw []byte) (string, diag.Diagnostics) {
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: raw}}
+	var resp resource.UpgradeStateResponse
+	u.StateUpgrader(ctx, req, &resp)
+	if resp.DynamicValue == nil {
+		return "", resp.Diagnostics
+	}
+	return string(resp.DynamicValue.JSON), resp.Diagnostics
+}
+
+func TestBehaviorMatchesGenerated(t *testing.T) {
+	type run struct {
+		name      string
+		got, want outcome
+	}
+	var runs []*run
+	var wg sync.WaitGroup
+	// Operations wait on real timers (a service polling policy may delay the
+	// first check by seconds), so every run starts at once.
+	for _, p := range pairs(t) {
+		if p.svc.PollPolicy != nil && testing.Short() {
+			continue
+		}
+		for _, sc := range scenarios(p.svc) {
+			r := &run{name: p.svc.Name + "/" + sc.name}
+			runs = append(runs, r)
+			wg.Add(2)
+			go func(p pair, sc scenario) {
+				defer wg.Done()
+				r.want = runScenario(p.svc, p.generated(), sc)
+			}(p, sc)
+			go func(p pair, sc scenario) {
+				defer wg.Done()
+				r.got = runScenario(p.svc, NewResource(p.svc), sc)
+			}(p, sc)
+		}
+	}
+	wg.Wait()
+
+	for _, r := range runs {
+		t.Run(r.name, func(t *testing.T) {
+			got, want := r.got, r.want
+			if !got.state.Equal(want.state) {
+				t.Errorf("state differs\nruntime:   %s\ngenerated: %s", got.state, want.state)
+			}
+			if !got.diags.Equal(want.diags) {
+				t.Errorf("diagnostics differ\nruntime:   %v\ngenerated: %v", got.diags, want.diags)
+			}
+			if g, w := strings.Join(got.requests, "\n"), strings.Join(want.requests, "\n"); g != w {
+				t.Errorf("requests differ\n--- runtime\n%s\n--- generated\n%s", g, w)
+			}
+		})
+	}
+}
+
+// A create whose duplicate check fails must still record the instance it
+// created, or nothing in Terraform refers to it.
+func TestCreateKeepsInstanceOnDuplicate(t *testing.T) {
+	for _, p := range pairs(t) {
+		if p.svc.PollPolicy != nil && testing.Short() {
+			continue
+		}
+		for _, sc := range scenarios(p.svc) {
+			if sc.name != "create_duplicate" {
+				continue
+			}
+			for kind, r := range map[string]resource.Resource{"generated": p.generated(), "runtime": NewResource(p.svc)} {
+				out := runScenario(p.svc, r, sc)
+				if !out.diags.HasError() || !strings.Contains(out.diags.Errors()[0].Detail(), "duplicate instances") {
+					t.Errorf("%s %s: got %v, want the duplicate error", kind, p.svc.Name, out.diags)
+				}
+				var id interface{}
+				if !out.state.IsNull() {
+					id, _, _ = tftypes.WalkAttributePath(out.state, tftypes.NewAttributePath().WithAttributeName("id"))
+				}
+				if v, ok := id.(tftypes.Value); !ok || !v.IsKnown() || v.IsNull() {
+					t.Errorf("%s %s: created instance not kept in state: %v", kind, p.svc.Name, out.state)
+				}
+			}
+		}
+	}
+}
+
+type scenario struct {
+	name              string
+	verifyUniqueNames bool
+	run               func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics)
+}
+
+type outcome struct {
+	state    tftypes.Value
+	diags    diag.Diagnostics
+	requests []string
+}
+
+func runScenario(svc *Service, r resource.Resource, sc scenario) outcome {
+	api := newFakeAPI(svc)
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	ctx := core.WithPollPolicy(context.Background(), core.PollPolicy{Initial: time.Millisecond})
+	client := &core.UniversalClient{HttpClient: srv.Client(), ApiEndpoint: srv.URL, VerifyUniqueNames: sc.verifyUniqueNames}
+	var cresp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &cresp)
+	state, diags := sc.run(ctx, r, schemaOf(r), svc)
+	cresp.Diagnostics.Append(diags...)
+	return outcome{state: state, diags: cresp.Diagnostics, requests: api.requests()}
+}
+
+func scenarios(svc *Service) []scenario {
+	create := func(name string, resume bool) func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+		return func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+			plan := planValues(svc, name, "new")
+			plan["resume_if_exists"] = tftypes.NewValue(tftypes.Bool, resume)
+			plan["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			plan["pending_operation"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			for _, o := range svc.Outputs {
+				plan[o.Name] = tftypes.NewValue(tfType(o.Type), tftypes.UnknownValue)
+			}
+			req := resource.CreateRequest{
+				Plan:   tfsdk.Plan{Schema: s, Raw: object(s, plan)},
+				Config: tfsdk.Config{Schema: s, Raw: object(s, configValues(svc, name, "new"))},
+			}
+			resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(object(s, nil).Type(), nil)}}
+			r.Create(ctx, req, &resp)
+			return resp.State.Raw, resp.Diagnostics
+		}
+	}
+	modifyPlan := func(resume bool) func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+		return func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+			config := configValues(svc, "existing", "new")
+			config["resume_if_exists"] = tftypes.NewValue(tftypes.Bool, resume)
+			plan := planValues(svc, "existing", "new")
+			plan["resume_if_exists"] = tftypes.NewValue(tftypes.Bool, resume)
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: object(s, config)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: object(s, plan)},
+				State:  tfsdk.State{Schema: s, Raw: tftypes.NewValue(object(s, nil).Type(), nil)},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, req, &resp)
+			return resp.Plan.Raw, resp.Diagnostics
+		}
+	}
+
+	return []scenario{
+		{name: "create", run: create("new", false)},
+		{name: "create_adopt", run: create("existing", true)},
+		{name: "create_existing", run: create("existing", false)},
+		{name: "create_duplicate", verifyUniqueNames: true, run: create("duplicate", false)},
+		{name: "read", run: func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+			state := stateValues(svc, "old")
+			state["pending_operation"] = tftypes.NewValue(tftypes.String, "00000000-0000-0000-0000-0000000000ff")
+			req := resource.ReadRequest{State: tfsdk.State{Schema: s, Raw: object(s, state)}}
+			resp := resource.ReadResponse{State: req.State}
+			r.Read(ctx, req, &resp)
+			return resp.State.Raw, resp.Diagnostics
+		}},
+		{name: "update", run: func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+			plan := planValues(svc, "existing", "new")
+			for name, v := range stateValues(svc, "old") {
+				if _, ok := plan[name]; !ok {
+					plan[name] = v
+				}
+			}
+			req := resource.UpdateRequest{
+				Plan:   tfsdk.Plan{Schema: s, Raw: object(s, plan)},
+				State:  tfsdk.State{Schema: s, Raw: object(s, stateValues(svc, "old"))},
+				Config: tfsdk.Config{Schema: s, Raw: object(s, configValues(svc, "existing", "new"))},
+			}
+			resp := resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: req.Plan.Raw.Copy()}}
+			r.Update(ctx, req, &resp)
+			return resp.State.Raw, resp.Diagnostics
+		}},
+		{name: "delete", run: func(ctx context.Context, r resource.Resource, s schema.Schema, svc *Service) (tftypes.Value, diag.Diagnostics) {
+			state := stateValues(svc, "old")
+			state["delete_mode"] = tftypes.NewValue(tftypes.String, "delete")
+			req := resource.DeleteRequest{State: tfsdk.State{Schema: s, Raw: object(s, state)}}
+			resp := resource.DeleteResponse{State: req.State}
+			r.Delete(ctx, req, &resp)
+			return resp.State.Raw, resp.Diagnostics
+		}},
+		{name: "plan_adopt", run: modifyPlan(true)},
+		{name: "plan_existing", run: modifyPlan(false)},
+	}
+}
+
+// paramValue is a sample value of a param attribute; references get a UUID
+// so that they pass validation without a lookup.
+func paramValue(p Param, variant string) tftypes.Value {
+	switch servicespec.CanonicalType(p.Type) {
+	case "bool":
+		return tftypes.NewValue(tftypes.Bool, variant == "new")
+	case "int64":
+		n := int64(1)
+		if variant == "new" {
+			n = 2
+		}
+		return tftypes.NewValue(tftypes.Number, n)
+	}
+	if p.RefServiceID > 0 {
+		return tftypes.NewValue(tftypes.String, "00000000-0000-0000-0000-00000000"+fmt.Sprintf("%04d", p.RefServiceID))
+	}
+	return tftypes.NewValue(tftypes.String, variant+"-"+servicespec.AttrName(p))
+}
+
+func configValues(svc *Service, name string, variant string) map[string]tftypes.Value {
+	values := map[string]tftypes.Value{"resource_name": tftypes.NewValue(tftypes.String, name)}
+	for _, p := range svc.AllParams {
+		values[servicespec.AttrName(p)] = paramValue(p, variant)
+		if p.WriteOnly {
+			values[servicespec.AttrName(p)+"_version"] = tftypes.NewValue(tftypes.Number, 2)
+		}
+	}
+	return values
+}
+
+// planValues is the config with write-only values removed and the defaults
+// of unset optional attributes applied.
+func planValues(svc *Service, name string, variant string) map[string]tftypes.Value {
+	values := configValues(svc, name, variant)
+	for _, p := range svc.AllParams {
+		if p.WriteOnly {
+			values[servicespec.AttrName(p)] = tftypes.NewValue(tfType(p.Type), nil)
+		}
+	}
+	deleteMode := svc.DeleteMode
+	if deleteMode == "" {
+		deleteMode = "state_only"
+	}
+	values["delete_mode"] = tftypes.NewValue(tftypes.String, deleteMode)
+	values["resume_if_exists"] = tftypes.NewValue(tftypes.Bool, svc.ResumeIfExists)
+	return values
+}
+
+func stateValues(svc *Service, variant string) map[string]tftypes.Value {
+	values := planValues(svc, "existing", variant)
+	values["id"] = tftypes.NewValue(tftypes.String, existingUID)
+	for _, p := range svc.AllParams {
+		if p.WriteOnly {
+			values[servicespec.AttrName(p)+"_version"] = tftypes.NewValue(tftypes.Number, 1)
+		}
+	}
+	for _, o := range svc.Outputs {
+		switch servicespec.CanonicalType(o.Type) {
+		case "bool":
+			values[o.Name] = tftypes.NewValue(tftypes.Bool, false)
+		case "int64":
+			values[o.Name] = tftypes.NewValue(tftypes.Number, 0)
+		default:
+			values[o.Name] = tftypes.NewValue(tftypes.String, "old-"+o.Name)
+		}
+	}
+	return values
+}
+
+func tfType(typ string) tftypes.Type {
+	switch servicespec.CanonicalType(typ) {
+	case "bool":
+		return tftypes.Bool
+	case "int64":
+		return tftypes.Number
+	default:
+		return tftypes.String
+	}
+}
+
+// object builds the schema object from values; missing attributes are null.
+func object(s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	typ := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, at := range typ.AttributeTypes {
+		if v, ok := values[name]; ok {
+			vals[name] = v
+		} else {
+			vals[name] = tftypes.NewValue(at, nil)
+		}
+	}
+	return tftypes.NewValue(typ, vals)
+}
+
+func schemaOf(r resource.Resource) schema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// describeSchema renders what a schema declares, validators and plan
+// modifiers by type and description, so that two schemas compare as text.
+func describeSchema(s schema.Schema) string {
+	ctx := context.Background()
+	lines := []string{fmt.Sprintf("version=%d description=%q markdown=%q", s.Version, s.Description, s.MarkdownDescription)}
+	for name, a := range s.Attributes {
+		line := fmt.Sprintf("%s %T required=%t optional=%t computed=%t sensitive=%t write_only=%t description=%q markdown=%q",
+			name, a, a.IsRequired(), a.IsOptional(), a.IsComputed(), a.IsSensitive(), a.IsWriteOnly(), a.GetDescription(), a.GetMarkdownDescription())
+		var extra []interface{}
+		switch a := a.(type) {
+		case schema.StringAttribute:
+			if a.Default != nil {
+				var resp defaults.StringResponse
+				a.Default.DefaultString(ctx, defaults.StringRequest{}, &resp)
+				extra = append(extra, "default="+resp.PlanValue.String())
+			}
+			for _, v := range a.Validators {
+				extra = append(extra, v)
+			}
+			for _, m := range a.PlanModifiers {
+				extra = append(extra, m)
+			}
+		case schema.Int64Attribute:
+			if a.Default != nil {
+				var resp defaults.Int64Response
+				a.Default.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+				extra = append(extra, "default="+resp.PlanValue.String())
+			}
+			for _, v := range a.Validators {
+				extra = append(extra, v)
+			}
+			for _, m := range a.PlanModifiers {
+				extra = append(extra, m)
+			}
+		case schema.BoolAttribute:
+			if a.Default != nil {
+				var resp defaults.BoolResponse
+				a.Default.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+				extra = append(extra, "default="+resp.PlanValue.String())
+			}
+			for _, v := range a.Validators {
+				extra = append(extra, v)
+			}
+			for _, m := range a.PlanModifiers {
+				extra = append(extra, m)
+			}
+		}
+		for _, e := range extra {
+			switch e := e.(type) {
+			case string:
+				line += " " + e
+			case interface {
+				MarkdownDescription(context.Context) string
+			}:
+				line += fmt.Sprintf(" %T(%q)", e, e.MarkdownDescription(ctx))
+			}
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines[1:])
+	return strings.Join(lines, "\n")
+}
+
+// fakeAPI serves the part of the Nubes API the resources use: every
+// operation succeeds at once, and one instance named "existing" is running.
+// Once an instance is created, another one named "duplicate" shows up as if
+// created concurrently elsewhere.
+type fakeAPI struct {
+	svc *Service
+
+	mu      sync.Mutex
+	log     []string
+	next    int
+	created bool
+}
+
+func newFakeAPI(svc *Service) *fakeAPI {
+	return &fakeAPI{svc: svc}
+}
+
+func (f *fakeAPI) requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := append([]string(nil), f.log...)
+	sort.Strings(out)
+	return out
+}
+
+func (f *fakeAPI) uid() string {
+	f.next++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", f.next)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var payload map[string]interface{}
+	if json.Unmarshal(body, &payload) == nil {
+		// descr carries a random idempotency marker.
+		delete(payload, "descr")
+		body, _ = json.Marshal(payload)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.log = append(f.log, fmt.Sprintf("%s %s %s", r.Method, r.URL.RequestURI(), body))
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/instances":
+		var results []interface{}
+		if r.URL.Query().Get("page") == "1" {
+			results = append(results, map[string]interface{}{
+				"instanceUid": existingUID, "displayName": "existing", "serviceId": f.svc.ServiceID,
+			})
+			if f.created {
+				results = append(results, map[string]interface{}{
+					"instanceUid": duplicateUID, "displayName": "duplicate", "serviceId": f.svc.ServiceID,
+				})
+			}
+		}
+		writeJSON(w, map[string]interface{}{"results": results})
+	case r.Method == "GET" && parts[0] == "instances" && len(parts) == 2:
+		writeJSON(w, map[string]interface{}{"instance": f.instance(parts[1])})
+	case r.Method == "POST" && (r.URL.Path == "/instances" || r.URL.Path == "/instanceOperations"):
+		f.created = f.created || r.URL.Path == "/instances"
+		w.Header().Set("Location", "./"+f.uid())
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == "GET" && parts[0] == "instanceOperations" && len(parts) == 2:
+		writeJSON(w, map[string]interface{}{"instanceOperation": f.operation()})
+	default:
+		writeJSON(w, map[string]interface{}{})
+	}
+}
+
+func (f *fakeAPI) instance(uid string) map[string]interface{} {
+	inst := map[string]interface{}{
+		"instanceUid":     uid,
+		"displayName":     "existing",
+		"serviceId":       0,
+		"explainedStatus": "running",
+		"availableOperations": []interface{}{
+			map[string]interface{}{"svcOperationId": 1, "operation": "modify"},
+			map[string]interface{}{"svcOperationId": 2, "operation": "suspend"},
+			map[string]interface{}{"svcOperationId": 3, "operation": "delete"},
+			map[string]interface{}{"svcOperationId": 4, "operation": "resume"},
+		},
+	}
+	if uid == existingUID {
+		inst["serviceId"] = f.svc.ServiceID
+	}
+	f.outputs(inst, "instance")
+	return inst
+}
+
+func (f *fakeAPI) operation() map[string]interface{} {
+	op := map[string]interface{}{
+		"dtFinish":     "2024-01-01T00:00:00Z",
+		"isSuccessful": true,
+		"cfsParams": []interface{}{
+			map[string]interface{}{"svcOperationCfsParamId": 90001, "isRequired": true, "defaultValue": "default"},
+			map[string]interface{}{"svcOperationCfsParamId": 90002, "isRequired": false, "dataType": "array"},
+		},
+	}
+	f.outputs(op, "operation")
+	return op
+}
+
+// outputs puts a value at the path of every output read from src.
+func (f *fakeAPI) outputs(obj map[string]interface{}, src string) {
+	for _, o := range f.svc.Outputs {
+		from := o.From
+		if from == "" {
+			from = "instance"
+		}
+		if from != src {
+			continue
+		}
+		var value interface{}
+		switch servicespec.CanonicalType(o.Type) {
+		case "bool":
+			value = true
+		case "int64":
+			value = 5432
+		default:
+			value = src + "-" + o.Name
+		}
+		put(obj, o.Path, value)
+	}
+}
+
+// put creates path (in core.LookupPath syntax) in obj and sets value.
+func put(obj map[string]interface{}, path string, value interface{}) {
+	cur := obj
+	segs := strings.Split(path, ".")
+	for i, seg := range segs {
+		last := i == len(segs)-1
+		key, selector, hasSelector := strings.Cut(seg, "[")
+		if !hasSelector {
+			if last {
+				cur[key] = value
+				return
+			}
+			next, ok := cur[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				cur[key] = next
+			}
+			cur = next
+			continue
+		}
+		field, want, _ := strings.Cut(strings.TrimSuffix(selector, "]"), "=")
+		item := map[string]interface{}{field: want}
+		items, _ := cur[key].([]interface{})
+		cur[key] = append(items, item)
+		if last {
+			return
+		}
+		cur = item
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,66 @@
+// Package resources_runtime builds the service resources at run time from
+// the embedded resources_yaml, as an alternative to the code tools/gen
+// writes to internal/resources_gen. Composites and resources with a hooks
+// file are only available as generated code.
+package resources_runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"terraform-provider-nubes/resources_overrides"
+	"terraform-provider-nubes/resources_yaml"
+	"terraform-provider-nubes/schema_history"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var (
+	loadOnce sync.Once
+	services []*Service
+	loadErr  error
+)
+
+// Services returns the services of the embedded YAML files.
+func Services() ([]*Service, error) {
+	loadOnce.Do(func() {
+		services, loadErr = Load(resources_yaml.FS, schema_history.FS, resources_overrides.FS)
+	})
+	return services, loadErr
+}
+
+// Replace returns generated with every resource that has an embedded service
+// swapped for its runtime counterpart, except the services in keep. The
+// embedded files are fixed at build time, so a load error is a build defect
+// and panics.
+func Replace(generated []func() resource.Resource, keep []string) []func() resource.Resource {
+	svcs, err := Services()
+	if err != nil {
+		panic(fmt.Sprintf("resources_runtime: %s", err))
+	}
+	byType := make(map[string]*Service, len(svcs))
+	for _, svc := range svcs {
+		byType[typeName(NewResource(svc))] = svc
+	}
+	for _, name := range keep {
+		delete(byType, "nubes_"+name)
+	}
+
+	out := make([]func() resource.Resource, 0, len(generated))
+	for _, newResource := range generated {
+		svc, ok := byType[typeName(newResource())]
+		if !ok {
+			out = append(out, newResource)
+			continue
+		}
+		out = append(out, func() resource.Resource { return NewResource(svc) })
+	}
+	return out
+}
+
+func typeName(r resource.Resource) string {
+	var resp resource.MetadataResponse
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "nubes"}, &resp)
+	return resp.TypeName
+}
@@ -0,0 +1,456 @@
+package resources_runtime
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+	"terraform-provider-nubes/internal/servicespec"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serviceResource is the generic counterpart of a generated
+// <name>_resource.go: each method follows the template step by step, with
+// the model held as an attribute map instead of a struct.
+type serviceResource struct {
+	svc    *Service
+	client *core.UniversalClient
+}
+
+var _ resource.Resource = &serviceResource{}
+var _ resource.ResourceWithModifyPlan = &serviceResource{}
+var _ resource.ResourceWithUpgradeState = &serviceResource{}
+
+// NewResource returns the resource type of svc.
+func NewResource(svc *Service) resource.Resource {
+	return &serviceResource{svc: svc}
+}
+
+// model is the resource data keyed by attribute name.
+type model map[string]attr.Value
+
+type dataGetter interface {
+	Get(ctx context.Context, target interface{}) diag.Diagnostics
+}
+
+type dataSetter interface {
+	Set(ctx context.Context, val interface{}) diag.Diagnostics
+}
+
+// getModel reads the whole plan, state or config; a null object gives a nil model.
+func getModel(ctx context.Context, from dataGetter) (model, diag.Diagnostics) {
+	var obj types.Object
+	diags := from.Get(ctx, &obj)
+	if diags.HasError() || obj.IsNull() {
+		return nil, diags
+	}
+	return model(obj.Attributes()), diags
+}
+
+func (r *serviceResource) setModel(ctx context.Context, to dataSetter, m model) diag.Diagnostics {
+	obj, diags := types.ObjectValue(r.svc.attrTypes(), m)
+	if diags.HasError() {
+		return diags
+	}
+	return to.Set(ctx, obj)
+}
+
+func (m model) str(name string) types.String {
+	v, _ := m[name].(types.String)
+	return v
+}
+
+func (m model) boolean(name string) types.Bool {
+	v, _ := m[name].(types.Bool)
+	return v
+}
+
+// format renders the value of a param attribute like the generated
+// resources_core.Format* calls.
+func format(p Param, v attr.Value) string {
+	switch servicespec.CanonicalType(p.Type) {
+	case "bool":
+		b, _ := v.(types.Bool)
+		return resources_core.FormatBool(b)
+	case "int64":
+		n, _ := v.(types.Int64)
+		return resources_core.FormatInt64(n)
+	default:
+		s, _ := v.(types.String)
+		return resources_core.FormatString(s)
+	}
+}
+
+func null(typ string) attr.Value {
+	switch servicespec.CanonicalType(typ) {
+	case "bool":
+		return types.BoolNull()
+	case "int64":
+		return types.Int64Null()
+	default:
+		return types.StringNull()
+	}
+}
+
+func (r *serviceResource) outputs() []resources_core.Output {
+	outputs := make([]resources_core.Output, 0, len(r.svc.Outputs))
+	for _, o := range r.svc.Outputs {
+		from := o.From
+		if from == "" {
+			from = "instance"
+		}
+		outputs = append(outputs, resources_core.Output{Attribute: o.Name, From: from, Path: o.Path})
+	}
+	return outputs
+}
+
+// assignOutputs sets every output from values, keeping the previous value of
+// those absent.
+func (r *serviceResource) assignOutputs(m model, values map[string]string) {
+	for _, o := range r.svc.Outputs {
+		switch servicespec.CanonicalType(o.Type) {
+		case "bool":
+			prev, _ := m[o.Name].(types.Bool)
+			m[o.Name] = resources_core.OutputBool(values, o.Name, prev)
+		case "int64":
+			prev, _ := m[o.Name].(types.Int64)
+			m[o.Name] = resources_core.OutputInt64(values, o.Name, prev)
+		default:
+			prev, _ := m[o.Name].(types.String)
+			m[o.Name] = resources_core.OutputString(values, o.Name, prev)
+		}
+	}
+}
+
+func (r *serviceResource) clearWriteOnly(m model) {
+	for _, p := range r.svc.AllParams {
+		if p.WriteOnly {
+			m[servicespec.AttrName(p)] = null(p.Type)
+		}
+	}
+}
+
+// withParams adds the sensitive params, references and polling of the
+// service to ctx.
+func (r *serviceResource) withParams(ctx context.Context) context.Context {
+	if len(r.svc.SensitiveParamIDs) > 0 {
+		ctx = core.WithSensitiveParams(ctx, r.svc.SensitiveParamIDs...)
+	}
+	if len(r.svc.ParamRefs) > 0 {
+		ctx = core.WithParamRefs(ctx, r.svc.ParamRefs)
+	}
+	return r.withPolling(ctx)
+}
+
+func (r *serviceResource) withPolling(ctx context.Context) context.Context {
+	if r.svc.PollPolicy != nil {
+		ctx = core.WithPollPolicy(ctx, *r.svc.PollPolicy)
+	}
+	return ctx
+}
+
+func (r *serviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.svc.Name
+}
+
+func (r *serviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = r.svc.Schema()
+}
+
+func (r *serviceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(r.svc.Upgraders))
+	for _, u := range r.svc.Upgraders {
+		upgraders[u.FromVersion] = resources_core.MigrateState(u.Steps...)
+	}
+	return upgraders
+}
+
+func (r *serviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	config, diags := getModel(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config == nil {
+		return
+	}
+
+	if len(r.svc.RefParams) > 0 {
+		refs := make([]resources_core.Reference, 0, len(r.svc.RefParams))
+		for _, p := range r.svc.RefParams {
+			refs = append(refs, resources_core.Reference{Attribute: servicespec.AttrName(p), ServiceID: p.RefServiceID, Value: config.str(servicespec.AttrName(p))})
+		}
+		resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client, refs...)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state, diags := getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state != nil && !state.str("id").IsNull() && !state.str("id").IsUnknown() {
+		return
+	}
+
+	resourceName := config.str("resource_name")
+	if resourceName.IsNull() || resourceName.IsUnknown() {
+		return
+	}
+	resumeIfExists := true
+	if v := config.boolean("resume_if_exists"); !v.IsNull() && !v.IsUnknown() {
+		resumeIfExists = v.ValueBool()
+	}
+
+	existing, err := r.client.FindInstanceByDisplayName(ctx, r.svc.ServiceID, resourceName.ValueString())
+	if err != nil || existing == nil {
+		return
+	}
+
+	if !resumeIfExists {
+		resp.Diagnostics.AddError(
+			"RESOURCE WITH SAME NAME EXISTS",
+			"A resource with the same resource_name already exists. Set resume_if_exists=true to adopt or choose a different name.",
+		)
+		return
+	}
+	addAdoptWarning(&resp.Diagnostics, existing.ExplainedStatus)
+}
+
+func addAdoptWarning(diags *diag.Diagnostics, explainedStatus string) {
+	status := strings.ToLower(explainedStatus)
+	if strings.Contains(status, "suspend") {
+		diags.AddWarning(
+			"RESOURCE WITH SAME NAME EXISTS (SUSPENDED)",
+			"An existing resource with the same resource_name was found and is suspended. With resume_if_exists=true it will be resumed and adopted without applying new parameters. Run plan/apply again with changes to perform modify.",
+		)
+		return
+	}
+	diags.AddWarning(
+		"RESOURCE WITH SAME NAME EXISTS",
+		"An existing resource with the same resource_name was found. With resume_if_exists=true it will be adopted without applying new parameters. Run plan/apply again with changes to perform modify.",
+	)
+}
+
+func (r *serviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	data, diags := getModel(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	if data == nil {
+		data = model{}
+	}
+	for _, p := range r.svc.AllParams {
+		if p.WriteOnly {
+			var v attr.Value
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(servicespec.AttrName(p)), &v)...)
+			data[servicespec.AttrName(p)] = v
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, opLog := core.WithOperationLog(ctx)
+	ctx = r.withParams(ctx)
+
+	resourceName := data.str("resource_name").ValueString()
+	resumeIfExists := data.boolean("resume_if_exists")
+	if r.client != nil && !resumeIfExists.IsNull() && !resumeIfExists.IsUnknown() && resumeIfExists.ValueBool() {
+		existing, err := r.client.FindInstanceByDisplayName(ctx, r.svc.ServiceID, resourceName)
+		if err == nil && existing != nil {
+			addAdoptWarning(&resp.Diagnostics, existing.ExplainedStatus)
+		}
+	}
+
+	params := make(map[int]string, len(r.svc.FixedParams)+len(r.svc.CreateParams))
+	for _, p := range r.svc.FixedParams {
+		params[p.ID] = p.Value
+	}
+	for _, p := range r.svc.CreateParams {
+		params[p.ID] = format(p, data[servicespec.AttrName(p)])
+	}
+
+	id, err := resources_core.CreateResource(ctx, r.client, r.svc.ServiceID, resourceName, resumeIfExists.ValueBool(), params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		data["pending_operation"] = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data["pending_operation"] = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data["id"] = types.StringValue(id)
+			for _, o := range r.svc.Outputs {
+				data[o.Name] = null(o.Type)
+			}
+			r.clearWriteOnly(data)
+			resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+		}
+		return
+	}
+
+	data["id"] = types.StringValue(id)
+	data["pending_operation"] = types.StringNull()
+	if len(r.svc.Outputs) > 0 {
+		outputs, err := resources_core.ReadOutputs(ctx, r.client, id, opLog.Last("create"), r.outputs()...)
+		if err != nil {
+			resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+		}
+		r.assignOutputs(data, outputs)
+	}
+	r.clearWriteOnly(data)
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+}
+
+func (r *serviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	data, diags := getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data == nil {
+		return
+	}
+	if id := data.str("id"); r.client != nil && !id.IsNull() && !id.IsUnknown() {
+		state, err := r.client.GetInstanceState(ctx, id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", err.Error())
+			return
+		}
+		if state != nil {
+			status := strings.ToLower(strings.TrimSpace(state.ExplainedStatus))
+			if state.IsDeleted || status == "deleted" {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.str("pending_operation"))
+		resp.Diagnostics.Append(diags...)
+		data["pending_operation"] = pending
+
+		if len(r.svc.Outputs) > 0 {
+			outputs, err := resources_core.ReadOutputs(ctx, r.client, id.ValueString(), "", r.outputs()...)
+			if err != nil {
+				resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+			}
+			r.assignOutputs(data, outputs)
+		}
+	}
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, data)...)
+}
+
+func (r *serviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan, diags := getModel(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	state, diags := getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan == nil {
+		plan = model{}
+	}
+	if state == nil {
+		state = model{}
+	}
+
+	instanceID := state.str("id")
+	if instanceID.IsNull() || instanceID.IsUnknown() {
+		instanceID = plan.str("id")
+	}
+	if instanceID.IsNull() || instanceID.IsUnknown() {
+		resp.Diagnostics.AddError("Client Error", "missing instance id for modify")
+		return
+	}
+
+	ctx = r.withParams(ctx)
+
+	params := map[int]string{}
+	for _, p := range r.svc.ModifyParams {
+		if !p.WriteOnly {
+			params[p.ID] = format(p, plan[servicespec.AttrName(p)])
+		}
+	}
+	for _, p := range r.svc.ModifyParams {
+		if !p.WriteOnly {
+			continue
+		}
+		// Write-only values are never in plan or state; resubmit only when the version trigger changes.
+		version := servicespec.AttrName(p) + "_version"
+		if !plan[version].Equal(state[version]) {
+			var v attr.Value
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(servicespec.AttrName(p)), &v)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			params[p.ID] = format(p, v)
+		}
+	}
+
+	ctx, opLog := core.WithOperationLog(ctx)
+	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state["pending_operation"] = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(r.setModel(ctx, &resp.State, state)...)
+		}
+		return
+	}
+
+	plan["id"] = instanceID
+	plan["pending_operation"] = types.StringNull()
+	if len(r.svc.Outputs) > 0 {
+		outputs, err := resources_core.ReadOutputs(ctx, r.client, instanceID.ValueString(), "", r.outputs()...)
+		if err != nil {
+			resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+		}
+		r.assignOutputs(plan, outputs)
+	}
+	r.clearWriteOnly(plan)
+	resp.Diagnostics.Append(r.setModel(ctx, &resp.State, plan)...)
+}
+
+func (r *serviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state, diags := getModel(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil || state.str("id").IsNull() || state.str("id").IsUnknown() {
+		return
+	}
+	ctx = r.withPolling(ctx)
+
+	if err := resources_core.DeleteResource(ctx, r.client, state.str("id").ValueString(), state.str("delete_mode").ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+}
+
+func (r *serviceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	r.client = client
+}
@@ -0,0 +1,243 @@
+package resources_runtime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/resources_core"
+	"terraform-provider-nubes/internal/servicespec"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const pendingOperationDescription = "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh."
+
+// Schema is the resource schema of the service, attribute for attribute the
+// one tools/gen renders.
+func (svc *Service) Schema() schema.Schema {
+	attrs := map[string]schema.Attribute{
+		"id":            schema.StringAttribute{Computed: true},
+		"resource_name": schema.StringAttribute{Required: true},
+	}
+	for _, p := range svc.AllParams {
+		attrs[servicespec.AttrName(p)] = paramAttribute(p)
+		if p.WriteOnly {
+			attrs[servicespec.AttrName(p)+"_version"] = schema.Int64Attribute{Optional: true}
+		}
+	}
+	for _, o := range svc.Outputs {
+		switch servicespec.CanonicalType(o.Type) {
+		case "bool":
+			attrs[o.Name] = schema.BoolAttribute{Computed: true, Sensitive: o.Sensitive,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()}}
+		case "int64":
+			attrs[o.Name] = schema.Int64Attribute{Computed: true, Sensitive: o.Sensitive,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()}}
+		default:
+			attrs[o.Name] = schema.StringAttribute{Computed: true, Sensitive: o.Sensitive,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}}
+		}
+	}
+	deleteMode := svc.DeleteMode
+	if deleteMode == "" {
+		deleteMode = "state_only"
+	}
+	attrs["pending_operation"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: pendingOperationDescription,
+	}
+	attrs["delete_mode"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(deleteMode),
+	}
+	attrs["resume_if_exists"] = schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(svc.ResumeIfExists),
+	}
+	return schema.Schema{
+		Version:    svc.SchemaVersion,
+		Attributes: attrs,
+	}
+}
+
+// attrTypes are the types of the state object.
+func (svc *Service) attrTypes() map[string]attr.Type {
+	out := map[string]attr.Type{
+		"id":                basetypeOf("string"),
+		"resource_name":     basetypeOf("string"),
+		"pending_operation": basetypeOf("string"),
+		"delete_mode":       basetypeOf("string"),
+		"resume_if_exists":  basetypeOf("bool"),
+	}
+	for _, p := range svc.AllParams {
+		out[servicespec.AttrName(p)] = basetypeOf(servicespec.CanonicalType(p.Type))
+		if p.WriteOnly {
+			out[servicespec.AttrName(p)+"_version"] = basetypeOf("int64")
+		}
+	}
+	for _, o := range svc.Outputs {
+		out[o.Name] = basetypeOf(servicespec.CanonicalType(o.Type))
+	}
+	return out
+}
+
+func basetypeOf(typ string) attr.Type {
+	switch typ {
+	case "bool":
+		return types.BoolType
+	case "int64":
+		return types.Int64Type
+	default:
+		return types.StringType
+	}
+}
+
+func paramAttribute(p Param) schema.Attribute {
+	description := attrDescription(p)
+	plain := strings.ReplaceAll(description, "`", "")
+	required := p.Required
+	optional := !p.Required
+	def, _ := parseDefault(p)
+
+	switch servicespec.CanonicalType(p.Type) {
+	case "bool":
+		a := schema.BoolAttribute{Required: required, Optional: optional, Sensitive: p.Sensitive, WriteOnly: p.WriteOnly,
+			Description: plain, MarkdownDescription: description}
+		if hasSchemaDefault(p) {
+			a.Computed = true
+			a.Default = booldefault.StaticBool(def.(bool))
+		}
+		return a
+	case "int64":
+		a := schema.Int64Attribute{Required: required, Optional: optional, Sensitive: p.Sensitive, WriteOnly: p.WriteOnly,
+			Description: plain, MarkdownDescription: description, Validators: int64Validators(p)}
+		if hasSchemaDefault(p) {
+			a.Computed = true
+			a.Default = int64default.StaticInt64(def.(int64))
+		}
+		return a
+	default:
+		a := schema.StringAttribute{Required: required, Optional: optional, Sensitive: p.Sensitive, WriteOnly: p.WriteOnly,
+			Description: plain, MarkdownDescription: description, Validators: stringValidators(p)}
+		if hasSchemaDefault(p) {
+			a.Computed = true
+			a.Default = stringdefault.StaticString(def.(string))
+		}
+		return a
+	}
+}
+
+// hasSchemaDefault reports whether the attribute gets a static schema default.
+// Write-only attributes cannot be computed, so their defaults are left to the API.
+func hasSchemaDefault(p Param) bool {
+	return p.Default != "" && !p.Required && !p.WriteOnly
+}
+
+// parseDefault reads the default as tools/gen writes it into Go source: a
+// bool literal, an integer literal or a string.
+func parseDefault(p Param) (interface{}, error) {
+	switch servicespec.CanonicalType(p.Type) {
+	case "bool":
+		switch strings.ToLower(p.Default) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("not a bool")
+	case "int64":
+		return strconv.ParseInt(p.Default, 0, 64)
+	default:
+		return p.Default, nil
+	}
+}
+
+func int64Validators(p Param) []validator.Int64 {
+	var out []validator.Int64
+	if len(p.Enum) > 0 {
+		values := make([]int64, 0, len(p.Enum))
+		for _, v := range p.Enum {
+			n, _ := strconv.ParseInt(v, 10, 64)
+			values = append(values, n)
+		}
+		out = append(out, int64validator.OneOf(values...))
+	}
+	switch {
+	case p.Min != nil && p.Max != nil:
+		out = append(out, int64validator.Between(*p.Min, *p.Max))
+	case p.Min != nil:
+		out = append(out, int64validator.AtLeast(*p.Min))
+	case p.Max != nil:
+		out = append(out, int64validator.AtMost(*p.Max))
+	}
+	return out
+}
+
+func stringValidators(p Param) []validator.String {
+	var out []validator.String
+	if len(p.Enum) > 0 {
+		out = append(out, stringvalidator.OneOf(p.Enum...))
+	}
+	if p.Pattern != "" {
+		out = append(out, stringvalidator.RegexMatches(regexp.MustCompile(p.Pattern), "must match "+p.Pattern))
+	}
+	switch p.Format {
+	case "cron":
+		out = append(out, resources_core.CronValidator())
+	case "uuid":
+		out = append(out, resources_core.UUIDValidator())
+	case "cidr":
+		out = append(out, resources_core.CIDRValidator())
+	case "ip":
+		out = append(out, resources_core.IPValidator())
+	}
+	return out
+}
+
+// attrDescription is the API label and description of the param, then the
+// reference target if any.
+func attrDescription(p Param) string {
+	var parts []string
+	label := strings.TrimSpace(p.Label)
+	descr := strings.TrimSpace(p.Description)
+	if label != "" {
+		parts = append(parts, sentence(label))
+	}
+	if descr != "" && descr != label {
+		parts = append(parts, sentence(descr))
+	}
+	if p.RefServiceID > 0 {
+		parts = append(parts, refDescription(p))
+	}
+	return strings.Join(parts, " ")
+}
+
+func refDescription(p Param) string {
+	if p.RefTarget != "" {
+		return fmt.Sprintf("Reference to a `nubes_%s` instance (service %d): its id (UUID) or resource_name.", p.RefTarget, p.RefServiceID)
+	}
+	return fmt.Sprintf("Reference to an instance of service %d: its id (UUID) or resource_name.", p.RefServiceID)
+}
+
+func sentence(s string) string {
+	if strings.HasSuffix(s, ".") || strings.HasSuffix(s, "!") || strings.HasSuffix(s, "?") {
+		return s
+	}
+	return s + "."
+}
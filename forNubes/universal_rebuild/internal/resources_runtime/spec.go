@@ -0,0 +1,127 @@
+package resources_runtime
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// The service YAML model and its normalisation are shared with tools/gen
+// (internal/servicespec); the comparison test checks that both produce the
+// same schema and requests.
+
+type (
+	Param  = servicespec.Param
+	Output = servicespec.Output
+)
+
+// Service is one resource type built from a service YAML.
+type Service struct {
+	servicespec.Resource
+
+	// ParamRefs maps param IDs to the service they reference.
+	ParamRefs  map[int]int
+	PollPolicy *core.PollPolicy
+
+	SchemaVersion int64
+	Upgraders     []Upgrader
+}
+
+// Upgrader moves state from an older schema version to the current one.
+type Upgrader struct {
+	FromVersion int64
+	Steps       []resources_core.StateMigration
+}
+
+// Load builds the services of every non-composite YAML in services,
+// applying the overlays and reading the schema history lock files
+// kept by tools/gen. Services are sorted by name.
+func Load(services fs.FS, history fs.FS, overrides fs.FS) ([]*Service, error) {
+	overlays, err := servicespec.LoadOverlaysFS(overrides)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := servicespec.Load(services, overlays)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Service, 0, len(resources))
+	for _, r := range resources {
+		svc := newService(r)
+		if err := applyHistory(svc, history); err != nil {
+			return nil, err
+		}
+		out = append(out, svc)
+	}
+	return out, nil
+}
+
+func newService(r servicespec.Resource) *Service {
+	svc := &Service{Resource: r}
+	for _, params := range [][]Param{r.CreateParams, r.ModifyParams} {
+		for _, p := range params {
+			if p.RefServiceID > 0 {
+				if svc.ParamRefs == nil {
+					svc.ParamRefs = map[int]int{}
+				}
+				svc.ParamRefs[p.ID] = p.RefServiceID
+			}
+		}
+	}
+	if r.Polling != nil {
+		svc.PollPolicy = &core.PollPolicy{
+			Initial:    r.Polling.Initial,
+			Max:        r.Polling.Max,
+			Multiplier: r.Polling.Multiplier,
+			Timeout:    r.Polling.Timeout,
+		}
+	}
+	return svc
+}
+
+// applyHistory sets the schema version and upgraders from the lock file.
+// The file must describe the current attributes: tools/gen updates it, and
+// a stale one would leave state written by a newer schema without upgraders.
+func applyHistory(svc *Service, history fs.FS) error {
+	file := path.Join("schema_history", svc.Name+".yaml")
+	h, err := servicespec.LoadHistory(history, svc.Name)
+	if err != nil {
+		return err
+	}
+	if h == nil {
+		return fmt.Errorf("%s: not found (run go run ./tools/gen)", file)
+	}
+	if len(h.Versions) == 0 {
+		return fmt.Errorf("%s: no versions", file)
+	}
+	latest := h.Versions[len(h.Versions)-1]
+	if !servicespec.SameAttributes(latest.Attributes, servicespec.StateAttributes(svc.Resource)) {
+		return fmt.Errorf("%s is out of date with resources_yaml; run go run ./tools/gen", file)
+	}
+
+	svc.SchemaVersion = latest.Version
+	for i := 0; i < len(h.Versions)-1; i++ {
+		up := Upgrader{FromVersion: h.Versions[i].Version}
+		for _, v := range h.Versions[i+1:] {
+			var step resources_core.StateMigration
+			if m := v.Migration; m != nil {
+				if len(m.Rename) > 0 {
+					step.Rename = m.Rename
+				}
+				if len(m.Drop) > 0 {
+					step.Drop = m.Drop
+				}
+				if len(m.Convert) > 0 {
+					step.Convert = m.Convert
+				}
+			}
+			up.Steps = append(up.Steps, step)
+		}
+		svc.Upgraders = append(svc.Upgraders, up)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package servicespec
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema history (lock file) per resource: schema_history/<name>.yaml.
+//
+// Every schema version records the attributes it stored in state. tools/gen
+// appends a version with the migration from the previous one when the YAML
+// changes incompatibly (an attribute disappears, is renamed or changes type);
+// compatible changes (new attributes) only refresh the latest version.
+
+type SchemaHistory struct {
+	Name     string          `yaml:"name"`
+	Versions []SchemaVersion `yaml:"versions"`
+}
+
+type SchemaVersion struct {
+	Version    int64             `yaml:"version"`
+	Migration  *HistoryMigration `yaml:"migration,omitempty"`
+	Attributes []HistoryAttr     `yaml:"attributes"`
+}
+
+// HistoryMigration is the step from the previous version to this one.
+type HistoryMigration struct {
+	Rename  map[string]string `yaml:"rename,omitempty"`
+	Drop    []string          `yaml:"drop,omitempty"`
+	Convert map[string]string `yaml:"convert,omitempty"`
+}
+
+// HistoryAttr identifies a stored attribute by a stable key (the API code for
+// params) so renames can be told apart from drop+add.
+type HistoryAttr struct {
+	Key  string `yaml:"key"`
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// LoadHistory reads <name>.yaml from the schema_history directory fsys. A
+// missing file yields nil.
+func LoadHistory(fsys fs.FS, name string) (*SchemaHistory, error) {
+	file := name + ".yaml"
+	b, err := fs.ReadFile(fsys, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h SchemaHistory
+	if err := yaml.Unmarshal(b, &h); err != nil {
+		return nil, fmt.Errorf("schema_history/%s: %w", file, err)
+	}
+	return &h, nil
+}
+
+// StateAttributes lists the service-specific attributes stored in state.
+// Fixed attributes (id, resource_name, delete_mode, resume_if_exists) never
+// change and are not tracked.
+func StateAttributes(r Resource) []HistoryAttr {
+	var attrs []HistoryAttr
+	for _, p := range r.AllParams {
+		attrs = append(attrs, HistoryAttr{Key: p.Code, Name: AttrName(p), Type: CanonicalType(p.Type)})
+		if p.WriteOnly {
+			attrs = append(attrs, HistoryAttr{Key: p.Code + ".version", Name: AttrName(p) + "_version", Type: "int64"})
+		}
+	}
+	for _, o := range r.Outputs {
+		attrs = append(attrs, HistoryAttr{Key: "output:" + o.Name, Name: o.Name, Type: CanonicalType(o.Type)})
+	}
+	return attrs
+}
+
+// SameAttributes reports whether a and b list the same attributes in order.
+func SameAttributes(a []HistoryAttr, b []HistoryAttr) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package servicespec
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CanonicalType maps a YAML type to the schema type: bool, int64 or string.
+func CanonicalType(t string) string {
+	switch strings.ToLower(t) {
+	case "bool":
+		return "bool"
+	case "int", "int64", "number":
+		return "int64"
+	default:
+		return "string"
+	}
+}
+
+// AttrName returns the Terraform attribute name for a param: the YAML tf_name
+// override when set, otherwise the snake_case form of the API code.
+func AttrName(p Param) string {
+	if name := strings.TrimSpace(p.TFName); name != "" {
+		return name
+	}
+	return ToSnake(p.Code)
+}
+
+// ToSnake converts an API param code to a Terraform attribute name. Runs of
+// capitals are treated as one acronym word: resourceCPU -> resource_cpu,
+// allowNoSSL -> allow_no_ssl, ext_BACKUP_SCHEDULE -> ext_backup_schedule.
+func ToSnake(s string) string {
+	var words []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' }) {
+		words = append(words, splitWords(part)...)
+	}
+	return strings.ToLower(strings.Join(words, "_"))
+}
+
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := false
+		switch {
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// camelCase / s3Uid boundary
+			boundary = true
+		case unicode.IsUpper(cur) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// end of an acronym: SSLCert -> SSL, Cert
+			boundary = true
+		}
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// CheckAttrNames rejects params and outputs that map to the same attribute or
+// to one the resource reserves.
+func CheckAttrNames(params []Param, outputs []Output) error {
+	seen := map[string]string{
+		"id":                "",
+		"resource_name":     "",
+		"delete_mode":       "",
+		"resume_if_exists":  "",
+		"pending_operation": "",
+	}
+	for _, p := range params {
+		names := []string{AttrName(p)}
+		if p.WriteOnly {
+			names = append(names, AttrName(p)+"_version")
+		}
+		for _, name := range names {
+			if other, ok := seen[name]; ok {
+				if other == "" {
+					return fmt.Errorf("param %s: attribute %q is reserved", p.Code, name)
+				}
+				return fmt.Errorf("params %s and %s both map to attribute %q; set tf_name on one of them", other, p.Code, name)
+			}
+			seen[name] = p.Code
+		}
+	}
+	for _, o := range outputs {
+		if other, ok := seen[o.Name]; ok {
+			if other == "" {
+				return fmt.Errorf("output %s: attribute is reserved", o.Name)
+			}
+			return fmt.Errorf("output %s clashes with param %s", o.Name, other)
+		}
+		seen[o.Name] = "output " + o.Name
+	}
+	return nil
+}
+
+// CheckOutput rejects an output without a usable name, path or source.
+func CheckOutput(o Output) error {
+	if o.Name == "" || o.Name != ToSnake(o.Name) {
+		return fmt.Errorf("output %q: name must be a snake_case attribute name", o.Name)
+	}
+	if o.Path == "" {
+		return fmt.Errorf("output %s: path is required", o.Name)
+	}
+	switch o.From {
+	case "", "instance", "operation":
+	default:
+		return fmt.Errorf("output %s: from must be instance or operation, got %q", o.Name, o.From)
+	}
+	return nil
+}
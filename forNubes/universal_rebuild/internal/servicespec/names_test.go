@@ -0,0 +1,105 @@
+package servicespec
+
+import (
+	"strings"
+	"testing"
+)
+
+// The names are state keys: a change here breaks existing state unless the
+// schema history records it as a rename.
+func TestToSnake(t *testing.T) {
+	for _, tc := range []struct {
+		code string
+		want string
+	}{
+		{"resourceCPU", "resource_cpu"},
+		{"allowNoSSL", "allow_no_ssl"},
+		{"ext_BACKUP_SCHEDULE", "ext_backup_schedule"},
+		{"SSLCert", "ssl_cert"},
+		{"s3UserUid", "s3_user_uid"},
+		{"resourceRealm", "resource_realm"},
+		{"vmCpu", "vm_cpu"},
+		{"durationMs", "duration_ms"},
+		{"ipv4Address", "ipv4_address"},
+		{"CPU", "cpu"},
+		{"name", "name"},
+		{"display-name", "display_name"},
+		{"already_snake", "already_snake"},
+		{"", ""},
+	} {
+		if got := ToSnake(tc.code); got != tc.want {
+			t.Errorf("ToSnake(%q) = %q, want %q", tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestAttrName(t *testing.T) {
+	for _, tc := range []struct {
+		p    Param
+		want string
+	}{
+		{Param{Code: "resourceCPU"}, "resource_cpu"},
+		{Param{Code: "resourceCPU", TFName: "cpu"}, "cpu"},
+		{Param{Code: "resourceCPU", TFName: "  "}, "resource_cpu"},
+	} {
+		if got := AttrName(tc.p); got != tc.want {
+			t.Errorf("AttrName(%+v) = %q, want %q", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestCheckAttrNames(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		params  []Param
+		outputs []Output
+		wantErr string
+	}{
+		{
+			name:   "distinct",
+			params: []Param{{Code: "resourceCPU"}, {Code: "resourceRAM"}},
+		},
+		{
+			name:    "codes mapping to one name",
+			params:  []Param{{Code: "resourceCPU"}, {Code: "resource_cpu"}},
+			wantErr: `params resourceCPU and resource_cpu both map to attribute "resource_cpu"; set tf_name on one of them`,
+		},
+		{
+			name:   "tf_name resolves a clash",
+			params: []Param{{Code: "resourceCPU"}, {Code: "resource_cpu", TFName: "cpu_count"}},
+		},
+		{
+			name:    "reserved name",
+			params:  []Param{{Code: "resourceName"}},
+			wantErr: `param resourceName: attribute "resource_name" is reserved`,
+		},
+		{
+			name:    "write-only version attribute",
+			params:  []Param{{Code: "password", WriteOnly: true}, {Code: "passwordVersion"}},
+			wantErr: `params password and passwordVersion both map to attribute "password_version"`,
+		},
+		{
+			name:    "output clashes with a param",
+			params:  []Param{{Code: "host"}},
+			outputs: []Output{{Name: "host"}},
+			wantErr: "output host clashes with param host",
+		},
+		{
+			name:    "reserved output",
+			outputs: []Output{{Name: "id"}},
+			wantErr: "output id: attribute is reserved",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckAttrNames(tc.params, tc.outputs)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.wantErr != "" && err == nil:
+				t.Fatalf("no error, want %q", tc.wantErr)
+			case tc.wantErr != "" && !strings.Contains(err.Error(), tc.wantErr):
+				t.Fatalf("error %q, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
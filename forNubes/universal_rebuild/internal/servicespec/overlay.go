@@ -0,0 +1,215 @@
+package servicespec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overlays are resources_overrides/<name>.yaml: hand customisation of a
+// service merged on top of the fetched resources_yaml file, so that it
+// survives both service_params_gen and tools/gen.
+
+// Overlay is the override file of one service.
+type Overlay struct {
+	// Path is the file the overlay was read from, for error messages.
+	Path string `yaml:"-"`
+	// Params maps an API param code (matched case-insensitively in create
+	// and modify) to its overrides.
+	Params map[string]OverlayParam `yaml:"params"`
+}
+
+// OverlayParam lists the overrides of one param; zero values leave it
+// unchanged.
+type OverlayParam struct {
+	// Rename sets the Terraform attribute name (like tf_name).
+	Rename string `yaml:"rename"`
+	// Hide removes the attribute; a create param is then always sent with
+	// its default.
+	Hide bool `yaml:"hide"`
+	// Default replaces the default and makes the attribute optional.
+	Default string `yaml:"default"`
+	// Sensitive marks the attribute sensitive.
+	Sensitive bool `yaml:"sensitive"`
+	// Type fixes a wrong catalog type: string, int64 or bool.
+	Type string `yaml:"type"`
+
+	// Validation keys, as in resources_yaml; each one set replaces the
+	// param's own. The catalog does not expose constraints, so they are
+	// maintained here.
+	Enum    []string `yaml:"enum"`
+	Min     *int64   `yaml:"min"`
+	Max     *int64   `yaml:"max"`
+	Pattern string   `yaml:"pattern"`
+	Format  string   `yaml:"format"`
+}
+
+// LoadOverlays reads every <name>.yaml in dir, keyed by name. A missing dir
+// means no overlays.
+func LoadOverlays(dir string) (map[string]*Overlay, error) {
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return map[string]*Overlay{}, nil
+	}
+	return loadOverlays(os.DirFS(dir), dir)
+}
+
+// LoadOverlaysFS is LoadOverlays for the root of fsys (the embedded
+// resources_overrides).
+func LoadOverlaysFS(fsys fs.FS) (map[string]*Overlay, error) {
+	return loadOverlays(fsys, ".")
+}
+
+func loadOverlays(fsys fs.FS, dir string) (map[string]*Overlay, error) {
+	overlays := map[string]*Overlay{}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		o := &Overlay{Path: path}
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		if err := dec.Decode(o); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for code, p := range o.Params {
+			switch p.Type {
+			case "", "string", "int64", "bool":
+			default:
+				return nil, fmt.Errorf("%s: param %s: type must be string, int64 or bool, got %q", path, code, p.Type)
+			}
+			if p.Hide && (p.Rename != "" || p.Sensitive) {
+				return nil, fmt.Errorf("%s: param %s: a hidden param cannot be renamed or sensitive", path, code)
+			}
+		}
+		overlays[strings.TrimSuffix(e.Name(), ".yaml")] = o
+	}
+	return overlays, nil
+}
+
+// Param returns the overrides of the param with code.
+func (o *Overlay) Param(code string) (OverlayParam, bool) {
+	if o == nil {
+		return OverlayParam{}, false
+	}
+	for c, p := range o.Params {
+		if strings.EqualFold(c, strings.TrimSpace(code)) {
+			return p, true
+		}
+	}
+	return OverlayParam{}, false
+}
+
+// Check reports overrides of codes that are not among the service's params,
+// so that a typo or a param removed from the catalog does not go unnoticed.
+func (o *Overlay) Check(codes []string) error {
+	if o == nil {
+		return nil
+	}
+	var unknown []string
+	for c := range o.Params {
+		found := false
+		for _, code := range codes {
+			found = found || strings.EqualFold(c, strings.TrimSpace(code))
+		}
+		if !found {
+			unknown = append(unknown, c)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s: no such param: %s", o.Path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// unusedOverlays returns the overlays whose name is not in names.
+func unusedOverlays(overlays map[string]*Overlay, names []string) []string {
+	var unused []string
+	for name := range overlays {
+		found := false
+		for _, n := range names {
+			found = found || n == name
+		}
+		if !found {
+			unused = append(unused, overlays[name].Path)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+// ApplyOverlay merges the overrides of o into the create and modify params
+// of svc. An override of a code the service does not have is an error.
+func ApplyOverlay(svc *Service, o *Overlay) error {
+	if o == nil {
+		return nil
+	}
+	var codes []string
+	apply := func(params []Param) {
+		for i := range params {
+			p := &params[i]
+			codes = append(codes, p.Code)
+			ov, ok := o.Param(p.Code)
+			if !ok {
+				continue
+			}
+			if ov.Rename != "" {
+				p.TFName = ov.Rename
+			}
+			if typ := CanonicalType(ov.Type); ov.Type != "" && typ != CanonicalType(p.Type) {
+				p.Type = ov.Type
+				// Validation keys of the old type no longer apply.
+				if typ != "int64" {
+					p.Min, p.Max = nil, nil
+				}
+				if typ != "string" {
+					p.Pattern, p.Format, p.RefServiceID = "", "", 0
+				}
+				if typ == "bool" {
+					p.Enum = nil
+				}
+			}
+			if len(ov.Enum) > 0 {
+				p.Enum = ov.Enum
+			}
+			if ov.Min != nil {
+				p.Min = ov.Min
+			}
+			if ov.Max != nil {
+				p.Max = ov.Max
+			}
+			if ov.Pattern != "" {
+				p.Pattern = ov.Pattern
+			}
+			if ov.Format != "" {
+				p.Format = ov.Format
+			}
+			if ov.Default != "" {
+				p.Default = ov.Default
+				p.Required = false
+			}
+			p.Sensitive = p.Sensitive || ov.Sensitive
+			p.Hidden = ov.Hide
+		}
+	}
+	apply(svc.Create.Params)
+	apply(svc.Modify.Params)
+	return o.Check(codes)
+}
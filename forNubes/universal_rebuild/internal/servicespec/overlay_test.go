@@ -0,0 +1,156 @@
+package servicespec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func int64p(v int64) *int64 { return &v }
+
+func TestApplyOverlay(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		param   Param
+		overlay OverlayParam
+		want    Param
+	}{
+		{
+			name:    "constraints are added",
+			param:   Param{Code: "diskSize", Type: "int64"},
+			overlay: OverlayParam{Min: int64p(10), Max: int64p(1000)},
+			want:    Param{Code: "diskSize", Type: "int64", Min: int64p(10), Max: int64p(1000)},
+		},
+		{
+			name:    "each key set replaces the param's own",
+			param:   Param{Code: "diskSize", Type: "int64", Min: int64p(1), Max: int64p(100)},
+			overlay: OverlayParam{Max: int64p(500)},
+			want:    Param{Code: "diskSize", Type: "int64", Min: int64p(1), Max: int64p(500)},
+		},
+		{
+			name:    "enum replaces, not appends",
+			param:   Param{Code: "version", Type: "string", Enum: []string{"13", "14"}},
+			overlay: OverlayParam{Enum: []string{"15", "16"}},
+			want:    Param{Code: "version", Type: "string", Enum: []string{"15", "16"}},
+		},
+		{
+			name:    "string keys",
+			param:   Param{Code: "schedule", Type: "string", Pattern: ".*"},
+			overlay: OverlayParam{Pattern: "^[a-z]+$", Format: "cron"},
+			want:    Param{Code: "schedule", Type: "string", Pattern: "^[a-z]+$", Format: "cron"},
+		},
+		{
+			name:    "type change to bool clears the keys of the old type",
+			param:   Param{Code: "ssl", Type: "string", Enum: []string{"true", "false"}, Pattern: "^(true|false)$", Format: "uuid", RefServiceID: 7},
+			overlay: OverlayParam{Type: "bool"},
+			want:    Param{Code: "ssl", Type: "bool"},
+		},
+		{
+			name:    "type change to int64 keeps enum, drops string keys",
+			param:   Param{Code: "port", Type: "string", Enum: []string{"80", "443"}, Pattern: "^[0-9]+$"},
+			overlay: OverlayParam{Type: "int64", Min: int64p(1)},
+			want:    Param{Code: "port", Type: "int64", Enum: []string{"80", "443"}, Min: int64p(1)},
+		},
+		{
+			name:    "type change to string drops min and max",
+			param:   Param{Code: "size", Type: "int64", Min: int64p(1), Max: int64p(9)},
+			overlay: OverlayParam{Type: "string", Pattern: "^[0-9]+G$"},
+			want:    Param{Code: "size", Type: "string", Pattern: "^[0-9]+G$"},
+		},
+		{
+			name:    "same canonical type keeps keys",
+			param:   Param{Code: "size", Type: "number", Min: int64p(1)},
+			overlay: OverlayParam{Type: "int64"},
+			want:    Param{Code: "size", Type: "number", Min: int64p(1)},
+		},
+		{
+			name:    "default makes the param optional",
+			param:   Param{Code: "replicas", Type: "int64", Required: true},
+			overlay: OverlayParam{Default: "3"},
+			want:    Param{Code: "replicas", Type: "int64", Default: "3"},
+		},
+		{
+			name:    "rename and sensitive",
+			param:   Param{Code: "adminPass", Type: "string"},
+			overlay: OverlayParam{Rename: "admin_password", Sensitive: true},
+			want:    Param{Code: "adminPass", Type: "string", Sensitive: true, TFName: "admin_password"},
+		},
+		{
+			name:    "sensitive from the catalog is kept",
+			param:   Param{Code: "token", Type: "string", Sensitive: true},
+			overlay: OverlayParam{Rename: "api_token"},
+			want:    Param{Code: "token", Type: "string", Sensitive: true, TFName: "api_token"},
+		},
+		{
+			name:    "hide",
+			param:   Param{Code: "internalFlag", Type: "bool", Default: "false"},
+			overlay: OverlayParam{Hide: true},
+			want:    Param{Code: "internalFlag", Type: "bool", Default: "false", Hidden: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &Service{Create: Operation{Params: []Param{tc.param}}}
+			o := &Overlay{Params: map[string]OverlayParam{strings.ToUpper(tc.param.Code): tc.overlay}}
+			if err := ApplyOverlay(svc, o); err != nil {
+				t.Fatal(err)
+			}
+			if got := svc.Create.Params[0]; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v\nwant %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyOverlayModifyAndUnknownCodes(t *testing.T) {
+	svc := &Service{
+		Create: Operation{Params: []Param{{Code: "name", Type: "string"}}},
+		Modify: Operation{Params: []Param{{Code: "diskSize", Type: "int64"}}},
+	}
+	o := &Overlay{Path: "resources_overrides/x.yaml", Params: map[string]OverlayParam{
+		"diskSize":  {Min: int64p(10)},
+		"diskSise":  {Min: int64p(10)},
+		"removedOp": {Hide: true},
+	}}
+	err := ApplyOverlay(svc, o)
+	if err == nil || err.Error() != "resources_overrides/x.yaml: no such param: diskSise, removedOp" {
+		t.Errorf("got error %v", err)
+	}
+	if p := svc.Modify.Params[0]; p.Min == nil || *p.Min != 10 {
+		t.Errorf("modify param not overridden: %+v", p)
+	}
+	if err := ApplyOverlay(svc, nil); err != nil {
+		t.Errorf("nil overlay: %v", err)
+	}
+}
+
+func TestLoadOverlaysFS(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{name: "valid", file: "params:\n  diskSize:\n    min: 10\n    max: 100\n"},
+		{name: "empty", file: ""},
+		{name: "unknown key", file: "params:\n  diskSize:\n    minimum: 10\n", wantErr: "field minimum not found"},
+		{name: "bad type", file: "params:\n  diskSize:\n    type: number\n", wantErr: `param diskSize: type must be string, int64 or bool, got "number"`},
+		{name: "hidden and renamed", file: "params:\n  diskSize:\n    hide: true\n    rename: disk\n", wantErr: "param diskSize: a hidden param cannot be renamed or sensitive"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			overlays, err := LoadOverlaysFS(fstest.MapFS{
+				"postgres.yaml": {Data: []byte(tc.file)},
+				"README.md":     {Data: []byte("not an overlay")},
+			})
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("error %v, want %q", err, tc.wantErr)
+			case tc.wantErr == "":
+				if len(overlays) != 1 || overlays["postgres"] == nil {
+					t.Fatalf("got overlays %v, want postgres only", overlays)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Package servicespec is the model of the resources_yaml service files and
+// the normalisation every consumer shares: overlays, the merge of create and
+// modify params into attributes, attribute names, validation keys and the
+// schema history lock files. Used by tools/gen, tools/examplegen and
+// internal/resources_runtime, so that the generated and the runtime
+// resources are built from the same rules.
+package servicespec
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KindComposite marks a composite resource file; Load skips them.
+const KindComposite = "composite"
+
+// Param is a create or modify param of a service YAML. The omitempty tags
+// keep the files service_params_gen writes free of unset keys.
+type Param struct {
+	ID        int    `yaml:"id"`
+	Code      string `yaml:"code"`
+	Type      string `yaml:"type"`
+	Required  bool   `yaml:"required"`
+	Default   string `yaml:"default,omitempty"`
+	Sensitive bool   `yaml:"sensitive,omitempty"`
+	WriteOnly bool   `yaml:"write_only,omitempty"`
+	TFName    string `yaml:"tf_name,omitempty"`
+
+	// Label and Description come from the API (cfsParams label/descr) and
+	// become the attribute description.
+	Label       string `yaml:"label,omitempty"`
+	Description string `yaml:"description,omitempty"`
+
+	// Validation
+	Enum    []string `yaml:"enum,omitempty"`
+	Min     *int64   `yaml:"min,omitempty"`
+	Max     *int64   `yaml:"max,omitempty"`
+	Pattern string   `yaml:"pattern,omitempty"`
+	Format  string   `yaml:"format,omitempty"`
+
+	// RefServiceID is the service whose instance this param points at (API refSvcId).
+	RefServiceID int `yaml:"ref_service_id,omitempty"`
+	// RefTarget is the resource name of RefServiceID, filled by Load.
+	RefTarget string `yaml:"-"`
+	// Hidden is set by an overlay (resources_overrides); the param is not an attribute.
+	Hidden bool `yaml:"-"`
+}
+
+// Output is a computed attribute read from the instance details or from the
+// create operation results.
+type Output struct {
+	Name      string `yaml:"name"`
+	From      string `yaml:"from,omitempty"`
+	Path      string `yaml:"path"`
+	Type      string `yaml:"type,omitempty"`
+	Sensitive bool   `yaml:"sensitive,omitempty"`
+}
+
+// PollSpec is the optional `polling` section of a service YAML. Durations use
+// Go syntax (2s, 1m30s); omitted fields keep core.DefaultPollPolicy.
+type PollSpec struct {
+	Initial    string  `yaml:"initial,omitempty"`
+	Max        string  `yaml:"max,omitempty"`
+	Multiplier float64 `yaml:"multiplier,omitempty"`
+	Timeout    string  `yaml:"timeout,omitempty"`
+}
+
+// Service is a service YAML file as written; service_params_gen writes it
+// back in the same layout.
+type Service struct {
+	Kind      string    `yaml:"kind,omitempty"`
+	Name      string    `yaml:"name"`
+	ServiceID int       `yaml:"service_id"`
+	Create    Operation `yaml:"create"`
+	Modify    Operation `yaml:"modify"`
+	Lifecycle Lifecycle `yaml:"lifecycle"`
+
+	// Operations lists the service's operations in the catalog, so that
+	// service_params_gen diff can report new ones.
+	Operations []string `yaml:"operations,omitempty"`
+
+	Outputs []Output  `yaml:"outputs,omitempty"`
+	Polling *PollSpec `yaml:"polling,omitempty"`
+}
+
+// Operation is the create or modify section of a service YAML.
+type Operation struct {
+	Params []Param `yaml:"params"`
+}
+
+type Lifecycle struct {
+	DeleteModeDefault     string `yaml:"delete_mode_default"`
+	ResumeIfExistsDefault bool   `yaml:"resume_if_exists_default"`
+}
+
+// Resource is a service with its overlay applied, normalised into the
+// attributes of its Terraform resource.
+type Resource struct {
+	Name      string
+	ServiceID int
+
+	// CreateParams and ModifyParams are the visible params of each
+	// operation; a modify param takes code, type and naming from the create
+	// param with the same code.
+	CreateParams []Param
+	ModifyParams []Param
+	// AllParams has one entry per attribute: the create params, then the
+	// modify-only params (never required).
+	AllParams []Param
+	// FixedParams are create params hidden by an overlay, always sent with
+	// their default.
+	FixedParams []FixedParam
+	// RefParams are the attributes that reference another service.
+	RefParams []Param
+	// SensitiveParamIDs lists create/modify param IDs whose values must be
+	// redacted from client logs (sensitive and write-only params).
+	SensitiveParamIDs []int
+
+	DeleteMode     string
+	ResumeIfExists bool
+	Outputs        []Output
+	Polling        *Poll
+}
+
+// FixedParam is a hidden create param and the value it is sent with.
+type FixedParam struct {
+	ID    int
+	Value string
+}
+
+// Poll is a parsed PollSpec; zero fields keep the client defaults.
+type Poll struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Timeout    time.Duration
+}
+
+// Load builds the resources of every non-composite YAML in services, with
+// overlays applied, sorted by name. An overlay without a service is an error.
+func Load(services fs.FS, overlays map[string]*Overlay) ([]Resource, error) {
+	var out []Resource
+	err := fs.WalkDir(services, ".", func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
+			return nil
+		}
+		b, err := fs.ReadFile(services, file)
+		if err != nil {
+			return err
+		}
+		var svc Service
+		if err := yaml.Unmarshal(b, &svc); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if svc.Kind == KindComposite {
+			return nil
+		}
+		if err := ApplyOverlay(&svc, overlays[svc.Name]); err != nil {
+			return err
+		}
+		r, err := Build(svc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		out = append(out, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(out))
+	for i, r := range out {
+		names[i] = r.Name
+	}
+	if unused := unusedOverlays(overlays, names); len(unused) > 0 {
+		return nil, fmt.Errorf("overlays without a service: %s", strings.Join(unused, ", "))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	resolveRefTargets(out)
+	return out, nil
+}
+
+// Build normalises a service (with its overlay already applied) and rejects
+// params and outputs that cannot become a valid schema.
+func Build(svc Service) (Resource, error) {
+	r := Resource{
+		Name:           svc.Name,
+		ServiceID:      svc.ServiceID,
+		DeleteMode:     svc.Lifecycle.DeleteModeDefault,
+		ResumeIfExists: svc.Lifecycle.ResumeIfExistsDefault,
+		Outputs:        svc.Outputs,
+	}
+	for _, p := range svc.Create.Params {
+		switch {
+		case !p.Hidden:
+			r.CreateParams = append(r.CreateParams, p)
+		case p.Default != "":
+			r.FixedParams = append(r.FixedParams, FixedParam{ID: p.ID, Value: p.Default})
+		case p.Required:
+			return r, fmt.Errorf("param %s is hidden but required and has no default", p.Code)
+		}
+	}
+	var modify []Param
+	for _, p := range svc.Modify.Params {
+		if !p.Hidden {
+			modify = append(modify, p)
+		}
+	}
+	r.ModifyParams = normalizeModifyParams(r.CreateParams, modify)
+	r.AllParams = mergeParams(r.CreateParams, r.ModifyParams)
+
+	r.SensitiveParamIDs = SensitiveParamIDs(r.CreateParams, r.ModifyParams)
+	for _, p := range r.AllParams {
+		if p.RefServiceID > 0 {
+			r.RefParams = append(r.RefParams, p)
+		}
+	}
+
+	if svc.Polling != nil {
+		poll, err := svc.Polling.Parse()
+		if err != nil {
+			return r, err
+		}
+		r.Polling = poll
+	}
+	if err := CheckAttrNames(r.AllParams, r.Outputs); err != nil {
+		return r, err
+	}
+	for _, o := range r.Outputs {
+		if err := CheckOutput(o); err != nil {
+			return r, err
+		}
+	}
+	for _, p := range r.AllParams {
+		if err := CheckParam(p); err != nil {
+			return r, err
+		}
+		if p.Default != "" {
+			if err := CheckDefault(p, p.Default); err != nil {
+				return r, fmt.Errorf("param %s: default %w", p.Code, err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// Parse validates the durations and the multiplier.
+func (p PollSpec) Parse() (*Poll, error) {
+	var poll Poll
+	for _, d := range []struct {
+		field string
+		value string
+		dst   *time.Duration
+	}{{"initial", p.Initial, &poll.Initial}, {"max", p.Max, &poll.Max}, {"timeout", p.Timeout, &poll.Timeout}} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("polling.%s: invalid duration %q", d.field, d.value)
+		}
+		*d.dst = v
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return nil, fmt.Errorf("polling.multiplier must be >= 1, got %v", p.Multiplier)
+	}
+	poll.Multiplier = p.Multiplier
+	return &poll, nil
+}
+
+// SensitiveParamIDs lists the IDs of the sensitive and write-only params,
+// whose values are redacted from client logs.
+func SensitiveParamIDs(createParams []Param, modifyParams []Param) []int {
+	var ids []int
+	for _, params := range [][]Param{createParams, modifyParams} {
+		for _, p := range params {
+			if p.Sensitive || p.WriteOnly {
+				ids = append(ids, p.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// OutputFrom is where an output is read from: instance (the default) or
+// operation.
+func OutputFrom(o Output) string {
+	if o.From == "" {
+		return "instance"
+	}
+	return o.From
+}
+
+// normalizeModifyParams drops duplicate codes and makes each modify param
+// follow the create param with the same code: codes match
+// case-insensitively, and the attribute is the create one.
+func normalizeModifyParams(createParams []Param, modifyParams []Param) []Param {
+	if len(modifyParams) == 0 {
+		return nil
+	}
+	createByCode := make(map[string]Param)
+	for _, p := range createParams {
+		if key := paramKey(p); key != "" {
+			createByCode[key] = p
+		}
+	}
+
+	normalized := make([]Param, 0, len(modifyParams))
+	seen := make(map[string]bool, len(modifyParams))
+	for _, p := range modifyParams {
+		key := paramKey(p)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if base, ok := createByCode[key]; ok {
+			p.Code = base.Code
+			p.Type = base.Type
+			p.Sensitive = p.Sensitive || base.Sensitive
+			p.WriteOnly = p.WriteOnly || base.WriteOnly
+			if p.TFName == "" {
+				p.TFName = base.TFName
+			}
+			if p.RefServiceID == 0 {
+				p.RefServiceID = base.RefServiceID
+			}
+		}
+		normalized = append(normalized, p)
+	}
+	return normalized
+}
+
+// mergeParams returns one param per attribute: create params first, then
+// modify-only params, which are optional.
+func mergeParams(createParams []Param, modifyParams []Param) []Param {
+	if len(createParams) == 0 && len(modifyParams) == 0 {
+		return nil
+	}
+	byCode := make(map[string]Param)
+	order := make([]string, 0, len(createParams)+len(modifyParams))
+	for _, p := range createParams {
+		key := paramKey(p)
+		if key == "" {
+			continue
+		}
+		if _, exists := byCode[key]; !exists {
+			order = append(order, key)
+		}
+		byCode[key] = p
+	}
+	for _, p := range modifyParams {
+		key := paramKey(p)
+		if key == "" {
+			continue
+		}
+		if _, exists := byCode[key]; !exists {
+			p.Required = false
+			byCode[key] = p
+			order = append(order, key)
+		}
+	}
+
+	merged := make([]Param, 0, len(order))
+	for _, key := range order {
+		merged = append(merged, byCode[key])
+	}
+	return merged
+}
+
+func paramKey(p Param) string {
+	return strings.ToLower(strings.TrimSpace(p.Code))
+}
+
+// resolveRefTargets names the resource behind each ref_service_id.
+func resolveRefTargets(resources []Resource) {
+	names := make(map[int]string, len(resources))
+	for _, r := range resources {
+		names[r.ServiceID] = r.Name
+	}
+	for _, r := range resources {
+		for _, params := range [][]Param{r.CreateParams, r.ModifyParams, r.AllParams, r.RefParams} {
+			for i := range params {
+				params[i].RefTarget = names[params[i].RefServiceID]
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package servicespec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Supported values of the YAML `format` key.
+var formats = map[string]bool{"cron": true, "uuid": true, "cidr": true, "ip": true}
+
+// CheckParam rejects validation keys that do not fit the param type, so a
+// typo fails generation instead of producing a schema that never validates.
+func CheckParam(p Param) error {
+	typ := CanonicalType(p.Type)
+	if (p.Min != nil || p.Max != nil) && typ != "int64" {
+		return fmt.Errorf("param %s: min/max are only supported for int64 params", p.Code)
+	}
+	if p.Min != nil && p.Max != nil && *p.Min > *p.Max {
+		return fmt.Errorf("param %s: min %d is greater than max %d", p.Code, *p.Min, *p.Max)
+	}
+	if (p.Pattern != "" || p.Format != "") && typ != "string" {
+		return fmt.Errorf("param %s: pattern/format are only supported for string params", p.Code)
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("param %s: invalid pattern: %w", p.Code, err)
+		}
+	}
+	if p.Format != "" && !formats[p.Format] {
+		return fmt.Errorf("param %s: unknown format %q", p.Code, p.Format)
+	}
+	if p.RefServiceID > 0 && typ != "string" {
+		return fmt.Errorf("param %s: ref_service_id is only supported for string params", p.Code)
+	}
+	if len(p.Enum) > 0 {
+		switch typ {
+		case "bool":
+			return fmt.Errorf("param %s: enum is not supported for bool params", p.Code)
+		case "int64":
+			for _, v := range p.Enum {
+				if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+					return fmt.Errorf("param %s: enum value %q is not an int64", p.Code, v)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// CheckDefault rejects defaults the schema default or the API would not
+// accept for the param type ("yes" for a bool, "10G" for an int64).
+func CheckDefault(p Param, value string) error {
+	switch CanonicalType(p.Type) {
+	case "bool":
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not a bool (true or false)", value)
+		}
+	case "int64":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an int64", value)
+		}
+	}
+	if len(p.Enum) > 0 {
+		for _, v := range p.Enum {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of the enum values %s", value, strings.Join(p.Enum, ", "))
+	}
+	return nil
+}
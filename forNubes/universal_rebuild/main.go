@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"time"
 
 	"terraform-provider-nubes/internal/provider"
 
@@ -19,6 +20,13 @@ func main() {
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := provider.ShutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Printf("tracing shutdown: %v", shutdownErr)
+	}
+
 	if err != nil {
 		log.Fatal(err)
 	}
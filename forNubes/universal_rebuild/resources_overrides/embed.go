@@ -0,0 +1,8 @@
+// Package resources_overrides embeds the service overlays for
+// internal/resources_runtime. Only the *.yaml files are overlays.
+package resources_overrides
+
+import "embed"
+
+//go:embed *
+var FS embed.FS
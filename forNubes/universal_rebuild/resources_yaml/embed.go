@@ -0,0 +1,8 @@
+// Package resources_yaml embeds the service YAML files for
+// internal/resources_runtime, which builds resources from them at run time.
+package resources_yaml
+
+import "embed"
+
+//go:embed *.yaml
+var FS embed.FS
@@ -0,0 +1,9 @@
+// Package schema_history embeds the schema history lock files kept by
+// tools/gen, so that internal/resources_runtime serves the same schema
+// versions and state upgraders as the generated resources.
+package schema_history
+
+import "embed"
+
+//go:embed *.yaml
+var FS embed.FS
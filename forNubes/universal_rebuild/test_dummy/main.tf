@@ -45,14 +45,14 @@ resource "nubes_postgres" "test_postgres" {
   s3_uid                        = "s3-111805"
   resource_instances            = 1
   resource_memory               = 512
-  resource_c_p_u                = 500
+  resource_cpu                  = 500
   resource_disk                 = 1
   resource_realm                = "k8s-3.ext.nubes.ru"
   app_version                   = "17"
   json_parameters               = "{ \"log_connections\": \"off\", \"log_disconnections\": \"off\" }"
   enable_pg_pooler_master       = false
   enable_pg_pooler_slave        = false
-  allow_no_s_s_l                = false
+  allow_no_ssl                  = false
   auto_scale                    = false
   auto_scale_percentage         = 10
   auto_scale_tech_window        = 0
@@ -79,7 +79,7 @@ resource "nubes_lucee" "test_lucee" {
   resource_name      = "tf-lucee-20260203-01"
   domain             = "lucee-20260203-01"
   git_path           = "https://github.com/xahys/testlucee"
-  resource_c_p_u     = 300
+  resource_cpu       = 300
   resource_memory    = 512
   resource_realm     = "k8s-3.ext.nubes.ru"
   resource_instances = 1
@@ -91,7 +91,7 @@ resource "nubes_kafka" "test_kafka" {
   resource_name               = "tf-kafka-20260203-01"
   resource_instances          = 1
   resource_memory             = 1000
-  resource_c_p_u              = 1000
+  resource_cpu                = 1000
   resource_disk               = 1
   need_external_address_master = false
   resource_realm              = "k8s-3.ext.nubes.ru"
@@ -102,7 +102,7 @@ resource "nubes_flask" "test_flask" {
   resource_name      = "tf-flask-20260203-01"
   domain             = "flask-20260203-01"
   resource_realm     = "k8s-3.ext.nubes.ru"
-  resource_c_p_u     = 300
+  resource_cpu       = 300
   resource_memory    = 512
   resource_instances = 1
   git_path           = "https://github.com/Foxyhhd/Baldurs-Gate-test.git"
@@ -111,7 +111,7 @@ resource "nubes_flask" "test_flask" {
 
 resource "nubes_redis" "test_redis" {
   resource_name                = "tf-redis-20260203-01"
-  resource_c_p_u               = 500
+  resource_cpu                 = 500
   resource_memory              = 512
   resource_disk                = 10
   resource_instances           = 1
@@ -125,7 +125,7 @@ resource "nubes_mongodb" "test_mongodb" {
   resource_name                = "tf-mongodb-20260203-01"
   resource_instances           = 1
   resource_memory              = 1000
-  resource_c_p_u               = 500
+  resource_cpu                 = 500
   resource_disk                = 10
   resource_realm               = "k8s-3.ext.nubes.ru"
   need_external_address_master = "false"
@@ -134,7 +134,7 @@ resource "nubes_mongodb" "test_mongodb" {
 
 resource "nubes_rabbitmq" "test_rabbitmq" {
   resource_name                = "tf-rabbitmq-20260203-01"
-  resource_c_p_u               = 500
+  resource_cpu                 = 500
   resource_memory              = 512
   resource_disk                = 10
   resource_realm               = "k8s-3.ext.nubes.ru"
@@ -147,7 +147,7 @@ resource "nubes_nodejs" "test_nodejs" {
   resource_name      = "tf-nodejs-20260203-01"
   domain             = "nodejs-20260203-01"
   git_path           = "https://github.com/xahys/testnode.git"
-  resource_c_p_u     = 500
+  resource_cpu       = 500
   resource_memory    = 1024
   resource_instances = 1
   resource_realm     = "k8s-3.ext.nubes.ru"
@@ -158,7 +158,7 @@ resource "nubes_nodejs" "test_nodejs" {
 resource "nubes_pgadmin" "test_pgadmin" {
   resource_name   = "tf-pgadmin-20260203-01"
   domain          = "pgadmin-20260203-01"
-  resource_c_p_u  = 200
+  resource_cpu    = 200
   resource_memory = 256
   resource_disk   = 1
   resource_realm  = "k8s-3.ext.nubes.ru"
@@ -169,7 +169,7 @@ resource "nubes_pgadmin" "test_pgadmin" {
 
 resource "nubes_nodered" "test_nodered" {
   resource_name      = "tf-nodered-20260203-01"
-  resource_c_p_u     = 500
+  resource_cpu       = 500
   resource_memory    = 512
   resource_disk      = 1
   resource_realm     = "k8s-3.ext.nubes.ru"
@@ -180,7 +180,7 @@ resource "nubes_nodered" "test_nodered" {
 
 resource "nubes_gitea" "test_gitea" {
   resource_name      = "tf-gitea-20260203-01"
-  resource_c_p_u     = 500
+  resource_cpu       = 500
   resource_memory    = 1024
   resource_disk      = 10
   resource_instances = 1
@@ -192,7 +192,7 @@ resource "nubes_gitea" "test_gitea" {
 resource "nubes_mariadb" "test_mariadb" {
   resource_name                = "tf-mariadb-20260203-01"
   resource_realm               = "k8s-3.ext.nubes.ru"
-  resource_c_p_u               = 500
+  resource_cpu                 = 500
   resource_memory              = 1024
   resource_disk                = 1
   resource_instances           = 1
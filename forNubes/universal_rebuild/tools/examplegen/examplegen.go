@@ -0,0 +1,321 @@
+// Package examplegen writes runnable Terraform examples for the generated
+// resources: examples/resources/nubes_<name>/{main.tf,variables.tf,outputs.tf}.
+// It is used by tools/gen and by the standalone tools/min_tf.
+package examplegen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// ProviderSource is the registry address used in required_providers.
+const ProviderSource = "terra.k8c.ru/nubes/nubes"
+
+type Service struct {
+	Name      string
+	ServiceID int
+	Attrs     []Attr
+	Outputs   []Output
+}
+
+// Attr is one param attribute as the generated schema exposes it.
+type Attr struct {
+	Name        string
+	Type        string // bool, int64 or string
+	Required    bool
+	Default     string
+	Description string
+	Enum        []string
+	Sensitive   bool
+	WriteOnly   bool
+	// Ref is the resource name (without nubes_) the attribute points at.
+	Ref string
+}
+
+type Output struct {
+	Name      string
+	Sensitive bool
+}
+
+// Load reads the service YAML files in dir with the overlays in overridesDir
+// applied, normalised as in tools/gen. Composite resources are skipped.
+func Load(dir string, overridesDir string) ([]Service, error) {
+	overlays, err := servicespec.LoadOverlays(overridesDir)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := servicespec.Load(os.DirFS(dir), overlays)
+	if err != nil {
+		return nil, err
+	}
+	return FromResources(resources), nil
+}
+
+// FromResources describes the attributes of already loaded resources: create
+// params first, then the optional modify-only params.
+func FromResources(resources []servicespec.Resource) []Service {
+	services := make([]Service, 0, len(resources))
+	for _, r := range resources {
+		svc := Service{Name: r.Name, ServiceID: r.ServiceID}
+		for _, p := range r.AllParams {
+			descr := strings.TrimSpace(p.Label)
+			if d := strings.TrimSpace(p.Description); d != "" && d != descr {
+				descr = strings.TrimSpace(descr + " " + d)
+			}
+			svc.Attrs = append(svc.Attrs, Attr{
+				Name:        servicespec.AttrName(p),
+				Type:        servicespec.CanonicalType(p.Type),
+				Required:    p.Required,
+				Default:     p.Default,
+				Description: descr,
+				Enum:        p.Enum,
+				Sensitive:   p.Sensitive || p.WriteOnly,
+				WriteOnly:   p.WriteOnly,
+				Ref:         p.RefTarget,
+			})
+		}
+		for _, o := range r.Outputs {
+			svc.Outputs = append(svc.Outputs, Output{Name: o.Name, Sensitive: o.Sensitive})
+		}
+		services = append(services, svc)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	return services
+}
+
+// Write renders the examples of services into dir (examples/resources). all
+// is a lookup of every service by name, used to wire references.
+func Write(dir string, services []Service, all map[string]Service) error {
+	for _, svc := range services {
+		files := Render(svc, all)
+		exampleDir := filepath.Join(dir, "nubes_"+svc.Name)
+		if err := os.MkdirAll(exampleDir, 0o755); err != nil {
+			return err
+		}
+		for _, name := range []string{"main.tf", "variables.tf", "outputs.tf"} {
+			if err := os.WriteFile(filepath.Join(exampleDir, name), files[name], 0o644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ByName indexes services by name for Write and Render.
+func ByName(services []Service) map[string]Service {
+	all := make(map[string]Service, len(services))
+	for _, svc := range services {
+		all[svc.Name] = svc
+	}
+	return all
+}
+
+// Render returns main.tf, variables.tf and outputs.tf for svc. Reference
+// params are wired to a resource of the referenced type, declared in the same
+// main.tf (recursively); all is a lookup of every service by name.
+func Render(svc Service, all map[string]Service) map[string][]byte {
+	r := &renderer{all: all, declared: map[string]bool{}}
+	r.variable("api_token", "string", "Nubes API token.", true)
+
+	r.resource(svc, "")
+
+	var main bytes.Buffer
+	main.WriteString("terraform {\n")
+	main.WriteString("  required_providers {\n")
+	main.WriteString("    nubes = {\n")
+	main.WriteString("      source = \"" + ProviderSource + "\"\n")
+	main.WriteString("    }\n")
+	main.WriteString("  }\n")
+	main.WriteString("}\n\n")
+	main.WriteString("provider \"nubes\" {\n")
+	main.WriteString("  api_token = var.api_token\n")
+	main.WriteString("}\n")
+	for _, block := range r.blocks {
+		main.WriteString("\n")
+		main.Write(block)
+	}
+
+	var outputs bytes.Buffer
+	addr := "nubes_" + svc.Name + ".example"
+	outputs.WriteString("output \"id\" {\n")
+	outputs.WriteString("  value = " + addr + ".id\n")
+	outputs.WriteString("}\n")
+	for _, o := range svc.Outputs {
+		outputs.WriteString("\noutput \"" + o.Name + "\" {\n")
+		if o.Sensitive {
+			outputs.WriteString("  value     = " + addr + "." + o.Name + "\n")
+			outputs.WriteString("  sensitive = true\n")
+		} else {
+			outputs.WriteString("  value = " + addr + "." + o.Name + "\n")
+		}
+		outputs.WriteString("}\n")
+	}
+
+	return map[string][]byte{
+		"main.tf":      main.Bytes(),
+		"variables.tf": r.variables.Bytes(),
+		"outputs.tf":   outputs.Bytes(),
+	}
+}
+
+// Blocks returns the variables and resource blocks of svc without the
+// terraform and provider blocks, to append to a configuration that already
+// declares the provider and var.api_token.
+func Blocks(svc Service, all map[string]Service) []byte {
+	r := &renderer{all: all, declared: map[string]bool{}}
+	r.resource(svc, "")
+
+	var b bytes.Buffer
+	b.Write(r.variables.Bytes())
+	for _, block := range r.blocks {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.Write(block)
+	}
+	return b.Bytes()
+}
+
+type renderer struct {
+	all       map[string]Service
+	declared  map[string]bool
+	blocks    [][]byte
+	variables bytes.Buffer
+}
+
+// resource appends the block of svc after the blocks it references. prefix
+// keeps variable names of referenced resources apart from the main one.
+func (r *renderer) resource(svc Service, prefix string) {
+	r.declared[svc.Name] = true
+
+	type line struct{ name, value string }
+	required := []line{{"resource_name", strconv.Quote("example-" + strings.ReplaceAll(strings.ToLower(svc.Name), "_", "-"))}}
+	var optional []string
+	for _, a := range svc.Attrs {
+		if a.Required {
+			required = append(required, line{a.Name, r.requiredValue(a, prefix)})
+			continue
+		}
+		value := literal(a.Type, a.Default)
+		comment := ""
+		if a.Default == "" {
+			value = placeholder(a.Type)
+			comment = " # no default"
+		}
+		if len(a.Enum) > 0 {
+			comment += " # one of: " + strings.Join(a.Enum, ", ")
+		}
+		optional = append(optional, fmt.Sprintf("  # %s = %s%s\n", a.Name, value, comment))
+	}
+
+	width := 0
+	for _, l := range required {
+		if len(l.name) > width {
+			width = len(l.name)
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "resource \"nubes_%s\" \"example\" {\n", svc.Name)
+	for _, l := range required {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, l.name, l.value)
+	}
+	if len(optional) > 0 {
+		b.WriteString("\n  # Optional parameters with their defaults:\n")
+		for _, o := range optional {
+			b.WriteString(o)
+		}
+	}
+	b.WriteString("}\n")
+	r.blocks = append(r.blocks, b.Bytes())
+}
+
+// requiredValue wires references, inlines defaults and asks for the rest
+// through variables.
+func (r *renderer) requiredValue(a Attr, prefix string) string {
+	if a.Ref != "" {
+		if target, ok := r.all[a.Ref]; ok {
+			if !r.declared[a.Ref] {
+				r.resource(target, a.Ref+"_")
+			}
+			return "nubes_" + a.Ref + ".example.id"
+		}
+	}
+	if a.Default != "" && !a.WriteOnly {
+		return literal(a.Type, a.Default)
+	}
+	name := prefix + a.Name
+	descr := a.Description
+	if descr == "" {
+		descr = "Value of " + a.Name + "."
+	}
+	if a.Ref != "" {
+		descr = strings.TrimSpace(descr + " Id or resource_name of a nubes_" + a.Ref + " instance.")
+	}
+	r.variable(name, hclType(a.Type), descr, a.Sensitive)
+	return "var." + name
+}
+
+func (r *renderer) variable(name, typ, descr string, sensitive bool) {
+	if r.variables.Len() > 0 {
+		r.variables.WriteString("\n")
+	}
+	fmt.Fprintf(&r.variables, "variable %q {\n", name)
+	fmt.Fprintf(&r.variables, "  type        = %s\n", typ)
+	fmt.Fprintf(&r.variables, "  description = %s\n", quote(descr))
+	if sensitive {
+		r.variables.WriteString("  sensitive   = true\n")
+	}
+	r.variables.WriteString("}\n")
+}
+
+func hclType(t string) string {
+	switch t {
+	case "bool":
+		return "bool"
+	case "int64":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func placeholder(t string) string {
+	switch t {
+	case "bool":
+		return "false"
+	case "int64":
+		return "0"
+	default:
+		return `""`
+	}
+}
+
+// literal renders a YAML default as an HCL value of type t.
+func literal(t, v string) string {
+	v = strings.TrimSpace(v)
+	switch t {
+	case "bool":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case "int64":
+		if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return v
+		}
+	}
+	return quote(v)
+}
+
+// quote renders an HCL string literal, escaping template sequences.
+func quote(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "${", "$${")
+	return strings.ReplaceAll(q, "%{", "%%{")
+}
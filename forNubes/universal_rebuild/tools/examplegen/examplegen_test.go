@@ -0,0 +1,154 @@
+package examplegen
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Every example of the tree must parse as HCL and use only what the resources
+// and the example's own variables declare.
+func TestRenderedExamplesParse(t *testing.T) {
+	services, err := Load(filepath.Join("..", "..", "resources_yaml"), filepath.Join("..", "..", "resources_overrides"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := ByName(services)
+	for _, svc := range services {
+		t.Run(svc.Name, func(t *testing.T) {
+			checkExample(t, Render(svc, all), all)
+		})
+	}
+}
+
+// Blocks must fit a configuration that declares only the provider and
+// var.api_token, like test_dummy/main.tf in universal_rebuild_devops.
+func TestBlocksParse(t *testing.T) {
+	services, err := Load(filepath.Join("..", "..", "resources_yaml"), filepath.Join("..", "..", "resources_overrides"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := ByName(services)
+	preamble := []byte("variable \"api_token\" {\n  type = string\n}\n")
+	for _, svc := range services {
+		t.Run(svc.Name, func(t *testing.T) {
+			checkExample(t, map[string][]byte{"main.tf": preamble, "blocks.tf": Blocks(svc, all)}, all)
+		})
+	}
+}
+
+func checkExample(t *testing.T, files map[string][]byte, all map[string]Service) {
+	t.Helper()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parser := hclparse.NewParser()
+	var bodies []*hclsyntax.Body
+	for _, name := range names {
+		f, diags := parser.ParseHCL(files[name], name)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", name, diags.Error())
+		}
+		bodies = append(bodies, f.Body.(*hclsyntax.Body))
+	}
+
+	variables := map[string]bool{}
+	resources := map[string]bool{}
+	for _, body := range bodies {
+		for _, b := range body.Blocks {
+			switch b.Type {
+			case "variable":
+				variables[b.Labels[0]] = true
+			case "resource":
+				resources[b.Labels[0]+"."+b.Labels[1]] = true
+			}
+		}
+	}
+
+	for _, body := range bodies {
+		for _, b := range body.Blocks {
+			if b.Type == "resource" {
+				checkResource(t, b, all)
+			}
+		}
+		for _, tr := range traversals(body) {
+			var attr hcl.TraverseAttr
+			if len(tr) > 1 {
+				attr, _ = tr[1].(hcl.TraverseAttr)
+			}
+			switch root := tr.RootName(); {
+			case attr.Name == "":
+				t.Errorf("%s: unexpected reference", tr.SourceRange())
+			case root == "var" && !variables[attr.Name]:
+				t.Errorf("%s: undeclared variable %s", tr.SourceRange(), attr.Name)
+			case root != "var" && !resources[root+"."+attr.Name]:
+				t.Errorf("%s: undeclared resource %s.%s", tr.SourceRange(), root, attr.Name)
+			}
+		}
+	}
+}
+
+// traversals returns the references of every attribute in body and its
+// nested blocks. The type of a variable is a type expression, not a reference.
+func traversals(body *hclsyntax.Body) []hcl.Traversal {
+	var out []hcl.Traversal
+	for _, a := range body.Attributes {
+		out = append(out, a.Expr.Variables()...)
+	}
+	for _, b := range body.Blocks {
+		if b.Type == "variable" {
+			continue
+		}
+		out = append(out, traversals(b.Body)...)
+	}
+	return out
+}
+
+// checkResource matches the attributes of a resource block against the
+// service: known names, every required attribute set, literals of the
+// attribute type.
+func checkResource(t *testing.T, b *hclsyntax.Block, all map[string]Service) {
+	t.Helper()
+	typ := b.Labels[0]
+	svc, ok := all[typ[len("nubes_"):]]
+	if !ok {
+		t.Errorf("%s: unknown resource type %s", b.Range(), typ)
+		return
+	}
+	attrs := map[string]Attr{"resource_name": {Name: "resource_name", Type: "string", Required: true}}
+	for _, a := range svc.Attrs {
+		attrs[a.Name] = a
+	}
+	for name, a := range attrs {
+		if _, ok := b.Body.Attributes[name]; !ok && a.Required {
+			t.Errorf("%s: %s.%s: required attribute is not set", b.Range(), typ, name)
+		}
+	}
+	for name, ha := range b.Body.Attributes {
+		a, ok := attrs[name]
+		if !ok {
+			t.Errorf("%s: %s has no attribute %s", ha.SrcRange, typ, name)
+			continue
+		}
+		if len(ha.Expr.Variables()) > 0 {
+			continue
+		}
+		v, diags := ha.Expr.Value(nil)
+		if diags.HasErrors() {
+			t.Errorf("%s: %s", ha.SrcRange, diags.Error())
+			continue
+		}
+		want := map[string]cty.Type{"bool": cty.Bool, "int64": cty.Number, "string": cty.String}[a.Type]
+		if v.Type() != want {
+			t.Errorf("%s: %s.%s is %s, want %s", ha.SrcRange, typ, name, v.Type().FriendlyName(), want.FriendlyName())
+		}
+	}
+}
@@ -0,0 +1,659 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"terraform-provider-nubes/internal/servicespec"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Composite resources (`kind: composite`) chain several services into one
+// Terraform resource. Children are created in declaration order and receive
+// the instance ids of earlier children through `wiring`.
+
+type CompositeYAML struct {
+	Kind     string `yaml:"kind"`
+	Name     string `yaml:"name"`
+	Children []struct {
+		Name    string `yaml:"name"`
+		Service string `yaml:"service"`
+		// Wiring maps a param code of this child to an earlier child name.
+		Wiring map[string]string `yaml:"wiring"`
+	} `yaml:"children"`
+	Lifecycle struct {
+		DeleteModeDefault     string `yaml:"delete_mode_default"`
+		ResumeIfExistsDefault bool   `yaml:"resume_if_exists_default"`
+	} `yaml:"lifecycle"`
+}
+
+type GenComposite struct {
+	Name           string
+	Children       []GenChild
+	DeleteMode     string
+	ResumeIfExists bool
+
+	SensitiveParamIDs []int
+	ParamRefs         []ParamRef
+	RefParams         []CompositeRef
+
+	SchemaVersion  int64
+	StateUpgraders []StateUpgrader
+
+	NeedsValidator       bool
+	NeedsStringValidator bool
+	NeedsInt64Validator  bool
+	NeedsRegexp          bool
+	NeedsInt64Default    bool
+}
+
+// GenChild is one child service. Its params keep their API codes; TFName holds
+// the prefixed attribute name (<child>_<attr>). Wired params are not attributes.
+type GenChild struct {
+	Name         string
+	ServiceID    int
+	Service      string
+	CreateParams []Param
+	ModifyParams []Param
+	AllParams    []Param
+	CreateWiring []Wire
+	ModifyWiring []Wire
+	// Polling is set when the service declares `polling`; the child then uses
+	// the service's generated <service>PollPolicy.
+	Polling bool
+}
+
+type Wire struct {
+	ParamID int
+	From    string
+}
+
+// CompositeRef is an unwired reference param validated at plan time.
+type CompositeRef struct {
+	Attribute string
+	ServiceID int
+	Field     string
+}
+
+func loadComposites(dir string, services []GenResource) ([]GenComposite, error) {
+	byName := make(map[string]GenResource, len(services))
+	for _, svc := range services {
+		byName[svc.Name] = svc
+	}
+
+	var composites []GenComposite
+	walkErr := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
+			return nil
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var c CompositeYAML
+		if err := yaml.Unmarshal(b, &c); err != nil {
+			return err
+		}
+		if c.Kind != servicespec.KindComposite {
+			return nil
+		}
+		gc, err := buildComposite(c, byName)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		composites = append(composites, gc)
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	sort.Slice(composites, func(i, j int) bool { return composites[i].Name < composites[j].Name })
+	return composites, nil
+}
+
+func buildComposite(c CompositeYAML, services map[string]GenResource) (GenComposite, error) {
+	gc := GenComposite{
+		Name:           c.Name,
+		DeleteMode:     c.Lifecycle.DeleteModeDefault,
+		ResumeIfExists: c.Lifecycle.ResumeIfExistsDefault,
+	}
+	if _, ok := services[c.Name]; ok {
+		return gc, fmt.Errorf("composite name %q clashes with a service", c.Name)
+	}
+	if len(c.Children) == 0 {
+		return gc, fmt.Errorf("composite %s has no children", c.Name)
+	}
+
+	seen := map[string]string{
+		"id":               "",
+		"resource_name":    "",
+		"delete_mode":      "",
+		"resume_if_exists": "",
+	}
+	childService := map[string]int{}
+	for _, ch := range c.Children {
+		if ch.Name == "" || ch.Name != servicespec.ToSnake(ch.Name) {
+			return gc, fmt.Errorf("child %q: name must be snake_case", ch.Name)
+		}
+		if _, dup := childService[ch.Name]; dup {
+			return gc, fmt.Errorf("duplicate child %s", ch.Name)
+		}
+		svc, ok := services[ch.Service]
+		if !ok {
+			return gc, fmt.Errorf("child %s: unknown service %q", ch.Name, ch.Service)
+		}
+
+		for code, from := range ch.Wiring {
+			refSvc, ok := childService[from]
+			if !ok {
+				return gc, fmt.Errorf("child %s: param %s is wired to %q, which is not an earlier child", ch.Name, code, from)
+			}
+			p, ok := findParam(svc.AllParams, code)
+			if !ok {
+				return gc, fmt.Errorf("child %s: service %s has no param %s", ch.Name, svc.Name, code)
+			}
+			if p.RefServiceID > 0 && p.RefServiceID != refSvc {
+				return gc, fmt.Errorf("child %s: param %s references service %d, but %s is service %d", ch.Name, code, p.RefServiceID, from, refSvc)
+			}
+		}
+
+		child := GenChild{Name: ch.Name, ServiceID: svc.ServiceID, Service: svc.Name, Polling: svc.Polling != nil}
+		prefix := func(params []Param) []Param {
+			var out []Param
+			for _, p := range params {
+				if _, wired := ch.Wiring[p.Code]; wired {
+					continue
+				}
+				p.TFName = ch.Name + "_" + servicespec.AttrName(p)
+				out = append(out, p)
+			}
+			return out
+		}
+		wires := func(params []Param) []Wire {
+			var out []Wire
+			for _, p := range params {
+				if from, ok := ch.Wiring[p.Code]; ok {
+					out = append(out, Wire{ParamID: p.ID, From: from})
+				}
+			}
+			return out
+		}
+		child.CreateParams = prefix(svc.CreateParams)
+		child.ModifyParams = prefix(svc.ModifyParams)
+		child.AllParams = prefix(svc.AllParams)
+		child.CreateWiring = wires(svc.CreateParams)
+		child.ModifyWiring = wires(svc.ModifyParams)
+
+		names := []string{ch.Name + "_id"}
+		for _, p := range child.AllParams {
+			if p.WriteOnly {
+				return gc, fmt.Errorf("child %s: write-only param %s is not supported in composites", ch.Name, p.Code)
+			}
+			names = append(names, servicespec.AttrName(p))
+		}
+		for _, name := range names {
+			if other, ok := seen[name]; ok {
+				return gc, fmt.Errorf("child %s: attribute %q clashes with %q", ch.Name, name, other)
+			}
+			seen[name] = ch.Name
+		}
+
+		for _, p := range child.AllParams {
+			if p.RefServiceID > 0 {
+				gc.RefParams = append(gc.RefParams, CompositeRef{
+					Attribute: servicespec.AttrName(p),
+					ServiceID: p.RefServiceID,
+					Field:     toCamel(ch.Name) + toCamel(p.Code),
+				})
+			}
+			if hasSchemaDefault(p) && servicespec.CanonicalType(p.Type) == "int64" {
+				gc.NeedsInt64Default = true
+			}
+			for _, v := range paramValidators(p) {
+				gc.NeedsValidator = true
+				switch {
+				case strings.HasPrefix(v, "stringvalidator."):
+					gc.NeedsStringValidator = true
+				case strings.HasPrefix(v, "int64validator."):
+					gc.NeedsInt64Validator = true
+				}
+				if strings.Contains(v, "regexp.") {
+					gc.NeedsRegexp = true
+				}
+			}
+		}
+		gc.SensitiveParamIDs = append(gc.SensitiveParamIDs, servicespec.SensitiveParamIDs(child.CreateParams, child.ModifyParams)...)
+		gc.ParamRefs = append(gc.ParamRefs, paramRefs(child.CreateParams, child.ModifyParams)...)
+
+		childService[ch.Name] = svc.ServiceID
+		gc.Children = append(gc.Children, child)
+	}
+	return gc, nil
+}
+
+func findParam(params []Param, code string) (Param, bool) {
+	for _, p := range params {
+		if p.Code == code {
+			return p, true
+		}
+	}
+	return Param{}, false
+}
+
+// compositeStateAttributes lists the attributes a composite stores in state,
+// keyed by child name so that renaming a child's service attribute is a rename.
+func compositeStateAttributes(c GenComposite) []HistoryAttr {
+	var attrs []HistoryAttr
+	for _, ch := range c.Children {
+		attrs = append(attrs, HistoryAttr{Key: ch.Name + ".id", Name: ch.Name + "_id", Type: "string"})
+		for _, p := range ch.AllParams {
+			attrs = append(attrs, HistoryAttr{Key: ch.Name + "." + p.Code, Name: servicespec.AttrName(p), Type: servicespec.CanonicalType(p.Type)})
+		}
+	}
+	return attrs
+}
+
+func writeComposite(out generated, outDir string, c GenComposite) error {
+	tpl, err := template.New("composite").Funcs(templateFuncs()).Parse(compositeTemplate)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, c); err != nil {
+		return err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		formatted = buf.Bytes()
+	}
+
+	out.add(filepath.Join(outDir, fmt.Sprintf("%s_resource.go", c.Name)), formatted)
+	return nil
+}
+
+const compositeTemplate = `package resources_gen
+
+import (
+	"context"
+	{{- if .NeedsRegexp }}
+	"regexp"
+	{{- end }}
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	{{- if .NeedsInt64Default }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	{{- end }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	{{- if .NeedsStringValidator }}
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	{{- end }}
+	{{- if .NeedsInt64Validator }}
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	{{- end }}
+	{{- if .NeedsValidator }}
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	{{- end }}
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Composite: {{.Name}}
+{{- range .Children }}
+// Child {{.Name}}: {{.Service}} (service {{.ServiceID}})
+{{- end }}
+
+var _ resource.Resource = &{{ToCamel .Name}}Resource{}
+{{- if .RefParams }}
+var _ resource.ResourceWithModifyPlan = &{{ToCamel .Name}}Resource{}
+{{- end }}
+var _ resource.ResourceWithUpgradeState = &{{ToCamel .Name}}Resource{}
+
+type {{ToCamel .Name}}Resource struct {
+	client *core.UniversalClient
+}
+
+type {{ToCamel .Name}}Model struct {
+	ID           types.String {{bt}}tfsdk:"id"{{bt}}
+	ResourceName types.String {{bt}}tfsdk:"resource_name"{{bt}}
+{{- range $c := .Children }}
+	{{ToCamel $c.Name}}ID types.String {{bt}}tfsdk:"{{$c.Name}}_id"{{bt}}
+{{- range $c.AllParams }}
+	{{ToCamel $c.Name}}{{ToCamel .Code}} {{ParamType .}} {{bt}}tfsdk:"{{AttrName .}}"{{bt}}
+{{- end }}
+{{- end }}
+	DeleteMode     types.String {{bt}}tfsdk:"delete_mode"{{bt}}
+	ResumeIfExists types.Bool   {{bt}}tfsdk:"resume_if_exists"{{bt}}
+}
+
+func New{{ToCamel .Name}}Resource() resource.Resource {
+	return &{{ToCamel .Name}}Resource{}
+}
+
+func (r *{{ToCamel .Name}}Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_{{.Name}}"
+}
+
+func (r *{{ToCamel .Name}}Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attrs := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"resource_name": schema.StringAttribute{Required: true},
+{{- range $c := .Children }}
+		"{{$c.Name}}_id": schema.StringAttribute{
+			Computed: true,
+			MarkdownDescription: "Instance id of the {{bt}}{{$c.Name}}{{bt}} child (nubes_{{$c.Service}}).",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+{{- range $c.AllParams }}
+		"{{AttrName .}}": schema.{{if eq (ParamType .) "types.Bool"}}Bool{{else if eq (ParamType .) "types.Int64"}}Int64{{else}}String{{end}}Attribute{
+			{{if .Required}}Required: true,{{else}}Optional: true,{{end}}
+			{{- if HasSchemaDefault .}}
+			Computed: true,
+			Default: {{ParamDefaultExpr .}},
+			{{- end}}
+			{{- if .Sensitive}}
+			Sensitive: true,
+			{{- end}}
+			{{- with AttrDescription .}}
+			Description:         {{printf "%q" (PlainText .)}},
+			MarkdownDescription: {{printf "%q" .}},
+			{{- end}}
+			{{- if Validators .}}
+			Validators: []validator.{{ValidatorKind .}}{
+				{{- range Validators .}}
+				{{.}},
+				{{- end}}
+			},
+			{{- end}}
+		},
+{{- end }}
+{{- end }}
+		"delete_mode": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString("{{if .DeleteMode}}{{.DeleteMode}}{{else}}state_only{{end}}"),
+		},
+		"resume_if_exists": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool({{if .ResumeIfExists}}true{{else}}false{{end}}),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		Version:    {{.SchemaVersion}},
+		Attributes: attrs,
+	}
+}
+
+func (r *{{ToCamel .Name}}Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+{{- range .StateUpgraders }}
+		{{.FromVersion}}: resources_core.MigrateState(
+{{- range .Steps }}
+			resources_core.StateMigration{
+{{- if .Renames }}
+				Rename: map[string]string{
+{{- range .Renames }}
+					"{{.From}}": "{{.To}}",
+{{- end }}
+				},
+{{- end }}
+{{- if .Drops }}
+				Drop: []string{
+{{- range .Drops }}
+					"{{.}}",
+{{- end }}
+				},
+{{- end }}
+{{- if .Converts }}
+				Convert: map[string]string{
+{{- range .Converts }}
+					"{{.Name}}": "{{.Type}}",
+{{- end }}
+				},
+{{- end }}
+			},
+{{- end }}
+		),
+{{- end }}
+	}
+}
+{{- if .RefParams }}
+
+func (r *{{ToCamel .Name}}Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	var config *{{ToCamel .Name}}Model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+{{- range .RefParams }}
+		resources_core.Reference{Attribute: "{{.Attribute}}", ServiceID: {{.ServiceID}}, Value: config.{{.Field}}},
+{{- end }}
+	)...)
+}
+{{- end }}
+
+func (r *{{ToCamel .Name}}Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data {{ToCamel .Name}}Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- if or .SensitiveParamIDs .ParamRefs }}
+{{ end }}
+{{- if .SensitiveParamIDs }}
+	ctx = core.WithSensitiveParams(ctx{{range .SensitiveParamIDs}}, {{.}}{{end}})
+{{- end }}
+{{- if .ParamRefs }}
+	ctx = core.WithParamRefs(ctx, map[int]int{ {{- range $i, $r := .ParamRefs}}{{if $i}}, {{end}}{{$r.ID}}: {{$r.ServiceID}}{{end -}} })
+{{- end }}
+
+	resourceName := data.ResourceName.ValueString()
+	children := []resources_core.CompositeChild{
+{{- range $c := .Children }}
+		{
+			Name:        "{{$c.Name}}",
+			ServiceID:   {{$c.ServiceID}},
+			DisplayName: resources_core.CompositeDisplayName(resourceName, "{{$c.Name}}"),
+			Params: map[int]string{
+{{- range $c.CreateParams }}
+				{{.ID}}: {{ParamFormat . (printf "data.%s%s" (ToCamel $c.Name) (ToCamel .Code))}},
+{{- end }}
+			},
+{{- if $c.CreateWiring }}
+			Wiring: map[int]string{
+{{- range $c.CreateWiring }}
+				{{.ParamID}}: "{{.From}}",
+{{- end }}
+			},
+{{- end }}
+{{- if $c.Polling }}
+			PollPolicy: {{ToLowerCamel $c.Service}}PollPolicy,
+{{- end }}
+		},
+{{- end }}
+	}
+
+	ids, err := resources_core.CreateComposite(ctx, r.client, data.ResumeIfExists.ValueBool(), children)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		if len(ids) == 0 {
+			return
+		}
+		// The rollback left some children; keep them in state so that they
+		// are deleted with the (tainted) resource.
+	}
+{{ range .Children }}
+	data.{{ToCamel .Name}}ID = resources_core.CompositeID(ids, "{{.Name}}")
+{{- end }}
+	data.ID = resources_core.CompositeID(ids{{range .Children}}, "{{.Name}}"{{end}})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *{{ToCamel .Name}}Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *{{ToCamel .Name}}Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data == nil {
+		return
+	}
+	if r.client != nil {
+		// A composite with a missing child is recreated as a whole.
+		for _, id := range []types.String{
+{{- range .Children }}
+			data.{{ToCamel .Name}}ID,
+{{- end }}
+		} {
+			if id.IsNull() || id.IsUnknown() {
+				continue
+			}
+			state, err := r.client.GetInstanceState(ctx, id.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", err.Error())
+				return
+			}
+			if state != nil && state.IsDeleted {
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *{{ToCamel .Name}}Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan {{ToCamel .Name}}Model
+	var state {{ToCamel .Name}}Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- if or .SensitiveParamIDs .ParamRefs }}
+{{ end }}
+{{- if .SensitiveParamIDs }}
+	ctx = core.WithSensitiveParams(ctx{{range .SensitiveParamIDs}}, {{.}}{{end}})
+{{- end }}
+{{- if .ParamRefs }}
+	ctx = core.WithParamRefs(ctx, map[int]int{ {{- range $i, $r := .ParamRefs}}{{if $i}}, {{end}}{{$r.ID}}: {{$r.ServiceID}}{{end -}} })
+{{- end }}
+
+	ids := map[string]string{
+{{- range .Children }}
+		"{{.Name}}": state.{{ToCamel .Name}}ID.ValueString(),
+{{- end }}
+	}
+	children := []resources_core.CompositeChild{
+{{- range $c := .Children }}
+{{- if $c.ModifyParams }}
+		{
+			Name: "{{$c.Name}}",
+			Params: map[int]string{
+{{- range $c.ModifyParams }}
+				{{.ID}}: {{ParamFormat . (printf "plan.%s%s" (ToCamel $c.Name) (ToCamel .Code))}},
+{{- end }}
+			},
+{{- if $c.ModifyWiring }}
+			Wiring: map[int]string{
+{{- range $c.ModifyWiring }}
+				{{.ParamID}}: "{{.From}}",
+{{- end }}
+			},
+{{- end }}
+			Skip: {{range $i, $p := $c.ModifyParams}}{{if $i}} && {{end}}plan.{{ToCamel $c.Name}}{{ToCamel $p.Code}}.Equal(state.{{ToCamel $c.Name}}{{ToCamel $p.Code}}){{end}},
+{{- if $c.Polling }}
+			PollPolicy: {{ToLowerCamel $c.Service}}PollPolicy,
+{{- end }}
+		},
+{{- end }}
+{{- end }}
+	}
+
+	if err := resources_core.UpdateComposite(ctx, r.client, ids, children); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+{{- range .Children }}
+	plan.{{ToCamel .Name}}ID = state.{{ToCamel .Name}}ID
+{{- end }}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *{{ToCamel .Name}}Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *{{ToCamel .Name}}Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	ids := map[string]string{
+{{- range .Children }}
+		"{{.Name}}": state.{{ToCamel .Name}}ID.ValueString(),
+{{- end }}
+	}
+	children := []resources_core.CompositeChild{
+{{- range .Children }}
+		{Name: "{{.Name}}"{{if .Polling}}, PollPolicy: {{ToLowerCamel .Service}}PollPolicy{{end}}},
+{{- end }}
+	}
+	if err := resources_core.DeleteComposite(ctx, r.client, ids, children, state.DeleteMode.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+}
+
+func (r *{{ToCamel .Name}}Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	r.client = client
+}
+`
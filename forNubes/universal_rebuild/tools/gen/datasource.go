@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"text/template"
+)
+
+// Every service also gets a nubes_<name> data source that reads an existing
+// instance by id or resource_name. Its attributes are the resource's params
+// and outputs, all computed; write-only params are never readable.
+
+type GenDataSource struct {
+	GenResource
+	Params []Param
+}
+
+func dataSourceModel(svc GenResource) GenDataSource {
+	ds := GenDataSource{GenResource: svc}
+	for _, p := range svc.AllParams {
+		if !p.WriteOnly {
+			ds.Params = append(ds.Params, p)
+		}
+	}
+	return ds
+}
+
+func writeDataSource(out generated, outDir string, svc GenResource) error {
+	tpl, err := template.New("dataSource").Funcs(templateFuncs()).Parse(dataSourceTemplate)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, dataSourceModel(svc)); err != nil {
+		return err
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		formatted = buf.Bytes()
+	}
+	out.add(filepath.Join(outDir, fmt.Sprintf("%s_data_source.go", svc.Name)), formatted)
+	return nil
+}
+
+const dataSourceTemplate = `package resources_gen
+
+import (
+	"context"
+
+	"terraform-provider-nubes/internal/core"
+	"terraform-provider-nubes/internal/resources_core"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Code generated by tools/gen. DO NOT EDIT.
+// Service: {{.Name}}
+// Service ID: {{.ServiceID}}
+
+var _ datasource.DataSource = &{{ToCamel .Name}}DataSource{}
+var _ datasource.DataSourceWithConfigValidators = &{{ToCamel .Name}}DataSource{}
+
+type {{ToCamel .Name}}DataSource struct {
+	client *core.UniversalClient
+}
+
+type {{ToCamel .Name}}DataSourceModel struct {
+	ID           types.String {{bt}}tfsdk:"id"{{bt}}
+	ResourceName types.String {{bt}}tfsdk:"resource_name"{{bt}}
+{{- range .Params }}
+	{{ToCamel .Code}} {{ParamType .}} {{bt}}tfsdk:"{{AttrName .}}"{{bt}}
+{{- end }}
+{{- range .Outputs }}
+	{{ToCamel .Name}} types.{{OutputKind .}} {{bt}}tfsdk:"{{.Name}}"{{bt}}
+{{- end }}
+}
+
+{{ if .Params }}
+// {{ToLowerCamel .Name}}DataParams map attributes to the params they show.
+var {{ToLowerCamel .Name}}DataParams = []resources_core.DataParam{
+{{- range .Params }}
+	{Attribute: "{{AttrName .}}", ID: {{.ID}}},
+{{- end }}
+}
+
+{{ end -}}
+func New{{ToCamel .Name}}DataSource() datasource.DataSource {
+	return &{{ToCamel .Name}}DataSource{}
+}
+
+func (d *{{ToCamel .Name}}DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_{{.Name}}"
+}
+
+func (d *{{ToCamel .Name}}DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing instance of the Nubes service {{bt}}{{.Name}}{{bt}} (service ID {{.ServiceID}}) by {{bt}}id{{bt}} or {{bt}}resource_name{{bt}}.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Instance UID. Exactly one of {{bt}}id{{bt}} and {{bt}}resource_name{{bt}} must be set.",
+			},
+			"resource_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Display name of the instance. Exactly one of {{bt}}id{{bt}} and {{bt}}resource_name{{bt}} must be set.",
+			},
+{{- range .Params }}
+			"{{AttrName .}}": schema.{{ValidatorKind .}}Attribute{
+				Computed: true,
+				{{- if .Sensitive}}
+				Sensitive: true,
+				{{- end}}
+				{{- with AttrDescription .}}
+				Description:         {{printf "%q" (PlainText .)}},
+				MarkdownDescription: {{printf "%q" .}},
+				{{- end}}
+			},
+{{- end }}
+{{- range .Outputs }}
+			"{{.Name}}": schema.{{OutputKind .}}Attribute{
+				Computed: true,
+				{{- if .Sensitive}}
+				Sensitive: true,
+				{{- end}}
+			},
+{{- end }}
+		},
+	}
+}
+
+func (d *{{ToCamel .Name}}DataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("resource_name")),
+	}
+}
+
+func (d *{{ToCamel .Name}}DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data {{ToCamel .Name}}DataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, {{if or .Params .Outputs}}values{{else}}_{{end}}, diags := resources_core.ReadInstance(ctx, d.client, {{.ServiceID}}, data.ID, data.ResourceName,
+		{{- if .Params}} {{ToLowerCamel .Name}}DataParams{{else}} nil{{end}},
+		{{- if .Outputs}} {{ToLowerCamel .Name}}Outputs{{else}} nil{{end}})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(info.InstanceUid)
+	data.ResourceName = types.StringValue(info.DisplayName)
+{{- range .Params }}
+	data.{{ToCamel .Code}} = resources_core.Output{{ValidatorKind .}}(values, "{{AttrName .}}", types.{{ValidatorKind .}}Null())
+{{- end }}
+{{- range .Outputs }}
+	data.{{ToCamel .Name}} = resources_core.Output{{OutputKind .}}(values, "{{.Name}}", types.{{OutputKind .}}Null())
+{{- end }}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (d *{{ToCamel .Name}}DataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*core.UniversalClient)
+	if !ok {
+		resp.Diagnostics.AddError("Error", "Wrong client type expected *core.UniversalClient")
+		return
+	}
+
+	d.client = client
+}
+`
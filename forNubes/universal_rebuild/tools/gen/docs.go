@@ -0,0 +1,439 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// Documentation in the tfplugindocs layout: docs/index.md for the provider,
+// docs/resources/<name>.md per resource type, docs/data-sources/<name>.md
+// per data source and the docs/guides/all-resources.md summary table.
+
+type DocPage struct {
+	Name string
+	// Kind is "Resource" or "Data Source".
+	Kind        string
+	Description string
+	Required    []DocAttr
+	Optional    []DocAttr
+	ReadOnly    []DocAttr
+}
+
+type DocAttr struct {
+	Name        string
+	Type        string
+	Flags       []string
+	Description string
+	// Modifiable is set for params changed in place by modify.
+	Modifiable bool
+}
+
+func (a DocAttr) Kind() string {
+	return strings.Join(append([]string{a.Type}, a.Flags...), ", ")
+}
+
+// addAttr files the attribute under the section tfplugindocs would use.
+func (d *DocPage) addAttr(a DocAttr, required bool, computedOnly bool) {
+	switch {
+	case required:
+		d.Required = append(d.Required, a)
+	case computedOnly:
+		d.ReadOnly = append(d.ReadOnly, a)
+	default:
+		d.Optional = append(d.Optional, a)
+	}
+}
+
+func (d *DocPage) sort() {
+	for _, attrs := range [][]DocAttr{d.Required, d.Optional, d.ReadOnly} {
+		sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+	}
+}
+
+func docType(t string) string {
+	switch servicespec.CanonicalType(t) {
+	case "bool":
+		return "Boolean"
+	case "int64":
+		return "Number"
+	default:
+		return "String"
+	}
+}
+
+// paramDoc describes a param attribute: API description, reference target, allowed values,
+// default and whether a change is applied in place.
+func paramDoc(p Param, modifiable bool) DocAttr {
+	a := DocAttr{Name: servicespec.AttrName(p), Type: docType(p.Type), Modifiable: modifiable}
+	if p.Sensitive || p.WriteOnly {
+		a.Flags = append(a.Flags, "Sensitive")
+	}
+	if p.WriteOnly {
+		a.Flags = append(a.Flags, "Write-only")
+	}
+
+	var parts []string
+	if d := attrDescription(p); d != "" {
+		parts = append(parts, d)
+	}
+	if len(p.Enum) > 0 {
+		parts = append(parts, "Allowed values: "+codeList(p.Enum)+".")
+	}
+	switch {
+	case p.Min != nil && p.Max != nil:
+		parts = append(parts, fmt.Sprintf("Between %d and %d.", *p.Min, *p.Max))
+	case p.Min != nil:
+		parts = append(parts, fmt.Sprintf("At least %d.", *p.Min))
+	case p.Max != nil:
+		parts = append(parts, fmt.Sprintf("At most %d.", *p.Max))
+	}
+	if p.Pattern != "" {
+		parts = append(parts, "Must match `"+p.Pattern+"`.")
+	}
+	if p.Format != "" {
+		parts = append(parts, "Format: "+p.Format+".")
+	}
+	if p.Default != "" {
+		if hasSchemaDefault(p) {
+			parts = append(parts, "Defaults to `"+p.Default+"`.")
+		} else {
+			parts = append(parts, "Service default: `"+p.Default+"`.")
+		}
+	}
+	if p.WriteOnly {
+		parts = append(parts, "Never stored in plan or state; change `"+servicespec.AttrName(p)+"_version` to send it again.")
+	}
+	if modifiable {
+		parts = append(parts, "Changes are applied in place (modify).")
+	} else {
+		parts = append(parts, "Create-only: changes after creation are not applied to the instance.")
+	}
+	a.Description = strings.Join(parts, " ")
+	return a
+}
+
+func codeList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "`" + v + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func isModifiable(p Param, modifyParams []Param) bool {
+	for _, m := range modifyParams {
+		if strings.EqualFold(strings.TrimSpace(m.Code), strings.TrimSpace(p.Code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lifecycleDocs documents the attributes every resource has.
+func lifecycleDocs(d *DocPage, deleteMode string, resumeIfExists bool) {
+	if deleteMode == "" {
+		deleteMode = "state_only"
+	}
+	d.addAttr(DocAttr{Name: "resource_name", Type: "String",
+		Description: "Display name of the instance. An existing instance with this name is adopted when `resume_if_exists` is true."}, true, false)
+	d.addAttr(DocAttr{Name: "delete_mode", Type: "String",
+		Description: "What `terraform destroy` does: `state_only` (forget the instance), `suspend` or `delete`. Defaults to `" + deleteMode + "`."}, false, false)
+	d.addAttr(DocAttr{Name: "resume_if_exists", Type: "Boolean",
+		Description: fmt.Sprintf("Adopt an existing instance with the same `resource_name` instead of failing. Defaults to `%t`.", resumeIfExists)}, false, false)
+	d.addAttr(DocAttr{Name: "id", Type: "String", Description: "Instance UID."}, false, true)
+}
+
+func resourceDoc(svc GenResource) DocPage {
+	d := DocPage{
+		Name:        svc.Name,
+		Kind:        "Resource",
+		Description: fmt.Sprintf("Manages an instance of the Nubes service `%s` (service ID %d).", svc.Name, svc.ServiceID),
+	}
+	lifecycleDocs(&d, svc.DeleteMode, svc.ResumeIfExists)
+	for _, p := range svc.AllParams {
+		d.addAttr(paramDoc(p, isModifiable(p, svc.ModifyParams)), p.Required, false)
+		if p.WriteOnly {
+			d.addAttr(DocAttr{Name: servicespec.AttrName(p) + "_version", Type: "Number",
+				Description: "Change to send `" + servicespec.AttrName(p) + "` again on the next apply."}, false, false)
+		}
+	}
+	for _, o := range svc.Outputs {
+		d.addAttr(outputDoc(o), false, true)
+	}
+	d.addAttr(DocAttr{Name: "pending_operation", Type: "String",
+		Description: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh."}, false, true)
+	d.sort()
+	return d
+}
+
+func outputDoc(o Output) DocAttr {
+	a := DocAttr{Name: o.Name, Type: docType(o.Type),
+		Description: fmt.Sprintf("Read from the %s (`%s`).", outputSource(o), o.Path)}
+	if o.Sensitive {
+		a.Flags = append(a.Flags, "Sensitive")
+	}
+	return a
+}
+
+func dataSourceDoc(svc GenResource) DocPage {
+	d := DocPage{
+		Name: svc.Name,
+		Kind: "Data Source",
+		Description: fmt.Sprintf("Reads an existing instance of the Nubes service `%s` (service ID %d) by `id` or `resource_name`.",
+			svc.Name, svc.ServiceID),
+	}
+	d.addAttr(DocAttr{Name: "id", Type: "String",
+		Description: "Instance UID. Exactly one of `id` and `resource_name` must be set."}, false, false)
+	d.addAttr(DocAttr{Name: "resource_name", Type: "String",
+		Description: "Display name of the instance. Exactly one of `id` and `resource_name` must be set."}, false, false)
+	for _, p := range dataSourceModel(svc).Params {
+		a := DocAttr{Name: servicespec.AttrName(p), Type: docType(p.Type),
+			Description: strings.TrimSpace(attrDescription(p) + " Current value of the `" + p.Code + "` param.")}
+		if p.Sensitive {
+			a.Flags = append(a.Flags, "Sensitive")
+		}
+		d.addAttr(a, false, true)
+	}
+	for _, o := range svc.Outputs {
+		a := outputDoc(o)
+		if servicespec.OutputFrom(o) == "operation" {
+			a.Description += " Always null: the create operation is not known to the data source."
+		}
+		d.addAttr(a, false, true)
+	}
+	d.sort()
+	return d
+}
+
+func outputSource(o Output) string {
+	if servicespec.OutputFrom(o) == "operation" {
+		return "create operation"
+	}
+	return "instance details"
+}
+
+func compositeDoc(c GenComposite) DocPage {
+	children := make([]string, len(c.Children))
+	for i, ch := range c.Children {
+		children[i] = fmt.Sprintf("`%s` (`nubes_%s`)", ch.Name, ch.Service)
+	}
+	d := DocPage{
+		Name: c.Name,
+		Kind: "Resource",
+		Description: "Manages " + strings.Join(children, ", ") + " as one resource. " +
+			"Children are created in this order and rolled back if a later child fails; " +
+			"attributes of a child are prefixed with its name.",
+	}
+	lifecycleDocs(&d, c.DeleteMode, c.ResumeIfExists)
+	for _, ch := range c.Children {
+		d.addAttr(DocAttr{Name: ch.Name + "_id", Type: "String",
+			Description: fmt.Sprintf("Instance UID of the `%s` child.", ch.Name)}, false, true)
+		for _, p := range ch.AllParams {
+			d.addAttr(paramDoc(p, isModifiable(p, ch.ModifyParams)), p.Required, false)
+		}
+	}
+	d.sort()
+	return d
+}
+
+const resourceDocTemplate = `---
+page_title: "nubes_{{.Name}} {{.Kind}} - nubes"
+subcategory: ""
+description: |-
+  {{.Description}}
+---
+
+# nubes_{{.Name}} ({{.Kind}})
+
+{{.Description}}
+
+<!-- schema generated by tools/gen -->
+## Schema
+{{- template "section" (dict "Title" "Required" "Attrs" .Required) }}
+{{- template "section" (dict "Title" "Optional" "Attrs" .Optional) }}
+{{- template "section" (dict "Title" "Read-Only" "Attrs" .ReadOnly) }}
+{{ define "section" }}
+{{- if .Attrs }}
+
+### {{.Title}}
+{{ range .Attrs }}
+- ` + "`{{.Name}}`" + ` ({{.Kind}}) {{.Description}}
+{{- end }}
+{{- end }}
+{{- end }}`
+
+const indexDocTemplate = `---
+page_title: "nubes Provider"
+subcategory: ""
+description: |-
+  Manages Nubes Cloud service instances.
+---
+
+# nubes Provider
+
+Manages Nubes Cloud service instances. Each resource type corresponds to one
+Nubes service; its attributes are the parameters of the service's create and
+modify operations.
+
+## Example Usage
+
+` + "```terraform" + `
+terraform {
+  required_providers {
+    nubes = {
+      source = "terra.k8c.ru/nubes/nubes"
+    }
+  }
+}
+
+# The token can also be set with the NUBES_API_TOKEN environment variable.
+provider "nubes" {
+  api_token = var.nubes_api_token
+}
+` + "```" + `
+
+## Resources
+{{ range .Resources }}
+- [nubes_{{.}}](resources/{{.}}.md)
+{{- end }}
+
+## Data Sources
+{{ range .DataSources }}
+- [nubes_{{.}}](data-sources/{{.}}.md)
+{{- end }}
+`
+
+const allResourcesDocTemplate = `---
+page_title: "All resources - nubes"
+subcategory: ""
+description: |-
+  Every nubes resource with its service and attributes.
+---
+
+# All resources
+
+Modifiable attributes are changed in place; the other params are only sent
+when the instance is created. The lifecycle attributes every resource has
+(` + "`resource_name`, `delete_mode`, `resume_if_exists`, `id`" + `) are left out.
+
+| Resource | Service | Required | Optional | Modifiable | Read-only | Data source |
+|---|---|---|---|---|---|---|
+{{- range . }}
+| [nubes_{{.Name}}](../resources/{{.Name}}.md) | {{.Service}} | {{names .Required}} | {{names .Optional}} | {{names .Modifiable}} | {{names .ReadOnly}} | {{if .DataSource}}[nubes_{{.Name}}](../data-sources/{{.Name}}.md){{else}}—{{end}} |
+{{- end }}
+`
+
+// AllResourcesRow is one resource in the all-resources table.
+type AllResourcesRow struct {
+	Name       string
+	Service    string
+	Required   []string
+	Optional   []string
+	Modifiable []string
+	ReadOnly   []string
+	DataSource bool
+}
+
+func allResourcesRow(page DocPage, service string, dataSource bool) AllResourcesRow {
+	row := AllResourcesRow{Name: page.Name, Service: service, DataSource: dataSource}
+	lifecycle := map[string]bool{"resource_name": true, "delete_mode": true, "resume_if_exists": true, "id": true, "pending_operation": true}
+	add := func(names *[]string, attrs []DocAttr) {
+		for _, a := range attrs {
+			if lifecycle[a.Name] {
+				continue
+			}
+			*names = append(*names, a.Name)
+			if a.Modifiable {
+				row.Modifiable = append(row.Modifiable, a.Name)
+			}
+		}
+	}
+	add(&row.Required, page.Required)
+	add(&row.Optional, page.Optional)
+	add(&row.ReadOnly, page.ReadOnly)
+	sort.Strings(row.Modifiable)
+	return row
+}
+
+// writeDocs renders the provider index, one page per resource and data
+// source, and the all-resources table into docsDir.
+func writeDocs(out generated, docsDir string, services []GenResource, composites []GenComposite) error {
+	pages := make([]DocPage, 0, len(services)+len(composites))
+	rows := make([]AllResourcesRow, 0, len(services)+len(composites))
+	for _, svc := range services {
+		page := resourceDoc(svc)
+		pages = append(pages, page)
+		rows = append(rows, allResourcesRow(page, fmt.Sprint(svc.ServiceID), true))
+	}
+	for _, c := range composites {
+		page := compositeDoc(c)
+		pages = append(pages, page)
+		children := make([]string, len(c.Children))
+		for i, ch := range c.Children {
+			children[i] = fmt.Sprintf("[nubes_%s](../resources/%s.md)", ch.Service, ch.Service)
+		}
+		rows = append(rows, allResourcesRow(page, "composite: "+strings.Join(children, ", "), false))
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+
+	resourceTpl, err := template.New("resourceDoc").Funcs(templateFuncs()).Parse(resourceDocTemplate)
+	if err != nil {
+		return err
+	}
+	indexTpl, err := template.New("indexDoc").Parse(indexDocTemplate)
+	if err != nil {
+		return err
+	}
+	allResourcesTpl, err := template.New("allResourcesDoc").Funcs(template.FuncMap{
+		"names": func(names []string) string {
+			if len(names) == 0 {
+				return "—"
+			}
+			return codeList(names)
+		},
+	}).Parse(allResourcesDocTemplate)
+	if err != nil {
+		return err
+	}
+
+	resourcesDir := filepath.Join(docsDir, "resources")
+	for _, page := range pages {
+		var buf bytes.Buffer
+		if err := resourceTpl.Execute(&buf, page); err != nil {
+			return err
+		}
+		out.add(filepath.Join(resourcesDir, page.Name+".md"), buf.Bytes())
+	}
+
+	dataSourcesDir := filepath.Join(docsDir, "data-sources")
+	for _, svc := range services {
+		var buf bytes.Buffer
+		if err := resourceTpl.Execute(&buf, dataSourceDoc(svc)); err != nil {
+			return err
+		}
+		out.add(filepath.Join(dataSourcesDir, svc.Name+".md"), buf.Bytes())
+	}
+
+	index := struct {
+		Resources   []string
+		DataSources []string
+	}{registryNames(services, composites), registryNames(services, nil)}
+	var buf bytes.Buffer
+	if err := indexTpl.Execute(&buf, index); err != nil {
+		return err
+	}
+	out.add(filepath.Join(docsDir, "index.md"), buf.Bytes())
+
+	var tableBuf bytes.Buffer
+	if err := allResourcesTpl.Execute(&tableBuf, rows); err != nil {
+		return err
+	}
+	out.add(filepath.Join(docsDir, "guides", "all-resources.md"), tableBuf.Bytes())
+	return nil
+}
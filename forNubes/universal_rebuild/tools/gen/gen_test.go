@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// Golden files hold the generated Go code of each testdata case:
+// testdata/<case>/golden/<file>.golden. Regenerate with
+//
+//	go test ./tools/gen -run TestGolden -update
+func TestGolden(t *testing.T) {
+	for _, name := range []string{"no_params", "modify_only", "defaults", "duplicate_codes", "overlay", "outputs"} {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join("testdata", name)
+			out, err := generate(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			goldenDir := filepath.Join(dir, "golden")
+			want := map[string]bool{}
+			for _, p := range out.paths() {
+				if !strings.HasPrefix(p, "internal/resources_gen/") {
+					continue
+				}
+				golden := filepath.Join(goldenDir, filepath.Base(p)+".golden")
+				want[filepath.Base(golden)] = true
+				if *update {
+					if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, out[p], 0o644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				b, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%s: %v (run with -update)", golden, err)
+				}
+				if string(b) != string(out[p]) {
+					t.Errorf("%s differs from %s:\n%s", p, golden, lineDiff(string(b), string(out[p])))
+				}
+			}
+
+			entries, err := os.ReadDir(goldenDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				if !want[e.Name()] {
+					t.Errorf("%s: golden file is no longer generated", filepath.Join(goldenDir, e.Name()))
+				}
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join("testdata", "defaults", "resources_yaml", "service_501.yaml")
+	b, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "resources_yaml"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "resources_yaml", "service_501.yaml"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := generate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourcesDir := filepath.Join("internal", "resources_gen")
+	if problems, err := out.check(root, resourcesDir); err != nil || len(problems) != len(out) {
+		t.Fatalf("before write: want %d missing files, got %d (%v)", len(out), len(problems), err)
+	}
+	if err := out.write(root); err != nil {
+		t.Fatal(err)
+	}
+	if problems, err := out.check(root, resourcesDir); err != nil || len(problems) != 0 {
+		t.Fatalf("after write: %v %v", problems, err)
+	}
+
+	registry := filepath.Join(root, resourcesDir, "registry.go")
+	if err := os.WriteFile(registry, []byte("package resources_gen\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(root, resourcesDir, "removed_resource.go")
+	if err := os.WriteFile(stale, []byte("package resources_gen\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := out.check(root, resourcesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 ||
+		!strings.HasPrefix(problems[0], "internal/resources_gen/registry.go: differs") ||
+		!strings.HasPrefix(problems[1], "internal/resources_gen/removed_resource.go: stale") {
+		t.Fatalf("unexpected problems: %q", problems)
+	}
+}
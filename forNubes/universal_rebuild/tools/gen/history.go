@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"sort"
+
+	"terraform-provider-nubes/internal/servicespec"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Schema history (lock file) per resource: schema_history/<name>.yaml.
+//
+// Every schema version records the attributes it stored in state. When the
+// YAML changes incompatibly (an attribute disappears, is renamed or changes
+// type) the generator appends a new version with the migration from the
+// previous one and emits UpgradeState for every older version. Compatible
+// changes (new attributes) only refresh the latest version: missing attributes
+// decode as null.
+
+type (
+	SchemaHistory    = servicespec.SchemaHistory
+	SchemaVersion    = servicespec.SchemaVersion
+	HistoryMigration = servicespec.HistoryMigration
+	HistoryAttr      = servicespec.HistoryAttr
+)
+
+const historyHeader = "# Code generated by tools/gen. DO NOT EDIT.\n# Schema history: versions of stored attributes and migrations between them.\n"
+
+func historyPath(dir string, name string) string {
+	return filepath.Join(dir, name+".yaml")
+}
+
+func renderHistory(h *SchemaHistory) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(historyHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+	if err := enc.Encode(h); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// updateHistory reconciles the history with the current attributes and
+// reports whether it changed.
+func updateHistory(h *SchemaHistory, name string, attrs []HistoryAttr) (*SchemaHistory, bool) {
+	if h == nil || len(h.Versions) == 0 {
+		return &SchemaHistory{Name: name, Versions: []SchemaVersion{{Version: 0, Attributes: attrs}}}, true
+	}
+
+	latest := h.Versions[len(h.Versions)-1]
+	migration := diffAttributes(latest.Attributes, attrs)
+	if migration == nil {
+		if servicespec.SameAttributes(latest.Attributes, attrs) {
+			return h, false
+		}
+		h.Versions[len(h.Versions)-1].Attributes = attrs
+		return h, true
+	}
+
+	h.Versions = append(h.Versions, SchemaVersion{
+		Version:    latest.Version + 1,
+		Migration:  migration,
+		Attributes: attrs,
+	})
+	return h, true
+}
+
+// diffAttributes returns the migration from prev to cur, or nil when state
+// written with prev still decodes with cur.
+func diffAttributes(prev []HistoryAttr, cur []HistoryAttr) *HistoryMigration {
+	curByKey := make(map[string]HistoryAttr, len(cur))
+	for _, a := range cur {
+		curByKey[a.Key] = a
+	}
+
+	m := &HistoryMigration{}
+	for _, old := range prev {
+		now, ok := curByKey[old.Key]
+		if !ok {
+			m.Drop = append(m.Drop, old.Name)
+			continue
+		}
+		if now.Name != old.Name {
+			if m.Rename == nil {
+				m.Rename = map[string]string{}
+			}
+			m.Rename[old.Name] = now.Name
+		}
+		if now.Type != old.Type {
+			if m.Convert == nil {
+				m.Convert = map[string]string{}
+			}
+			m.Convert[now.Name] = now.Type
+		}
+	}
+	if len(m.Rename) == 0 && len(m.Drop) == 0 && len(m.Convert) == 0 {
+		return nil
+	}
+	sort.Strings(m.Drop)
+	return m
+}
+
+// historyUpgraders builds one upgrader per older version, each chaining the
+// steps up to the latest version.
+func historyUpgraders(h *SchemaHistory) (int64, []StateUpgrader) {
+	if h == nil || len(h.Versions) == 0 {
+		return 0, nil
+	}
+
+	var upgraders []StateUpgrader
+	for i := 0; i < len(h.Versions)-1; i++ {
+		up := StateUpgrader{FromVersion: h.Versions[i].Version}
+		for _, v := range h.Versions[i+1:] {
+			up.Steps = append(up.Steps, stateStep(v.Migration))
+		}
+		upgraders = append(upgraders, up)
+	}
+	return h.Versions[len(h.Versions)-1].Version, upgraders
+}
+
+func stateStep(m *HistoryMigration) StateStep {
+	var step StateStep
+	if m == nil {
+		return step
+	}
+	for _, from := range sortedKeys(m.Rename) {
+		step.Renames = append(step.Renames, AttrRename{From: from, To: m.Rename[from]})
+	}
+	step.Drops = append(step.Drops, m.Drop...)
+	for _, name := range sortedKeys(m.Convert) {
+		step.Converts = append(step.Converts, AttrConvert{Name: name, Type: m.Convert[name]})
+	}
+	return step
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
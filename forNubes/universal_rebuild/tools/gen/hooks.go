@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+)
+
+// Per-service customisation that survives regeneration: overlays from
+// resources_overrides/<name>.yaml are applied to the YAML before building the
+// resource (servicespec.ApplyOverlay), and hand-written hooks in
+// internal/resources_gen/<name>_hooks.go are called from the generated CRUD
+// methods.
+
+// Hooks are the optional methods of a resource's hooks file:
+//
+//	func (r *XResource) beforeCreate(ctx context.Context, data *XModel, params map[int]string) diag.Diagnostics
+//	func (r *XResource) afterCreate(ctx context.Context, data *XModel) diag.Diagnostics
+//	func (r *XResource) beforeUpdate(ctx context.Context, plan *XModel, state *XModel, params map[int]string) diag.Diagnostics
+//	func (r *XResource) afterRead(ctx context.Context, data *XModel) diag.Diagnostics
+type Hooks struct {
+	BeforeCreate bool
+	AfterCreate  bool
+	BeforeUpdate bool
+	AfterRead    bool
+}
+
+func (h Hooks) Any() bool {
+	return h.BeforeCreate || h.AfterCreate || h.BeforeUpdate || h.AfterRead
+}
+
+// findHooks parses the hooks file, if there is one, and reports which hook
+// methods of resourceType it declares.
+func findHooks(path string, resourceType string) (Hooks, error) {
+	var h Hooks
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+		star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		if id, ok := star.X.(*ast.Ident); !ok || id.Name != resourceType {
+			continue
+		}
+		switch fn.Name.Name {
+		case "beforeCreate":
+			h.BeforeCreate = true
+		case "afterCreate":
+			h.AfterCreate = true
+		case "beforeUpdate":
+			h.BeforeUpdate = true
+		case "afterRead":
+			h.AfterRead = true
+		}
+	}
+	return h, nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/servicespec"
+
+	"gopkg.in/yaml.v3"
+)
+
+// `gen lint` checks service YAML files against serviceSchema and the rules
+// the templates rely on, so malformed input is reported as file:line instead
+// of producing broken Go.
+
+type lintIssue struct {
+	Path   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (i lintIssue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", filepath.ToSlash(i.Path), i.Line, i.Column, i.Msg)
+}
+
+func runLint(args []string) int {
+	fset := flag.NewFlagSet("lint", flag.ExitOnError)
+	printSchema := fset.Bool("schema", false, "print the JSON Schema of service YAML files and exit")
+	overridesDir := fset.String("overrides", "resources_overrides", "directory with service overlays")
+	fset.Usage = func() {
+		fmt.Fprintln(fset.Output(), "usage: go run ./tools/gen lint [-schema] [file or dir ...] (default resources_yaml)")
+		fset.PrintDefaults()
+	}
+	fset.Parse(args)
+
+	if *printSchema {
+		b, err := schemaJSON()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		os.Stdout.Write(b)
+		return 0
+	}
+
+	paths := fset.Args()
+	if len(paths) == 0 {
+		paths = []string{"resources_yaml"}
+	}
+	files, err := yamlFiles(paths)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	overlays, err := servicespec.LoadOverlays(*overridesDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	issues, err := lintFiles(files, overlays)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, i := range issues {
+		fmt.Fprintln(os.Stderr, i)
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "%d problem(s) in %d file(s)\n", len(issues), len(files))
+		return 1
+	}
+	return 0
+}
+
+func yamlFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.HasSuffix(d.Name(), ".yaml") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// lintFile is a parsed file that passed the schema.
+type lintFile struct {
+	path string
+	doc  *yaml.Node
+}
+
+// lintFiles returns the problems of all files, sorted by file and line.
+// Names and service IDs must be unique across the files, and composites are
+// checked against the services among them. Services are checked with their
+// overlays applied, as the generator sees them.
+func lintFiles(files []string, overlays map[string]*servicespec.Overlay) ([]lintIssue, error) {
+	c := &schemaChecker{root: serviceSchema()}
+	var services, composites []lintFile
+	for _, path := range files {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		c.path = path
+		var doc yaml.Node
+		if err := yaml.Unmarshal(b, &doc); err != nil {
+			c.issues = append(c.issues, parseIssue(path, err))
+			continue
+		}
+		if len(doc.Content) == 0 {
+			c.issues = append(c.issues, lintIssue{Path: path, Line: 1, Column: 1, Msg: "empty file"})
+			continue
+		}
+		root := doc.Content[0]
+		before := len(c.issues)
+		c.check(root, c.root, "")
+		if len(c.issues) > before {
+			continue
+		}
+		if v := mapValue(root, "kind"); v != nil && v.Value == servicespec.KindComposite {
+			composites = append(composites, lintFile{path, root})
+		} else {
+			services = append(services, lintFile{path, root})
+		}
+	}
+
+	names := map[string]lintIssue{}
+	ids := map[string]lintIssue{}
+	unique := func(f lintFile, key string, seen map[string]lintIssue) {
+		v := mapValue(f.doc, key)
+		if v == nil {
+			return
+		}
+		c.path = f.path
+		if first, ok := seen[v.Value]; ok {
+			c.errorf(v, "%s %s is already used at %s:%d", key, v.Value, filepath.ToSlash(first.Path), first.Line)
+			return
+		}
+		seen[v.Value] = lintIssue{Path: f.path, Line: v.Line}
+	}
+
+	byName := map[string]GenResource{}
+	for _, f := range services {
+		unique(f, "name", names)
+		unique(f, "service_id", ids)
+		var o *servicespec.Overlay
+		if v := mapValue(f.doc, "name"); v != nil {
+			o = overlays[v.Value]
+		}
+		before := len(c.issues)
+		lintService(c, f.doc, o)
+		if len(c.issues) > before {
+			continue
+		}
+		// Anything the line-level checks missed still fails like the generator would.
+		var svc ServiceYAML
+		if err := f.doc.Decode(&svc); err != nil {
+			c.errorf(f.doc, "%v", err)
+			continue
+		}
+		if err := servicespec.ApplyOverlay(&svc, o); err != nil {
+			c.errorf(f.doc, "%v", err)
+			continue
+		}
+		r, err := servicespec.Build(svc)
+		if err != nil {
+			c.errorf(f.doc, "%v", err)
+			continue
+		}
+		byName[r.Name] = buildService(r)
+	}
+	for _, f := range composites {
+		unique(f, "name", names)
+		c.path = f.path
+		var comp CompositeYAML
+		if err := f.doc.Decode(&comp); err != nil {
+			c.errorf(f.doc, "%v", err)
+			continue
+		}
+		if _, err := buildComposite(comp, byName); err != nil {
+			c.errorf(mapValue(f.doc, "children"), "%v", err)
+		}
+	}
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		a, b := c.issues[i], c.issues[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Line < b.Line
+	})
+	return c.issues, nil
+}
+
+var yamlLine = regexp.MustCompile(`^line (\d+): `)
+
+// parseIssue places a YAML syntax error on the line the parser reports.
+func parseIssue(path string, err error) lintIssue {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	line := 1
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = msg[len(m[0]):]
+	}
+	return lintIssue{Path: path, Line: line, Column: 1, Msg: msg}
+}
+
+// lintService checks what the schema cannot express: unique param IDs and
+// codes, modify params typed like their create counterparts, defaults and
+// validation keys that fit the param type. A type fixed by the overlay o
+// replaces the one in the file.
+func lintService(c *schemaChecker, doc *yaml.Node, o *servicespec.Overlay) {
+	type entry struct {
+		param Param
+		node  *yaml.Node
+	}
+	ids := map[int]*yaml.Node{}
+	create := map[string]entry{}
+	for _, op := range []string{"create", "modify"} {
+		params := mapValue(mapValue(doc, op), "params")
+		if params == nil {
+			continue
+		}
+		codes := map[string]*yaml.Node{}
+		for i, n := range params.Content {
+			field := fmt.Sprintf("%s.params[%d]", op, i)
+			var p Param
+			if err := n.Decode(&p); err != nil {
+				c.errorf(n, "%s: %v", field, err)
+				continue
+			}
+			if ov, ok := o.Param(p.Code); ok && ov.Type != "" {
+				p.Type = ov.Type
+			}
+			if first, ok := ids[p.ID]; ok {
+				c.errorf(mapValue(n, "id"), "%s: duplicate param id %d (first at line %d)", field, p.ID, first.Line)
+			} else {
+				ids[p.ID] = mapValue(n, "id")
+			}
+			key := strings.ToLower(strings.TrimSpace(p.Code))
+			if first, ok := codes[key]; ok {
+				c.errorf(mapValue(n, "code"), "%s: duplicate code %s in %s (first at line %d)", field, p.Code, op, first.Line)
+			} else {
+				codes[key] = mapValue(n, "code")
+			}
+			if op == "create" {
+				create[key] = entry{p, n}
+			} else if base, ok := create[key]; ok && servicespec.CanonicalType(base.param.Type) != servicespec.CanonicalType(p.Type) {
+				c.errorf(mapValue(n, "type"), "%s: type %s differs from create param %s (%s, line %d)",
+					field, p.Type, base.param.Code, base.param.Type, base.node.Line)
+			}
+			if v := mapValue(n, "default"); v != nil {
+				if err := servicespec.CheckDefault(p, v.Value); err != nil {
+					c.errorf(v, "%s.default: %v", field, err)
+				}
+			}
+			if err := servicespec.CheckParam(p); err != nil {
+				c.errorf(n, "%s: %v", field, err)
+			}
+		}
+	}
+	if polling := mapValue(doc, "polling"); polling != nil {
+		var spec servicespec.PollSpec
+		if err := polling.Decode(&spec); err == nil {
+			if _, err := spec.Parse(); err != nil {
+				c.errorf(polling, "polling: %v", err)
+			}
+		}
+	}
+}
+
+// mapValue returns the value node of key in a mapping node, or nil.
+func mapValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
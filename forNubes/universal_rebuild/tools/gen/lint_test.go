@@ -0,0 +1,64 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+func TestLint(t *testing.T) {
+	files, err := yamlFiles([]string{filepath.Join("testdata", "lint")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The overlay fixes the modify type of overlaid.yaml.
+	overlays := map[string]*servicespec.Overlay{
+		"overlaid": {Params: map[string]servicespec.OverlayParam{"diskSize": {Type: "int64"}}},
+	}
+	issues, err := lintFiles(files, overlays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, i := range issues {
+		got = append(got, i.String())
+	}
+
+	want := []string{
+		`testdata/lint/bad_service.yaml:1:7: name: "bad-service" does not match ^[A-Za-z][A-Za-z0-9_]*$`,
+		`testdata/lint/bad_service.yaml:16:11: create.params[2].colour: unknown key`,
+		`testdata/lint/broken.yaml:1:1: did not find expected ',' or ']'`,
+		`testdata/lint/composite.yaml:4:5: child disk: unknown service "missing"`,
+		`testdata/lint/semantic.yaml:1:7: name semantic is already used at testdata/lint/clash.yaml:1`,
+		`testdata/lint/semantic.yaml:2:13: service_id 601 is already used at testdata/lint/clash.yaml:2`,
+		`testdata/lint/semantic.yaml:9:20: create.params[0].default: "10G" is not an int64`,
+		`testdata/lint/semantic.yaml:10:15: create.params[1]: duplicate param id 6101 (first at line 5)`,
+		`testdata/lint/semantic.yaml:13:20: create.params[1].default: "yes" is not a bool (true or false)`,
+		`testdata/lint/semantic.yaml:18:17: modify.params[0]: type string differs from create param diskSize (int64, line 5)`,
+		`testdata/lint/semantic.yaml:20:17: modify.params[1]: duplicate code DISKSIZE in modify (first at line 17)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("lint issues:\n%s", lineDiff(strings.Join(want, "\n"), strings.Join(got, "\n")))
+	}
+}
+
+// The service files in the tree must stay clean.
+func TestLintTree(t *testing.T) {
+	files, err := yamlFiles([]string{filepath.Join("..", "..", "resources_yaml")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlays, err := servicespec.LoadOverlays(filepath.Join("..", "..", "resources_overrides"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues, err := lintFiles(files, overlays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range issues {
+		t.Error(i)
+	}
+}
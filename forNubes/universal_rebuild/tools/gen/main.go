@@ -2,50 +2,50 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"go/format"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"text/template"
 
-	"gopkg.in/yaml.v3"
+	"terraform-provider-nubes/internal/servicespec"
+	"terraform-provider-nubes/tools/examplegen"
 )
 
-type Param struct {
-	ID       int    `yaml:"id"`
-	Code     string `yaml:"code"`
-	Type     string `yaml:"type"`
-	Required bool   `yaml:"required"`
-	Default  string `yaml:"default"`
-}
-
-type ServiceYAML struct {
-	Name      string `yaml:"name"`
-	ServiceID int    `yaml:"service_id"`
-	Create    struct {
-		Params []Param `yaml:"params"`
-	} `yaml:"create"`
-	Modify struct {
-		Params []Param `yaml:"params"`
-	} `yaml:"modify"`
-	Lifecycle struct {
-		DeleteModeDefault     string `yaml:"delete_mode_default"`
-		ResumeIfExistsDefault bool   `yaml:"resume_if_exists_default"`
-	} `yaml:"lifecycle"`
-}
+// The service YAML model and its normalisation are shared with the runtime
+// resources and the examples (internal/servicespec).
+type (
+	Param       = servicespec.Param
+	ServiceYAML = servicespec.Service
+	Output      = servicespec.Output
+	FixedParam  = servicespec.FixedParam
+)
 
 type GenResource struct {
-	Name              string
-	ServiceID         int
-	CreateParams      []Param
-	CreateFixedParams []FixedParam
-	ModifyParams      []Param
-	AllParams         []Param
-	DeleteMode        string
-	ResumeIfExists    bool
+	servicespec.Resource
+
+	HasWriteOnly bool
+
+	SchemaVersion  int64
+	StateUpgraders []StateUpgrader
+
+	// ParamRefs maps the create/modify param IDs of RefParams to the
+	// referenced service.
+	ParamRefs []ParamRef
+
+	PollExpr            *PollExpr
+	Hooks               Hooks
+	NeedsBoolModifier   bool
+	NeedsInt64Modifier  bool
+	NeedsStringModifier bool
+
+	NeedsValidator       bool
+	NeedsStringValidator bool
+	NeedsInt64Validator  bool
+	NeedsRegexp          bool
 
 	UsesBool           bool
 	UsesInt64          bool
@@ -57,132 +57,301 @@ type GenResource struct {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+
+	check := flag.Bool("check", false, "render to memory and fail if the files on disk differ")
+	flag.Parse()
+
 	root, err := os.Getwd()
 	if err != nil {
 		panic(err)
 	}
-	resourcesDir := filepath.Join(root, "resources_yaml")
-	outDir := filepath.Join(root, "internal", "resources_gen")
-
-	services, err := loadServices(resourcesDir)
+	out, err := generate(root)
 	if err != nil {
 		panic(err)
 	}
 
-	for _, svc := range services {
-		if err := writeResource(outDir, svc); err != nil {
+	if *check {
+		problems, err := out.check(root, filepath.Join("internal", "resources_gen"))
+		if err != nil {
 			panic(err)
 		}
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		if len(problems) > 0 {
+			fmt.Fprintf(os.Stderr, "%d generated file(s) out of date; run go run ./tools/gen\n", len(problems))
+			os.Exit(1)
+		}
+		return
 	}
-	if err := writeRegistry(outDir, services); err != nil {
+	if err := out.write(root); err != nil {
 		panic(err)
 	}
 }
 
-func loadServices(dir string) ([]GenResource, error) {
-	var services []GenResource
-	walkErr := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+// generate renders every generated file for the tree at root. Paths in the
+// result are relative to root.
+func generate(root string) (generated, error) {
+	resourcesDir := filepath.Join(root, "resources_yaml")
+	historyDir := "schema_history"
+	overridesDir := "resources_overrides"
+	outDir := filepath.Join("internal", "resources_gen")
+	docsDir := "docs"
+	examplesDir := filepath.Join("examples", "resources")
+	out := generated{}
+
+	overlays, err := servicespec.LoadOverlays(filepath.Join(root, overridesDir))
+	if err != nil {
+		return nil, err
+	}
+	services, err := loadServices(resourcesDir, overlays)
+	if err != nil {
+		return nil, err
+	}
+	for i := range services {
+		svc := &services[i]
+		svc.Hooks, err = findHooks(filepath.Join(root, outDir, svc.Name+"_hooks.go"), toCamel(svc.Name)+"Resource")
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if d.IsDir() || !strings.HasSuffix(d.Name(), ".yaml") {
-			return nil
+		svc.SchemaVersion, svc.StateUpgraders, err = applySchemaHistory(out, root, historyDir, svc.Name, servicespec.StateAttributes(svc.Resource))
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		b, err := os.ReadFile(path)
+	composites, err := loadComposites(resourcesDir, services)
+	if err != nil {
+		return nil, err
+	}
+	for i := range composites {
+		c := &composites[i]
+		c.SchemaVersion, c.StateUpgraders, err = applySchemaHistory(out, root, historyDir, c.Name, compositeStateAttributes(*c))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		var svc ServiceYAML
-		if err := yaml.Unmarshal(b, &svc); err != nil {
-			return err
+	}
+
+	for _, svc := range services {
+		if err := writeResource(out, outDir, svc); err != nil {
+			return nil, err
+		}
+		if err := writeDataSource(out, outDir, svc); err != nil {
+			return nil, err
 		}
+	}
+	for _, c := range composites {
+		if err := writeComposite(out, outDir, c); err != nil {
+			return nil, err
+		}
+	}
+	var hooked []string
+	for _, svc := range services {
+		if svc.Hooks.Any() {
+			hooked = append(hooked, svc.Name)
+		}
+	}
+	writeRegistry(out, outDir, registryNames(services, composites), registryNames(services, nil), hooked)
+	schemaDoc, err := schemaJSON()
+	if err != nil {
+		return nil, err
+	}
+	out.add(filepath.Join("resources_yaml", schemaFile), schemaDoc)
+	if err := writeDocs(out, docsDir, services, composites); err != nil {
+		return nil, err
+	}
 
-		createParams, createFixed := splitCreateParams(svc.Name, svc.Create.Params)
-		modifyParams := svc.Modify.Params
-		modifyParams = normalizeModifyParams(createParams, modifyParams)
-		allParams := mergeParams(createParams, modifyParams)
-		gr := GenResource{
-			Name:              svc.Name,
-			ServiceID:         svc.ServiceID,
-			CreateParams:      createParams,
-			CreateFixedParams: createFixed,
-			ModifyParams:      modifyParams,
-			AllParams:         allParams,
-			DeleteMode:        svc.Lifecycle.DeleteModeDefault,
-			ResumeIfExists:    svc.Lifecycle.ResumeIfExistsDefault,
+	resources := make([]servicespec.Resource, len(services))
+	for i, svc := range services {
+		resources[i] = svc.Resource
+	}
+	examples := examplegen.FromResources(resources)
+	all := examplegen.ByName(examples)
+	for _, ex := range examples {
+		for name, b := range examplegen.Render(ex, all) {
+			out.add(filepath.Join(examplesDir, "nubes_"+ex.Name, name), b)
 		}
+	}
+	return out, nil
+}
 
-		analyzeParams := func(p Param) {
-			switch strings.ToLower(p.Type) {
-			case "bool":
-				gr.UsesBool = true
-				if p.Default != "" && !p.Required {
-					gr.NeedsBoolDefault = true
-					gr.HasDefaults = true
-				}
-			case "int", "int64", "number":
-				gr.UsesInt64 = true
-				if p.Default != "" && !p.Required {
-					gr.NeedsInt64Default = true
-					gr.HasDefaults = true
-				}
-			default:
-				gr.UsesString = true
-				if p.Default != "" && !p.Required {
-					gr.NeedsStringDefault = true
-					gr.HasDefaults = true
-				}
-			}
+// applySchemaHistory updates schema_history/<name>.yaml for the resource and
+// derives its schema version and state upgraders. The history file is always
+// part of the output, so a check also catches an outdated lock file.
+func applySchemaHistory(out generated, root string, historyDir string, name string, attrs []HistoryAttr) (int64, []StateUpgrader, error) {
+	h, err := servicespec.LoadHistory(os.DirFS(filepath.Join(root, historyDir)), name)
+	if err != nil {
+		return 0, nil, err
+	}
+	h, _ = updateHistory(h, name, attrs)
+	b, err := renderHistory(h)
+	if err != nil {
+		return 0, nil, err
+	}
+	out.add(historyPath(historyDir, name), b)
+	version, upgraders := historyUpgraders(h)
+	return version, upgraders, nil
+}
+
+func loadServices(dir string, overlays map[string]*servicespec.Overlay) ([]GenResource, error) {
+	resources, err := servicespec.Load(os.DirFS(dir), overlays)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]GenResource, len(resources))
+	for i, r := range resources {
+		services[i] = buildService(r)
+	}
+	return services, nil
+}
+
+// buildService adds to a normalised resource what the templates need: the
+// poll policy expressions and the imports and helpers the file uses.
+func buildService(r servicespec.Resource) GenResource {
+	gr := GenResource{Resource: r}
+	if r.Polling != nil {
+		gr.PollExpr = pollExpr(*r.Polling)
+	}
+	for _, p := range r.AllParams {
+		if p.WriteOnly {
+			gr.HasWriteOnly = true
 		}
-		for _, p := range gr.AllParams {
-			analyzeParams(p)
+	}
+	gr.ParamRefs = paramRefs(r.CreateParams, r.ModifyParams)
+	for _, o := range r.Outputs {
+		switch servicespec.CanonicalType(o.Type) {
+		case "bool":
+			gr.NeedsBoolModifier = true
+		case "int64":
+			gr.NeedsInt64Modifier = true
+		default:
+			gr.NeedsStringModifier = true
 		}
-
-		// Schema defaults apply only to create params
-		gr.NeedsBoolDefault = false
-		gr.NeedsInt64Default = false
-		gr.NeedsStringDefault = false
-		for _, p := range gr.AllParams {
-			if p.Default == "" || p.Required {
-				continue
+	}
+	for _, p := range r.AllParams {
+		for _, v := range paramValidators(p) {
+			gr.NeedsValidator = true
+			switch {
+			case strings.HasPrefix(v, "stringvalidator."):
+				gr.NeedsStringValidator = true
+			case strings.HasPrefix(v, "int64validator."):
+				gr.NeedsInt64Validator = true
 			}
-			switch strings.ToLower(p.Type) {
-			case "bool":
+			if strings.Contains(v, "regexp.") {
+				gr.NeedsRegexp = true
+			}
+		}
+	}
+
+	analyzeParams := func(p Param) {
+		switch strings.ToLower(p.Type) {
+		case "bool":
+			gr.UsesBool = true
+			if p.Default != "" && !p.Required {
 				gr.NeedsBoolDefault = true
-			case "int", "int64", "number":
+				gr.HasDefaults = true
+			}
+		case "int", "int64", "number":
+			gr.UsesInt64 = true
+			if p.Default != "" && !p.Required {
 				gr.NeedsInt64Default = true
-			default:
+				gr.HasDefaults = true
+			}
+		default:
+			gr.UsesString = true
+			if p.Default != "" && !p.Required {
 				gr.NeedsStringDefault = true
+				gr.HasDefaults = true
 			}
 		}
+	}
+	for _, p := range gr.AllParams {
+		analyzeParams(p)
+	}
 
-		services = append(services, gr)
-		return nil
-	})
+	// Schema defaults apply only to create params
+	gr.NeedsBoolDefault = false
+	gr.NeedsInt64Default = false
+	gr.NeedsStringDefault = false
+	for _, p := range gr.AllParams {
+		if !hasSchemaDefault(p) {
+			continue
+		}
+		switch strings.ToLower(p.Type) {
+		case "bool":
+			gr.NeedsBoolDefault = true
+		case "int", "int64", "number":
+			gr.NeedsInt64Default = true
+		default:
+			gr.NeedsStringDefault = true
+		}
+	}
+	return gr
+}
 
-	if walkErr != nil {
-		return nil, walkErr
+type ParamRef struct {
+	ID        int
+	ServiceID int
+}
+
+func paramRefs(createParams []Param, modifyParams []Param) []ParamRef {
+	var refs []ParamRef
+	for _, params := range [][]Param{createParams, modifyParams} {
+		for _, p := range params {
+			if p.RefServiceID > 0 {
+				refs = append(refs, ParamRef{ID: p.ID, ServiceID: p.RefServiceID})
+			}
+		}
 	}
+	return refs
+}
 
-	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
-	return services, nil
+// refDescription documents a reference attribute.
+func refDescription(p Param) string {
+	if p.RefTarget != "" {
+		return fmt.Sprintf("Reference to a `nubes_%s` instance (service %d): its id (UUID) or resource_name.", p.RefTarget, p.RefServiceID)
+	}
+	return fmt.Sprintf("Reference to an instance of service %d: its id (UUID) or resource_name.", p.RefServiceID)
+}
+
+// attrDescription is the attribute description: the API label and
+// description of the param, then the reference target if any.
+func attrDescription(p Param) string {
+	var parts []string
+	label := strings.TrimSpace(p.Label)
+	descr := strings.TrimSpace(p.Description)
+	if label != "" {
+		parts = append(parts, sentence(label))
+	}
+	if descr != "" && descr != label {
+		parts = append(parts, sentence(descr))
+	}
+	if p.RefServiceID > 0 {
+		parts = append(parts, refDescription(p))
+	}
+	return strings.Join(parts, " ")
 }
 
-func writeResource(outDir string, svc GenResource) error {
+func sentence(s string) string {
+	if strings.HasSuffix(s, ".") || strings.HasSuffix(s, "!") || strings.HasSuffix(s, "?") {
+		return s
+	}
+	return s + "."
+}
+
+// plainText strips the Markdown used in generated descriptions.
+func plainText(s string) string {
+	return strings.ReplaceAll(s, "`", "")
+}
+
+func writeResource(out generated, outDir string, svc GenResource) error {
 	fileName := fmt.Sprintf("%s_resource.go", svc.Name)
 	filePath := filepath.Join(outDir, fileName)
 
-	tpl, err := template.New("resource").Funcs(template.FuncMap{
-		"ToCamel":          toCamel,
-		"ToSnake":          toSnake,
-		"ParamType":        paramType,
-		"ParamDefault":     paramDefault,
-		"ParamDefaultExpr": paramDefaultExpr,
-		"ParamFormat":      paramFormat,
-		"FixedParamExpr":   fixedParamExpr,
-		"bt":               func() string { return "`" },
-	}).Parse(resourceTemplate)
+	tpl, err := template.New("resource").Funcs(templateFuncs()).Parse(resourceTemplate)
 	if err != nil {
 		return err
 	}
@@ -197,20 +366,86 @@ func writeResource(outDir string, svc GenResource) error {
 		formatted = buf.Bytes()
 	}
 
-	return os.WriteFile(filePath, formatted, 0644)
+	out.add(filePath, formatted)
+	return nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"ToCamel":          toCamel,
+		"ToLowerCamel":     toLowerCamel,
+		"ToSnake":          servicespec.ToSnake,
+		"AttrName":         servicespec.AttrName,
+		"ParamType":        paramType,
+		"ParamDefault":     paramDefault,
+		"HasSchemaDefault": hasSchemaDefault,
+		"ParamDefaultExpr": paramDefaultExpr,
+		"ParamFormat":      paramFormat,
+		"FixedParamExpr":   fixedParamExpr,
+		"Validators":       paramValidators,
+		"AttrDescription":  attrDescription,
+		"PlainText":        plainText,
+		"ValidatorKind":    validatorKind,
+		"OutputKind":       outputKind,
+		"OutputFrom":       servicespec.OutputFrom,
+		"ToLower":          strings.ToLower,
+		"dict": func(kv ...interface{}) map[string]interface{} {
+			m := make(map[string]interface{}, len(kv)/2)
+			for i := 0; i+1 < len(kv); i += 2 {
+				m[kv[i].(string)] = kv[i+1]
+			}
+			return m
+		},
+		"bt": func() string { return "`" },
+	}
+}
+
+func registryNames(services []GenResource, composites []GenComposite) []string {
+	names := make([]string, 0, len(services)+len(composites))
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+	for _, c := range composites {
+		names = append(names, c.Name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-func writeRegistry(outDir string, services []GenResource) error {
+func writeRegistry(out generated, outDir string, names []string, dataSourceNames []string, hooked []string) {
 	var buf bytes.Buffer
 	buf.WriteString("package resources_gen\n\n")
-	buf.WriteString("import \"github.com/hashicorp/terraform-plugin-framework/resource\"\n\n")
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"github.com/hashicorp/terraform-plugin-framework/datasource\"\n")
+	buf.WriteString("\t\"github.com/hashicorp/terraform-plugin-framework/resource\"\n")
+	buf.WriteString(")\n\n")
 	buf.WriteString("// Code generated by tools/gen. DO NOT EDIT.\n")
 	buf.WriteString("func AllResources() []func() resource.Resource {\n")
 	buf.WriteString("\treturn []func() resource.Resource{\n")
-	for _, svc := range services {
-		buf.WriteString(fmt.Sprintf("\t\tNew%[1]sResource,\n", toCamel(svc.Name)))
+	for _, name := range names {
+		buf.WriteString(fmt.Sprintf("\t\tNew%[1]sResource,\n", toCamel(name)))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+	buf.WriteString("func AllDataSources() []func() datasource.DataSource {\n")
+	buf.WriteString("\treturn []func() datasource.DataSource{\n")
+	for _, name := range dataSourceNames {
+		buf.WriteString(fmt.Sprintf("\t\tNew%[1]sDataSource,\n", toCamel(name)))
 	}
 	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+	buf.WriteString("// ServicesWithHooks lists the resources that have a hooks file; the runtime\n")
+	buf.WriteString("// resources (build tag runtime_resources) leave them to the generated code.\n")
+	buf.WriteString("func ServicesWithHooks() []string {\n")
+	if len(hooked) == 0 {
+		buf.WriteString("\treturn nil\n")
+	} else {
+		buf.WriteString("\treturn []string{\n")
+		for _, name := range hooked {
+			buf.WriteString(fmt.Sprintf("\t\t%q,\n", name))
+		}
+		buf.WriteString("\t}\n")
+	}
 	buf.WriteString("}\n")
 
 	formatted, err := format.Source(buf.Bytes())
@@ -218,7 +453,7 @@ func writeRegistry(outDir string, services []GenResource) error {
 		formatted = buf.Bytes()
 	}
 
-	return os.WriteFile(filepath.Join(outDir, "registry.go"), formatted, 0644)
+	out.add(filepath.Join(outDir, "registry.go"), formatted)
 }
 
 func toCamel(s string) string {
@@ -232,15 +467,16 @@ func toCamel(s string) string {
 	return strings.Join(parts, "")
 }
 
-func toSnake(s string) string {
-	var out []rune
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			out = append(out, '_')
-		}
-		out = append(out, rune(strings.ToLower(string(r))[0]))
+func toLowerCamel(s string) string {
+	c := toCamel(s)
+	if c == "" {
+		return c
 	}
-	return string(out)
+	return strings.ToLower(c[:1]) + c[1:]
+}
+
+func outputKind(o Output) string {
+	return validatorKind(Param{Type: o.Type})
 }
 
 func paramType(p Param) string {
@@ -258,6 +494,12 @@ func paramDefault(p Param) string {
 	return p.Default
 }
 
+// hasSchemaDefault reports whether the attribute gets a static schema default.
+// Write-only attributes cannot be computed, so their defaults are left to the API.
+func hasSchemaDefault(p Param) bool {
+	return p.Default != "" && !p.Required && !p.WriteOnly
+}
+
 func paramDefaultExpr(p Param) string {
 	if p.Default == "" {
 		return ""
@@ -288,7 +530,13 @@ const resourceTemplate = `package resources_gen
 
 import (
 	"context"
+	{{- if .NeedsRegexp }}
+	"regexp"
+	{{- end }}
 	"strings"
+	{{- if .Polling }}
+	"time"
+	{{- end }}
 
 	"terraform-provider-nubes/internal/core"
 	"terraform-provider-nubes/internal/resources_core"
@@ -296,10 +544,34 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	{{- if .HasWriteOnly }}
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	{{- end }}
 	{{- if .NeedsInt64Default }}
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	{{- end }}
+	{{- if .Outputs }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	{{- end }}
+	{{- if .NeedsBoolModifier }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	{{- end }}
+	{{- if .NeedsInt64Modifier }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	{{- end }}
+	{{- if .NeedsStringModifier }}
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	{{- end }}
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	{{- if .NeedsStringValidator }}
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	{{- end }}
+	{{- if .NeedsInt64Validator }}
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	{{- end }}
+	{{- if .NeedsValidator }}
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	{{- end }}
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -309,6 +581,7 @@ import (
 
 var _ resource.Resource = &{{ToCamel .Name}}Resource{}
 var _ resource.ResourceWithModifyPlan = &{{ToCamel .Name}}Resource{}
+var _ resource.ResourceWithUpgradeState = &{{ToCamel .Name}}Resource{}
 
 type {{ToCamel .Name}}Resource struct {
 	client *core.UniversalClient
@@ -318,12 +591,48 @@ type {{ToCamel .Name}}Model struct {
 	ID            types.String {{bt}}tfsdk:"id"{{bt}}
 	ResourceName  types.String {{bt}}tfsdk:"resource_name"{{bt}}
 {{- range .AllParams }}
-	{{ToCamel .Code}} {{ParamType .}} {{bt}}tfsdk:"{{ToSnake .Code}}"{{bt}}
+	{{ToCamel .Code}} {{ParamType .}} {{bt}}tfsdk:"{{AttrName .}}"{{bt}}
+{{- if .WriteOnly }}
+	{{ToCamel .Code}}Version types.Int64 {{bt}}tfsdk:"{{AttrName .}}_version"{{bt}}
+{{- end }}
+{{- end }}
+{{- range .Outputs }}
+	{{ToCamel .Name}} types.{{OutputKind .}} {{bt}}tfsdk:"{{.Name}}"{{bt}}
 {{- end }}
+	PendingOperation types.String {{bt}}tfsdk:"pending_operation"{{bt}}
 	DeleteMode     types.String {{bt}}tfsdk:"delete_mode"{{bt}}
 	ResumeIfExists types.Bool   {{bt}}tfsdk:"resume_if_exists"{{bt}}
 }
 
+{{ if .Polling }}
+// {{ToLowerCamel .Name}}PollPolicy overrides operation polling for this service.
+var {{ToLowerCamel .Name}}PollPolicy = core.PollPolicy{
+{{- with .PollExpr }}
+{{- if .Initial }}
+	Initial: {{.Initial}},
+{{- end }}
+{{- if .Max }}
+	Max: {{.Max}},
+{{- end }}
+{{- if .Multiplier }}
+	Multiplier: {{.Multiplier}},
+{{- end }}
+{{- if .Timeout }}
+	Timeout: {{.Timeout}},
+{{- end }}
+{{- end }}
+}
+
+{{ end -}}
+{{ if .Outputs }}
+// {{ToLowerCamel .Name}}Outputs are the computed attributes declared in the service YAML.
+var {{ToLowerCamel .Name}}Outputs = []resources_core.Output{
+{{- range .Outputs }}
+	{Attribute: "{{.Name}}", From: "{{OutputFrom .}}", Path: {{printf "%q" .Path}}},
+{{- end }}
+}
+
+{{ end -}}
 func New{{ToCamel .Name}}Resource() resource.Resource {
 	return &{{ToCamel .Name}}Resource{}
 }
@@ -337,12 +646,51 @@ func (r *{{ToCamel .Name}}Resource) Schema(ctx context.Context, req resource.Sch
 		"id":           schema.StringAttribute{Computed: true},
 		"resource_name": schema.StringAttribute{Required: true},
 {{- range .AllParams }}
-		"{{ToSnake .Code}}": schema.{{if eq (ParamType .) "types.Bool"}}Bool{{else if eq (ParamType .) "types.Int64"}}Int64{{else}}String{{end}}Attribute{
+		"{{AttrName .}}": schema.{{if eq (ParamType .) "types.Bool"}}Bool{{else if eq (ParamType .) "types.Int64"}}Int64{{else}}String{{end}}Attribute{
 			{{if .Required}}Required: true,{{else}}Optional: true,{{end}}
-			{{if and (ParamDefault .) (not .Required)}}Computed: true,{{end}}
-			{{if and (ParamDefault .) (not .Required)}}Default: {{ParamDefaultExpr .}},{{end}}
+			{{- if HasSchemaDefault .}}
+			Computed: true,
+			Default: {{ParamDefaultExpr .}},
+			{{- end}}
+			{{- if .Sensitive}}
+			Sensitive: true,
+			{{- end}}
+			{{- if .WriteOnly}}
+			WriteOnly: true,
+			{{- end}}
+			{{- with AttrDescription .}}
+			Description:         {{printf "%q" (PlainText .)}},
+			MarkdownDescription: {{printf "%q" .}},
+			{{- end}}
+			{{- if Validators .}}
+			Validators: []validator.{{ValidatorKind .}}{
+				{{- range Validators .}}
+				{{.}},
+				{{- end}}
+			},
+			{{- end}}
+		},
+{{- if .WriteOnly }}
+		"{{AttrName .}}_version": schema.Int64Attribute{
+			Optional: true,
+		},
+{{- end }}
+{{- end }}
+{{- range .Outputs }}
+		"{{.Name}}": schema.{{OutputKind .}}Attribute{
+			Computed: true,
+			{{- if .Sensitive}}
+			Sensitive: true,
+			{{- end}}
+			PlanModifiers: []planmodifier.{{OutputKind .}}{
+				{{ToLower (OutputKind .)}}planmodifier.UseStateForUnknown(),
+			},
 		},
 {{- end }}
+		"pending_operation": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "UID of an operation that was still running when Terraform stopped waiting; checked and cleared on refresh.",
+		},
 		"delete_mode": schema.StringAttribute{
 			Optional: true,
 			Computed: true,
@@ -355,7 +703,44 @@ func (r *{{ToCamel .Name}}Resource) Schema(ctx context.Context, req resource.Sch
 		},
 	}
 
-	resp.Schema = schema.Schema{Attributes: attrs}
+	resp.Schema = schema.Schema{
+		Version:    {{.SchemaVersion}},
+		Attributes: attrs,
+	}
+}
+
+func (r *{{ToCamel .Name}}Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+{{- range .StateUpgraders }}
+		{{.FromVersion}}: resources_core.MigrateState(
+{{- range .Steps }}
+			resources_core.StateMigration{
+{{- if .Renames }}
+				Rename: map[string]string{
+{{- range .Renames }}
+					"{{.From}}": "{{.To}}",
+{{- end }}
+				},
+{{- end }}
+{{- if .Drops }}
+				Drop: []string{
+{{- range .Drops }}
+					"{{.}}",
+{{- end }}
+				},
+{{- end }}
+{{- if .Converts }}
+				Convert: map[string]string{
+{{- range .Converts }}
+					"{{.Name}}": "{{.Type}}",
+{{- end }}
+				},
+{{- end }}
+			},
+{{- end }}
+		),
+{{- end }}
+	}
 }
 
 func (r *{{ToCamel .Name}}Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -371,6 +756,17 @@ func (r *{{ToCamel .Name}}Resource) ModifyPlan(ctx context.Context, req resource
 	if config == nil {
 		return
 	}
+{{- if .RefParams }}
+
+	resp.Diagnostics.Append(resources_core.ValidateReferences(ctx, r.client,
+{{- range .RefParams }}
+		resources_core.Reference{Attribute: "{{AttrName .}}", ServiceID: {{.RefServiceID}}, Value: config.{{ToCamel .Code}}},
+{{- end }}
+	)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- end }}
 
 	var state *{{ToCamel .Name}}Model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -420,10 +816,26 @@ func (r *{{ToCamel .Name}}Resource) ModifyPlan(ctx context.Context, req resource
 func (r *{{ToCamel .Name}}Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data {{ToCamel .Name}}Model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+{{- range .AllParams }}
+{{- if .WriteOnly }}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("{{AttrName .}}"), &data.{{ToCamel .Code}})...)
+{{- end }}
+{{- end }}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, opLog := core.WithOperationLog(ctx)
+{{- if .SensitiveParamIDs }}
+	ctx = core.WithSensitiveParams(ctx{{range .SensitiveParamIDs}}, {{.}}{{end}})
+{{- end }}
+{{- if .ParamRefs }}
+	ctx = core.WithParamRefs(ctx, map[int]int{ {{- range $i, $r := .ParamRefs}}{{if $i}}, {{end}}{{$r.ID}}: {{$r.ServiceID}}{{end -}} })
+{{- end }}
+{{- if .Polling }}
+	ctx = core.WithPollPolicy(ctx, {{ToLowerCamel .Name}}PollPolicy)
+{{- end }}
+
 	resourceName := data.ResourceName.ValueString()
 	if r.client != nil && !data.ResumeIfExists.IsNull() && !data.ResumeIfExists.IsUnknown() && data.ResumeIfExists.ValueBool() {
 		existing, err := r.client.FindInstanceByDisplayName(ctx, {{.ServiceID}}, resourceName)
@@ -444,21 +856,64 @@ func (r *{{ToCamel .Name}}Resource) Create(ctx context.Context, req resource.Cre
 	}
 
 	params := map[int]string{
-{{- range .CreateFixedParams }}
+{{- range .FixedParams }}
 		{{.ID}}: {{FixedParamExpr .}},
 {{- end }}
 {{- range .CreateParams }}
 		{{.ID}}: {{ParamFormat . (printf "data.%s" (ToCamel .Code))}},
 {{- end }}
 	}
+{{- if .Hooks.BeforeCreate }}
+
+	resp.Diagnostics.Append(r.beforeCreate(ctx, &data, params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- end }}
 
 	id, err := resources_core.CreateResource(ctx, r.client, {{.ServiceID}}, resourceName, data.ResumeIfExists.ValueBool(), params)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		data.PendingOperation = types.StringNull()
+		if pendingID, pendingOp := opLog.Pending(); pendingID != "" && pendingOp != "" {
+			id = pendingID
+			data.PendingOperation = types.StringValue(pendingOp)
+		}
+		if id != "" {
+			// Keep the instance (tainted) so that it is not orphaned: refresh
+			// reconciles a pending operation, destroy or replacement removes it.
+			data.ID = types.StringValue(id)
+{{- range .Outputs }}
+			data.{{ToCamel .Name}} = types.{{OutputKind .}}Null()
+{{- end }}
+{{- range .AllParams }}
+{{- if .WriteOnly }}
+			data.{{ToCamel .Code}} = {{ParamType .}}Null()
+{{- end }}
+{{- end }}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
 		return
 	}
 
 	data.ID = types.StringValue(id)
+	data.PendingOperation = types.StringNull()
+{{- if .Outputs }}
+
+	outputs, err := resources_core.ReadOutputs(ctx, r.client, id, opLog.Last("create"), {{ToLowerCamel .Name}}Outputs...)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+	}
+{{- template "assignOutputs" (dict "Var" "data" "Outputs" .Outputs) }}
+{{- end }}
+{{- range .AllParams }}
+{{- if .WriteOnly }}
+	data.{{ToCamel .Code}} = {{ParamType .}}Null()
+{{- end }}
+{{- end }}
+{{- if .Hooks.AfterCreate }}
+	resp.Diagnostics.Append(r.afterCreate(ctx, &data)...)
+{{- end }}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -484,6 +939,25 @@ func (r *{{ToCamel .Name}}Resource) Read(ctx context.Context, req resource.ReadR
 				return
 			}
 		}
+
+		pending, diags := resources_core.ReconcilePendingOperation(ctx, r.client, data.PendingOperation)
+		resp.Diagnostics.Append(diags...)
+		data.PendingOperation = pending
+{{- if .Outputs }}
+
+		outputs, err := resources_core.ReadOutputs(ctx, r.client, data.ID.ValueString(), "", {{ToLowerCamel .Name}}Outputs...)
+		if err != nil {
+			resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+		}
+{{- template "assignOutputs" (dict "Var" "data" "Outputs" .Outputs) }}
+{{- end }}
+{{- if .Hooks.AfterRead }}
+
+		resp.Diagnostics.Append(r.afterRead(ctx, data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+{{- end }}
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
 }
@@ -505,19 +979,71 @@ func (r *{{ToCamel .Name}}Resource) Update(ctx context.Context, req resource.Upd
 		resp.Diagnostics.AddError("Client Error", "missing instance id for modify")
 		return
 	}
+{{- if or .SensitiveParamIDs .ParamRefs .Polling }}
+{{ end }}
+{{- if .SensitiveParamIDs }}
+	ctx = core.WithSensitiveParams(ctx{{range .SensitiveParamIDs}}, {{.}}{{end}})
+{{- end }}
+{{- if .ParamRefs }}
+	ctx = core.WithParamRefs(ctx, map[int]int{ {{- range $i, $r := .ParamRefs}}{{if $i}}, {{end}}{{$r.ID}}: {{$r.ServiceID}}{{end -}} })
+{{- end }}
+{{- if .Polling }}
+	ctx = core.WithPollPolicy(ctx, {{ToLowerCamel .Name}}PollPolicy)
+{{- end }}
 
 	params := map[int]string{
 {{- range .ModifyParams }}
+{{- if not .WriteOnly }}
 		{{.ID}}: {{ParamFormat . (printf "plan.%s" (ToCamel .Code))}},
+{{- end }}
 {{- end }}
 	}
+{{- range .ModifyParams }}
+{{- if .WriteOnly }}
+	// Write-only values are never in plan or state; resubmit only when the version trigger changes.
+	if !plan.{{ToCamel .Code}}Version.Equal(state.{{ToCamel .Code}}Version) {
+		var {{ToLowerCamel .Code}} {{ParamType .}}
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("{{AttrName .}}"), &{{ToLowerCamel .Code}})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		params[{{.ID}}] = {{ParamFormat . (ToLowerCamel .Code)}}
+	}
+{{- end }}
+{{- end }}
+{{- if .Hooks.BeforeUpdate }}
 
+	resp.Diagnostics.Append(r.beforeUpdate(ctx, &plan, &state, params)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+{{- end }}
+
+	ctx, opLog := core.WithOperationLog(ctx)
 	if err := resources_core.UpdateResource(ctx, r.client, instanceID.ValueString(), params); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
+		if _, pendingOp := opLog.Pending(); pendingOp != "" {
+			state.PendingOperation = types.StringValue(pendingOp)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
 		return
 	}
 
 	plan.ID = instanceID
+	plan.PendingOperation = types.StringNull()
+{{- if .Outputs }}
+
+	outputs, err := resources_core.ReadOutputs(ctx, r.client, instanceID.ValueString(), "", {{ToLowerCamel .Name}}Outputs...)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Read Outputs", err.Error())
+	}
+{{- template "assignOutputs" (dict "Var" "plan" "Outputs" .Outputs) }}
+{{- end }}
+{{- range .AllParams }}
+{{- if .WriteOnly }}
+	plan.{{ToCamel .Code}} = {{ParamType .}}Null()
+{{- end }}
+{{- end }}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -530,6 +1056,9 @@ func (r *{{ToCamel .Name}}Resource) Delete(ctx context.Context, req resource.Del
 	if state == nil || state.ID.IsNull() || state.ID.IsUnknown() {
 		return
 	}
+{{- if .Polling }}
+	ctx = core.WithPollPolicy(ctx, {{ToLowerCamel .Name}}PollPolicy)
+{{- end }}
 
 	if err := resources_core.DeleteResource(ctx, r.client, state.ID.ValueString(), state.DeleteMode.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Client Error", err.Error())
@@ -552,95 +1081,40 @@ func (r *{{ToCamel .Name}}Resource) Configure(_ context.Context, req resource.Co
 }
 
 // format helpers live in resources_core/helpers.go
+{{- define "assignOutputs" }}
+{{- $v := .Var }}
+{{- range .Outputs }}
+	{{$v}}.{{ToCamel .Name}} = resources_core.Output{{OutputKind .}}(outputs, "{{.Name}}", {{$v}}.{{ToCamel .Name}})
+{{- end }}
+{{- end }}
 `
 
-type FixedParam struct {
-	ID    int
-	Value string
+type StateUpgrader struct {
+	FromVersion int64
+	Steps       []StateStep
 }
 
-func splitCreateParams(serviceName string, params []Param) ([]Param, []FixedParam) {
-	if len(params) == 0 {
-		return nil, nil
-	}
-	return params, nil
+// StateStep is the migration between two consecutive schema versions.
+type StateStep struct {
+	Renames  []AttrRename
+	Drops    []string
+	Converts []AttrConvert
 }
 
-func isResourceRealmParam(p Param) bool {
-	return strings.EqualFold(strings.TrimSpace(p.Code), "resourceRealm")
+type AttrRename struct {
+	From string
+	To   string
 }
 
-func fixedParamExpr(p FixedParam) string {
-	return fmt.Sprintf("resources_core.FormatString(types.StringValue(%q))", p.Value)
+type AttrConvert struct {
+	Name string
+	Type string
 }
 
-func mergeParams(createParams []Param, modifyParams []Param) []Param {
-	if len(createParams) == 0 && len(modifyParams) == 0 {
-		return nil
-	}
-
-	byCode := make(map[string]Param)
-	order := make([]string, 0, len(createParams)+len(modifyParams))
-
-	for _, p := range createParams {
-		key := strings.ToLower(strings.TrimSpace(p.Code))
-		if key == "" {
-			continue
-		}
-		byCode[key] = p
-		order = append(order, key)
-	}
-
-	for _, p := range modifyParams {
-		key := strings.ToLower(strings.TrimSpace(p.Code))
-		if key == "" {
-			continue
-		}
-		if _, exists := byCode[key]; !exists {
-			p.Required = false
-			byCode[key] = p
-			order = append(order, key)
-		}
-	}
-
-	merged := make([]Param, 0, len(order))
-	seen := make(map[string]struct{}, len(order))
-	for _, key := range order {
-		if _, ok := seen[key]; ok {
-			continue
-		}
-		seen[key] = struct{}{}
-		merged = append(merged, byCode[key])
-	}
-
-	return merged
+func isResourceRealmParam(p Param) bool {
+	return strings.EqualFold(strings.TrimSpace(p.Code), "resourceRealm")
 }
 
-func normalizeModifyParams(createParams []Param, modifyParams []Param) []Param {
-	if len(modifyParams) == 0 {
-		return nil
-	}
-
-	createByCode := make(map[string]Param)
-	for _, p := range createParams {
-		key := strings.ToLower(strings.TrimSpace(p.Code))
-		if key == "" {
-			continue
-		}
-		createByCode[key] = p
-	}
-
-	normalized := make([]Param, 0, len(modifyParams))
-	for _, p := range modifyParams {
-		key := strings.ToLower(strings.TrimSpace(p.Code))
-		if key == "" {
-			continue
-		}
-		if base, ok := createByCode[key]; ok {
-			p.Type = base.Type
-		}
-		normalized = append(normalized, p)
-	}
-
-	return normalized
+func fixedParamExpr(p FixedParam) string {
+	return fmt.Sprintf("resources_core.FormatString(types.StringValue(%q))", p.Value)
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// generated holds rendered files by path relative to the tree root, so that
+// they can be written or compared with the committed ones (-check).
+type generated map[string][]byte
+
+func (g generated) add(path string, b []byte) {
+	g[filepath.ToSlash(path)] = b
+}
+
+func (g generated) paths() []string {
+	paths := make([]string, 0, len(g))
+	for p := range g {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (g generated) write(root string) error {
+	for _, p := range g.paths() {
+		path := filepath.Join(root, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, g[p], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check compares the rendered files with the files under root and returns a
+// description (with a line diff) of every mismatch. Generated resources and
+// data sources in resourcesDir without a YAML source are reported as stale.
+func (g generated) check(root string, resourcesDir string) ([]string, error) {
+	var problems []string
+	for _, p := range g.paths() {
+		onDisk, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		if errors.Is(err, fs.ErrNotExist) {
+			problems = append(problems, fmt.Sprintf("%s: missing", p))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(onDisk, g[p]) {
+			problems = append(problems, fmt.Sprintf("%s: differs\n%s", p, lineDiff(string(onDisk), string(g[p]))))
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, resourcesDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		p := filepath.ToSlash(filepath.Join(resourcesDir, e.Name()))
+		generatedFile := strings.HasSuffix(e.Name(), "_resource.go") || strings.HasSuffix(e.Name(), "_data_source.go")
+		if generatedFile && g[p] == nil {
+			problems = append(problems, fmt.Sprintf("%s: stale (no YAML source)", p))
+		}
+	}
+	return problems, nil
+}
+
+// lineDiff returns the changed lines between want (on disk) and got
+// (rendered), "-" for removed and "+" for added, with line numbers.
+func lineDiff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// Longest common subsequence table, filled from the end.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&sb, "  -%d: %s\n", i+1, a[i])
+			i++
+		default:
+			fmt.Fprintf(&sb, "  +%d: %s\n", j+1, b[j])
+			j++
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
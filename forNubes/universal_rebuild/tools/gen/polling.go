@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// PollExpr holds the Go expressions of a core.PollPolicy literal.
+type PollExpr struct {
+	Initial    string
+	Max        string
+	Multiplier string
+	Timeout    string
+}
+
+func pollExpr(p servicespec.Poll) *PollExpr {
+	e := PollExpr{
+		Initial: durationExpr(p.Initial),
+		Max:     durationExpr(p.Max),
+		Timeout: durationExpr(p.Timeout),
+	}
+	if p.Multiplier != 0 {
+		e.Multiplier = strconv.FormatFloat(p.Multiplier, 'f', -1, 64)
+	}
+	return &e
+}
+
+// durationExpr renders a duration as a Go expression (90s -> 90 * time.Second).
+func durationExpr(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{{time.Hour, "time.Hour"}, {time.Minute, "time.Minute"}, {time.Second, "time.Second"}, {time.Millisecond, "time.Millisecond"}} {
+		if d%unit.d == 0 {
+			if d == unit.d {
+				return unit.name
+			}
+			return fmt.Sprintf("%d * %s", d/unit.d, unit.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"terraform-provider-nubes/internal/servicespec"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The formal schema of a resources_yaml file. `gen lint` validates files
+// against it (schemaChecker below implements the subset of JSON Schema used
+// here) and the generator writes it to resources_yaml/service.schema.json so
+// editors can validate YAML as it is typed.
+
+const schemaFile = "service.schema.json"
+
+type schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Type        []string           `json:"-"`
+	Const       any                `json:"const,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Properties  map[string]*schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties is false (closed object) or a *schema for values.
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	If                   *schema            `json:"if,omitempty"`
+	Then                 *schema            `json:"then,omitempty"`
+	Else                 *schema            `json:"else,omitempty"`
+	Definitions          map[string]*schema `json:"definitions,omitempty"`
+}
+
+func (s *schema) MarshalJSON() ([]byte, error) {
+	type plain schema
+	out := struct {
+		Type any `json:"type,omitempty"`
+		*plain
+	}{plain: (*plain)(s)}
+	switch len(s.Type) {
+	case 0:
+	case 1:
+		out.Type = s.Type[0]
+	default:
+		out.Type = s.Type
+	}
+	return json.Marshal(out)
+}
+
+func typed(t ...string) *schema { return &schema{Type: t} }
+
+func described(s *schema, d string) *schema {
+	s.Description = d
+	return s
+}
+
+func object(required []string, props map[string]*schema) *schema {
+	return &schema{Type: []string{"object"}, Properties: props, Required: required, AdditionalProperties: false}
+}
+
+func arrayOf(items *schema) *schema {
+	return &schema{Type: []string{"array"}, Items: items}
+}
+
+func stringEnum(values ...string) *schema {
+	s := typed("string")
+	for _, v := range values {
+		s.Enum = append(s.Enum, v)
+	}
+	return s
+}
+
+func pattern(re string) *schema {
+	s := typed("string")
+	s.Pattern = re
+	return s
+}
+
+func minimum(t string, min float64) *schema {
+	s := typed(t)
+	s.Minimum = &min
+	return s
+}
+
+func ref(name string) *schema { return &schema{Ref: "#/definitions/" + name} }
+
+const (
+	namePattern     = `^[A-Za-z][A-Za-z0-9_]*$`
+	attrPattern     = `^[a-z][a-z0-9_]*$`
+	durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+)
+
+func lifecycleSchema() *schema {
+	return object(nil, map[string]*schema{
+		"delete_mode_default":      described(stringEnum("state_only", "suspend", "delete"), "Default of delete_mode."),
+		"resume_if_exists_default": described(typed("boolean"), "Default of resume_if_exists."),
+	})
+}
+
+// serviceSchema builds the JSON Schema (draft-07) of a service or composite YAML.
+func serviceSchema() *schema {
+	formats := make([]string, 0, len(formatValidators))
+	for f := range formatValidators {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+
+	param := object([]string{"id", "code", "type"}, map[string]*schema{
+		"id":             described(minimum("integer", 1), "API param ID; unique within the file."),
+		"code":           described(pattern(`^\S+$`), "API param code."),
+		"type":           stringEnum("string", "int", "int64", "number", "bool"),
+		"required":       typed("boolean"),
+		"default":        described(typed("string", "number", "boolean"), "Default value; must parse as the param type."),
+		"sensitive":      typed("boolean"),
+		"write_only":     typed("boolean"),
+		"tf_name":        described(pattern(attrPattern), "Terraform attribute name instead of the snake_case code."),
+		"label":          typed("string"),
+		"description":    typed("string"),
+		"enum":           arrayOf(typed("string", "number")),
+		"min":            typed("integer"),
+		"max":            typed("integer"),
+		"pattern":        described(typed("string"), "Regular expression the value must match."),
+		"format":         stringEnum(formats...),
+		"ref_service_id": described(minimum("integer", 1), "Service whose instance this param references."),
+	})
+	operation := object(nil, map[string]*schema{
+		"params": arrayOf(ref("param")),
+	})
+	output := object([]string{"name", "path"}, map[string]*schema{
+		"name":      pattern(attrPattern),
+		"from":      stringEnum("instance", "operation"),
+		"path":      pattern(`\S`),
+		"type":      stringEnum("string", "int64", "bool"),
+		"sensitive": typed("boolean"),
+	})
+	polling := object(nil, map[string]*schema{
+		"initial":    pattern(durationPattern),
+		"max":        pattern(durationPattern),
+		"timeout":    pattern(durationPattern),
+		"multiplier": minimum("number", 1),
+	})
+	service := object([]string{"name", "service_id"}, map[string]*schema{
+		"kind":       stringEnum("service"),
+		"name":       described(pattern(namePattern), "Resource name; the Terraform type is nubes_<name>."),
+		"service_id": minimum("integer", 1),
+		"create":     ref("operation"),
+		"modify":     ref("operation"),
+		"lifecycle":  ref("lifecycle"),
+		"outputs":    arrayOf(ref("output")),
+		"polling":    ref("polling"),
+		"operations": described(arrayOf(typed("string")),
+			"Catalog operations of the service, recorded by service_params_gen for its diff mode."),
+	})
+	child := object([]string{"name", "service"}, map[string]*schema{
+		"name":    pattern(attrPattern),
+		"service": described(pattern(namePattern), "Name of the child service."),
+		"wiring": described(&schema{Type: []string{"object"}, AdditionalProperties: pattern(attrPattern)},
+			"Param code of this child mapped to the name of an earlier child."),
+	})
+	composite := object([]string{"kind", "name", "children"}, map[string]*schema{
+		"kind":      stringEnum(servicespec.KindComposite),
+		"name":      pattern(namePattern),
+		"children":  arrayOf(ref("child")),
+		"lifecycle": ref("lifecycle"),
+	})
+
+	return &schema{
+		Schema:      "http://json-schema.org/draft-07/schema#",
+		Title:       "Nubes service",
+		Description: "resources_yaml file read by tools/gen. Generated by tools/gen; do not edit.",
+		If:          &schema{Properties: map[string]*schema{"kind": {Const: servicespec.KindComposite}}, Required: []string{"kind"}},
+		Then:        ref("composite"),
+		Else:        ref("service"),
+		Definitions: map[string]*schema{
+			"service":   service,
+			"composite": composite,
+			"param":     param,
+			"operation": operation,
+			"output":    output,
+			"polling":   polling,
+			"lifecycle": lifecycleSchema(),
+			"child":     child,
+		},
+	}
+}
+
+func schemaJSON() ([]byte, error) {
+	b, err := json.MarshalIndent(serviceSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// schemaChecker validates YAML nodes against a schema and records problems
+// with the line of the offending node.
+type schemaChecker struct {
+	root   *schema
+	issues []lintIssue
+	path   string
+}
+
+func (c *schemaChecker) errorf(n *yaml.Node, format string, args ...any) {
+	c.issues = append(c.issues, lintIssue{Path: c.path, Line: n.Line, Column: n.Column, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (c *schemaChecker) matches(n *yaml.Node, s *schema) bool {
+	sub := &schemaChecker{root: c.root, path: c.path}
+	sub.check(n, s, "")
+	return len(sub.issues) == 0
+}
+
+func (c *schemaChecker) check(n *yaml.Node, s *schema, field string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if s.Ref != "" {
+		s = c.root.Definitions[s.Ref[len("#/definitions/"):]]
+	}
+	label := field
+	if label == "" {
+		label = "document"
+	}
+	if s.If != nil {
+		if c.matches(n, s.If) {
+			if s.Then != nil {
+				c.check(n, s.Then, field)
+			}
+		} else if s.Else != nil {
+			c.check(n, s.Else, field)
+		}
+	}
+
+	got := nodeType(n)
+	if len(s.Type) > 0 && !typeAllowed(got, s.Type) {
+		c.errorf(n, "%s: expected %s, got %s", label, joinOr(s.Type), got)
+		return
+	}
+	if s.Const != nil && (n.Kind != yaml.ScalarNode || n.Value != fmt.Sprint(s.Const)) {
+		c.errorf(n, "%s: must be %v", label, s.Const)
+	}
+	if len(s.Enum) > 0 && n.Kind == yaml.ScalarNode {
+		ok := false
+		for _, v := range s.Enum {
+			ok = ok || n.Value == fmt.Sprint(v)
+		}
+		if !ok {
+			c.errorf(n, "%s: %q is not one of %v", label, n.Value, s.Enum)
+		}
+	}
+	if s.Pattern != "" && got == "string" && !regexp.MustCompile(s.Pattern).MatchString(n.Value) {
+		c.errorf(n, "%s: %q does not match %s", label, n.Value, s.Pattern)
+	}
+	if s.Minimum != nil && (got == "integer" || got == "number") {
+		if v, err := strconv.ParseFloat(n.Value, 64); err == nil && v < *s.Minimum {
+			c.errorf(n, "%s: %s is less than %v", label, n.Value, *s.Minimum)
+		}
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			name := join(field, key.Value)
+			if seen[key.Value] {
+				c.errorf(key, "%s: duplicate key", name)
+				continue
+			}
+			seen[key.Value] = true
+			if prop, ok := s.Properties[key.Value]; ok {
+				c.check(value, prop, name)
+				continue
+			}
+			switch extra := s.AdditionalProperties.(type) {
+			case bool:
+				if !extra {
+					c.errorf(key, "%s: unknown key", name)
+				}
+			case *schema:
+				c.check(value, extra, name)
+			}
+		}
+		for _, r := range s.Required {
+			if !seen[r] {
+				c.errorf(n, "%s: missing required key %q", label, r)
+			}
+		}
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				c.check(item, s.Items, fmt.Sprintf("%s[%d]", field, i))
+			}
+		}
+	}
+}
+
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	default:
+		return "string"
+	}
+}
+
+func typeAllowed(got string, want []string) bool {
+	for _, w := range want {
+		if w == got || (w == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func joinOr(types []string) string {
+	s := types[0]
+	for i, t := range types[1:] {
+		if i == len(types)-2 {
+			s += " or " + t
+		} else {
+			s += ", " + t
+		}
+	}
+	return s
+}
+
+func join(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
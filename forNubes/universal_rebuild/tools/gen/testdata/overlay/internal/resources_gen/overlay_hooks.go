@@ -0,0 +1,23 @@
+package resources_gen
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func (r *OverlayResource) beforeCreate(ctx context.Context, data *OverlayModel, params map[int]string) diag.Diagnostics {
+	return nil
+}
+
+func (r *OverlayResource) afterCreate(ctx context.Context, data *OverlayModel) diag.Diagnostics {
+	return nil
+}
+
+func (r *OverlayResource) beforeUpdate(ctx context.Context, plan *OverlayModel, state *OverlayModel, params map[int]string) diag.Diagnostics {
+	return nil
+}
+
+func (r *OverlayResource) afterRead(ctx context.Context, data *OverlayModel) diag.Diagnostics {
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// The resources_core validator each YAML `format` value maps to
+// (servicespec.CheckParam rejects the others).
+var formatValidators = map[string]string{
+	"cron": "resources_core.CronValidator()",
+	"uuid": "resources_core.UUIDValidator()",
+	"cidr": "resources_core.CIDRValidator()",
+	"ip":   "resources_core.IPValidator()",
+}
+
+// paramValidators returns the Go expressions for the attribute's Validators list.
+func paramValidators(p Param) []string {
+	var out []string
+	switch servicespec.CanonicalType(p.Type) {
+	case "int64":
+		if len(p.Enum) > 0 {
+			out = append(out, fmt.Sprintf("int64validator.OneOf(%s)", strings.Join(p.Enum, ", ")))
+		}
+		switch {
+		case p.Min != nil && p.Max != nil:
+			out = append(out, fmt.Sprintf("int64validator.Between(%d, %d)", *p.Min, *p.Max))
+		case p.Min != nil:
+			out = append(out, fmt.Sprintf("int64validator.AtLeast(%d)", *p.Min))
+		case p.Max != nil:
+			out = append(out, fmt.Sprintf("int64validator.AtMost(%d)", *p.Max))
+		}
+	case "string":
+		if len(p.Enum) > 0 {
+			quoted := make([]string, 0, len(p.Enum))
+			for _, v := range p.Enum {
+				quoted = append(quoted, strconv.Quote(v))
+			}
+			out = append(out, fmt.Sprintf("stringvalidator.OneOf(%s)", strings.Join(quoted, ", ")))
+		}
+		if p.Pattern != "" {
+			out = append(out, fmt.Sprintf("stringvalidator.RegexMatches(regexp.MustCompile(%s), %s)",
+				strconv.Quote(p.Pattern), strconv.Quote("must match "+p.Pattern)))
+		}
+		if p.Format != "" {
+			out = append(out, formatValidators[p.Format])
+		}
+	}
+	return out
+}
+
+func validatorKind(p Param) string {
+	switch servicespec.CanonicalType(p.Type) {
+	case "bool":
+		return "Bool"
+	case "int64":
+		return "Int64"
+	default:
+		return "String"
+	}
+}
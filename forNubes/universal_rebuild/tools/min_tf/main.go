@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"terraform-provider-nubes/tools/examplegen"
+)
+
+// min_tf writes runnable examples (examples/resources/nubes_<name>/) for the
+// services in resources_yaml. tools/gen does the same for all services.
+//
+// Usage:
+//
+//	min_tf [-out examples/resources] [service_name...]
+//	min_tf -print <service_name>
+//	min_tf -blocks <service_name>
+//
+// -blocks prints only the variables and resource blocks, for appending to a
+// configuration that already has the provider (scripts/build_provider.sh in
+// universal_rebuild_devops appends them to test_dummy/main.tf).
+func main() {
+	out := flag.String("out", filepath.Join("examples", "resources"), "examples directory")
+	printOnly := flag.Bool("print", false, "print main.tf of one service instead of writing files")
+	blocksOnly := flag.Bool("blocks", false, "print the variables and resource blocks of one service")
+	flag.Parse()
+
+	root, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	services, err := examplegen.Load(filepath.Join(root, "resources_yaml"), filepath.Join(root, "resources_overrides"))
+	if err != nil {
+		panic(err)
+	}
+	all := examplegen.ByName(services)
+
+	selected := services
+	if flag.NArg() > 0 {
+		selected = nil
+		for _, name := range flag.Args() {
+			svc, ok := all[name]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "unknown service %q\n", name)
+				os.Exit(1)
+			}
+			selected = append(selected, svc)
+		}
+	}
+
+	if *printOnly || *blocksOnly {
+		if len(selected) != 1 || flag.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: min_tf -print|-blocks <service_name>")
+			os.Exit(1)
+		}
+		if *blocksOnly {
+			os.Stdout.Write(examplegen.Blocks(selected[0], all))
+			return
+		}
+		os.Stdout.Write(examplegen.Render(selected[0], all)["main.tf"])
+		return
+	}
+
+	if err := examplegen.Write(*out, selected, all); err != nil {
+		panic(err)
+	}
+	for _, svc := range selected {
+		fmt.Printf("written %s\n", filepath.Join(*out, "nubes_"+svc.Name))
+	}
+}
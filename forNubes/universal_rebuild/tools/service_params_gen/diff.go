@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-nubes/internal/servicespec"
+
+	"gopkg.in/yaml.v3"
+)
+
+// diff mode: compares every service YAML with the current catalog and
+// reports the drift; -write merges the catalog into the files (mergeSpec).
+
+// handledOperations are run by the provider itself; any other catalog
+// operation is reported as new until the YAML records it in `operations`.
+var handledOperations = []string{"create", "delete", "modify", "resume", "suspend"}
+
+type DiffReport struct {
+	Services []ServiceDiff `json:"services"`
+}
+
+type ServiceDiff struct {
+	File          string        `json:"file"`
+	Name          string        `json:"name"`
+	ServiceID     int           `json:"service_id"`
+	Error         string        `json:"error,omitempty"`
+	NewOperations []string      `json:"new_operations,omitempty"`
+	Changes       []ParamChange `json:"changes,omitempty"`
+	Written       bool          `json:"written,omitempty"`
+}
+
+// ParamChange is one added, removed or changed param. Field, Old and New are
+// set for "changed" (field is id, type, required, default or ref_service_id).
+type ParamChange struct {
+	Operation string `json:"operation"`
+	Change    string `json:"change"`
+	Code      string `json:"code"`
+	ID        int    `json:"id"`
+	Field     string `json:"field,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+func (d ServiceDiff) drifted() bool {
+	return d.Error != "" || len(d.NewOperations) > 0 || len(d.Changes) > 0
+}
+
+func runDiff(args []string) int {
+	fset := flag.NewFlagSet("diff", flag.ExitOnError)
+	dir := fset.String("dir", "resources_yaml", "directory with service YAML files")
+	format := fset.String("format", "text", "report format: text or json")
+	write := fset.Bool("write", false, "update the YAML files with the catalog params")
+	api := apiFlags(fset)
+	fset.Parse(args)
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "unknown format %q (text or json)\n", *format)
+		return 2
+	}
+
+	client, err := api.client()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	report, err := diffDir(client, *dir, *write)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	} else {
+		printDiff(os.Stdout, report)
+	}
+
+	for _, d := range report.Services {
+		if d.Error != "" || (d.drifted() && !d.Written) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// diffDir fetches the catalog definition of every service YAML in dir.
+// Composites are skipped.
+func diffDir(client *apiClient, dir string, write bool) (DiffReport, error) {
+	var report DiffReport
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return report, err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		local, ok, err := readSpec(path)
+		if err != nil {
+			return report, err
+		}
+		if !ok {
+			continue
+		}
+		d := ServiceDiff{File: filepath.ToSlash(path), Name: local.Name, ServiceID: local.ServiceID}
+		fetched, err := client.fetchSpec(local.ServiceID)
+		if err != nil {
+			d.Error = err.Error()
+			report.Services = append(report.Services, d)
+			continue
+		}
+		d.NewOperations = newOperations(local.Operations, fetched.Operations)
+		d.Changes = append(diffParams("create", local.Create.Params, fetched.Create.Params),
+			diffParams("modify", local.Modify.Params, fetched.Modify.Params)...)
+		if write && d.drifted() {
+			if err := writeSpec(path, mergeSpec(local, fetched)); err != nil {
+				return report, err
+			}
+			d.Written = true
+		}
+		report.Services = append(report.Services, d)
+	}
+	return report, nil
+}
+
+// readSpec reads a service YAML; ok is false for composites.
+func readSpec(path string) (servicespec.Service, bool, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return servicespec.Service{}, false, err
+	}
+	var head struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(b, &head); err != nil {
+		return servicespec.Service{}, false, fmt.Errorf("%s: %w", path, err)
+	}
+	if head.Kind == servicespec.KindComposite {
+		return servicespec.Service{}, false, nil
+	}
+	var spec servicespec.Service
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		return servicespec.Service{}, false, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, true, nil
+}
+
+func writeSpec(path string, spec servicespec.Service) error {
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+func newOperations(known []string, fetched []string) []string {
+	if len(known) == 0 {
+		known = handledOperations
+	}
+	var out []string
+	for _, op := range fetched {
+		if !containsString(known, op) {
+			out = append(out, op)
+		}
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// diffParams matches params by code (case-insensitive) and lists the
+// differences in catalog order, then the removed ones in local order.
+func diffParams(op string, local []servicespec.Param, fetched []servicespec.Param) []ParamChange {
+	byCode := make(map[string]servicespec.Param, len(local))
+	for _, p := range local {
+		byCode[strings.ToLower(p.Code)] = p
+	}
+	var changes []ParamChange
+	seen := map[string]bool{}
+	for _, f := range fetched {
+		key := strings.ToLower(f.Code)
+		seen[key] = true
+		l, ok := byCode[key]
+		if !ok {
+			changes = append(changes, ParamChange{Operation: op, Change: "added", Code: f.Code, ID: f.ID,
+				New: describeParam(f)})
+			continue
+		}
+		changed := func(field, old, new string) {
+			if old != new {
+				changes = append(changes, ParamChange{Operation: op, Change: "changed", Code: f.Code, ID: f.ID,
+					Field: field, Old: old, New: new})
+			}
+		}
+		changed("id", strconv.Itoa(l.ID), strconv.Itoa(f.ID))
+		changed("type", servicespec.CanonicalType(l.Type), servicespec.CanonicalType(f.Type))
+		changed("required", strconv.FormatBool(l.Required), strconv.FormatBool(f.Required))
+		changed("default", l.Default, f.Default)
+		if l.RefServiceID > 0 || f.RefServiceID > 0 {
+			changed("ref_service_id", strconv.Itoa(l.RefServiceID), strconv.Itoa(f.RefServiceID))
+		}
+	}
+	for _, l := range local {
+		if !seen[strings.ToLower(l.Code)] {
+			changes = append(changes, ParamChange{Operation: op, Change: "removed", Code: l.Code, ID: l.ID,
+				Old: describeParam(l)})
+		}
+	}
+	return changes
+}
+
+func describeParam(p servicespec.Param) string {
+	s := servicespec.CanonicalType(p.Type)
+	if p.Required {
+		s += ", required"
+	}
+	if p.Default != "" {
+		s += ", default " + strconv.Quote(p.Default)
+	}
+	return s
+}
+
+func printDiff(w io.Writer, report DiffReport) {
+	clean := 0
+	for _, d := range report.Services {
+		if !d.drifted() {
+			clean++
+			continue
+		}
+		fmt.Fprintf(w, "%s (service %d, %s):\n", d.Name, d.ServiceID, d.File)
+		if d.Error != "" {
+			fmt.Fprintf(w, "  ! fetch failed: %s\n", d.Error)
+		}
+		for _, op := range d.NewOperations {
+			fmt.Fprintf(w, "  + operation %s\n", op)
+		}
+		for _, c := range d.Changes {
+			switch c.Change {
+			case "added":
+				fmt.Fprintf(w, "  + %s %s (id %d, %s)\n", c.Operation, c.Code, c.ID, c.New)
+			case "removed":
+				fmt.Fprintf(w, "  - %s %s (id %d, %s)\n", c.Operation, c.Code, c.ID, c.Old)
+			default:
+				fmt.Fprintf(w, "  ~ %s %s: %s %q -> %q\n", c.Operation, c.Code, c.Field, c.Old, c.New)
+			}
+		}
+		if d.Written {
+			fmt.Fprintln(w, "  written")
+		}
+	}
+	fmt.Fprintf(w, "%d of %d service(s) without drift\n", clean, len(report.Services))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+func TestDiffParams(t *testing.T) {
+	local := []servicespec.Param{
+		{ID: 1, Code: "resourceCpu", Type: "int", Required: true, Default: "500"},
+		{ID: 2, Code: "domain", Type: "string"},
+		{ID: 3, Code: "psqlUid", Type: "string", Required: true, RefServiceID: 5},
+	}
+	for _, tc := range []struct {
+		name    string
+		fetched []servicespec.Param
+		want    []ParamChange
+	}{
+		{
+			name:    "unchanged",
+			fetched: local,
+		},
+		{
+			// Codes match case-insensitively; int and int64 are the same type.
+			name: "same params in another spelling",
+			fetched: []servicespec.Param{
+				{ID: 1, Code: "ResourceCpu", Type: "int64", Required: true, Default: "500"},
+				{ID: 2, Code: "DOMAIN", Type: "string"},
+				{ID: 3, Code: "psqlUid", Type: "string", Required: true, RefServiceID: 5},
+			},
+		},
+		{
+			name: "added and removed",
+			fetched: []servicespec.Param{
+				{ID: 1, Code: "resourceCpu", Type: "int", Required: true, Default: "500"},
+				{ID: 4, Code: "resourceMemory", Type: "int", Required: true, Default: "512"},
+				{ID: 3, Code: "psqlUid", Type: "string", Required: true, RefServiceID: 5},
+			},
+			want: []ParamChange{
+				{Operation: "create", Change: "added", Code: "resourceMemory", ID: 4, New: `int64, required, default "512"`},
+				{Operation: "create", Change: "removed", Code: "domain", ID: 2, Old: "string"},
+			},
+		},
+		{
+			name: "changed fields",
+			fetched: []servicespec.Param{
+				{ID: 11, Code: "resourceCpu", Type: "string", Default: "1000"},
+				{ID: 2, Code: "domain", Type: "string", Required: true},
+				{ID: 3, Code: "psqlUid", Type: "string", Required: true, RefServiceID: 6},
+			},
+			want: []ParamChange{
+				{Operation: "create", Change: "changed", Code: "resourceCpu", ID: 11, Field: "id", Old: "1", New: "11"},
+				{Operation: "create", Change: "changed", Code: "resourceCpu", ID: 11, Field: "type", Old: "int64", New: "string"},
+				{Operation: "create", Change: "changed", Code: "resourceCpu", ID: 11, Field: "required", Old: "true", New: "false"},
+				{Operation: "create", Change: "changed", Code: "resourceCpu", ID: 11, Field: "default", Old: "500", New: "1000"},
+				{Operation: "create", Change: "changed", Code: "domain", ID: 2, Field: "required", Old: "false", New: "true"},
+				{Operation: "create", Change: "changed", Code: "psqlUid", ID: 3, Field: "ref_service_id", Old: "5", New: "6"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := diffParams("create", local, tc.fetched)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v\nwant %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewOperations(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		known   []string
+		fetched []string
+		want    []string
+	}{
+		{
+			name:    "handled operations without a recorded list",
+			fetched: []string{"create", "modify", "delete", "suspend", "resume"},
+		},
+		{
+			name:    "new operation without a recorded list",
+			fetched: []string{"create", "backup", "delete"},
+			want:    []string{"backup"},
+		},
+		{
+			name:    "recorded in the YAML",
+			known:   []string{"create", "delete", "backup"},
+			fetched: []string{"create", "backup", "delete"},
+		},
+		{
+			// A recorded list replaces handledOperations.
+			name:    "not in the recorded list",
+			known:   []string{"create", "delete"},
+			fetched: []string{"create", "modify", "delete", "restore"},
+			want:    []string{"modify", "restore"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := newOperations(tc.known, tc.fetched); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("newOperations(%q, %q) = %q, want %q", tc.known, tc.fetched, got, tc.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,121 +14,158 @@ import (
 	"strconv"
 	"strings"
 
-	"gopkg.in/yaml.v3"
+	"terraform-provider-nubes/internal/servicespec"
 )
 
 // service_params_gen: builds resource YAML from /index.cfm?endpoint=/services/{svcId}
 // and /index.cfm?endpoint=/serviceOperation/{svcOperationId}.
 //
-// Env:
-// - NUBES_API_TOKEN (or read from test_universal/terraform.tfvars)
-// - NUBES_API_ENDPOINT (default: https://deck-api.ngcloud.ru/api/v1/index.cfm)
-// - NUBES_SERVICE_ID (required)
-// - NUBES_SERVICE_NAME (optional override for YAML name)
-// - NUBES_OUTPUT (optional output path)
+//	service_params_gen [--service-id N]... [--all] [--out-dir resources_yaml]
+//	                   [--name NAME] [--endpoint URL] [--token-file FILE]
+//
+// Each service is written to <out-dir>/service_<id>.yaml. An existing file is
+// updated with mergeSpec, so hand-edited fields survive regeneration.
+// The token comes from --token-file or NUBES_API_TOKEN, the endpoint default
+// from NUBES_API_ENDPOINT.
+//
+// `service_params_gen diff [-dir resources_yaml] [-format text|json] [-write]`
+// compares every service YAML with the catalog (see diff.go).
+
+const defaultEndpoint = "https://deck-api.ngcloud.ru/api/v1/index.cfm"
 
 func main() {
-	cfg, err := loadConfig()
-	if err != nil {
-		panic(err)
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:]))
 	}
+	os.Exit(runGenerate(os.Args[1:]))
+}
 
-	client := &apiClient{
-		endpoint: cfg.ApiEndpoint,
-		token:    cfg.ApiToken,
+func runGenerate(args []string) int {
+	fset := flag.NewFlagSet("service_params_gen", flag.ExitOnError)
+	var ids serviceIDs
+	fset.Var(&ids, "service-id", "service ID to fetch (repeatable)")
+	all := fset.Bool("all", false, "fetch every service of the catalog (/services)")
+	outDir := fset.String("out-dir", "resources_yaml", "directory for service_<id>.yaml files")
+	name := fset.String("name", "", "resource name in the YAML (only with a single --service-id)")
+	api := apiFlags(fset)
+	fset.Parse(args)
+
+	if len(ids) == 0 && !*all {
+		fmt.Fprintln(os.Stderr, "--service-id or --all is required")
+		fset.Usage()
+		return 2
 	}
-
-	service, err := client.getService(cfg.ServiceID)
+	if *name != "" && (len(ids) != 1 || *all) {
+		fmt.Fprintln(os.Stderr, "--name needs exactly one --service-id")
+		return 2
+	}
+	client, err := api.client()
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		return 2
 	}
-
-	name := cfg.ServiceName
-	if name == "" {
-		name = service.Name
+	if *all {
+		services, err := client.listServices()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, svc := range services {
+			ids = append(ids, svc.ID)
+		}
 	}
-	if name == "" {
-		name = fmt.Sprintf("service_%d", cfg.ServiceID)
+	sort.Ints(ids)
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
 	}
 
-	ops, err := client.collectOperations(service.Operations)
-	if err != nil {
-		panic(err)
+	failed := 0
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		path, err := generateService(client, id, *outDir, *name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "service %d: %v\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Printf("written %s\n", path)
 	}
-
-	spec := ResourceSpec{
-		Name:      name,
-		ServiceID: cfg.ServiceID,
-		Create:    Operation{Params: ops["create"]},
-		Modify:    Operation{Params: ops["modify"]},
-		Lifecycle: Lifecycle{DeleteModeDefault: "state_only", ResumeIfExistsDefault: true},
+	if failed > 0 {
+		return 1
 	}
+	return 0
+}
 
-	out, err := yaml.Marshal(spec)
+// generateService fetches one service and writes <outDir>/service_<id>.yaml,
+// merging it into the existing file if there is one.
+func generateService(client *apiClient, serviceID int, outDir string, name string) (string, error) {
+	spec, err := client.fetchSpec(serviceID)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
-
-	if cfg.OutputPath != "" {
-		if err := os.WriteFile(cfg.OutputPath, out, 0o644); err != nil {
-			panic(err)
+	path := filepath.Join(outDir, fmt.Sprintf("service_%d.yaml", serviceID))
+	if _, err := os.Stat(path); err == nil {
+		local, ok, err := readSpec(path)
+		if err != nil {
+			return "", err
 		}
-		fmt.Printf("written %s\n", cfg.OutputPath)
-		return
+		if !ok {
+			return "", fmt.Errorf("%s is a composite", path)
+		}
+		spec = mergeSpec(local, spec)
 	}
-
-	fmt.Print(string(out))
+	if name != "" {
+		spec.Name = name
+	}
+	return path, writeSpec(path, spec)
 }
 
-// ===== config =====
+// serviceIDs is a repeatable --service-id flag.
+type serviceIDs []int
 
-type config struct {
-	ApiEndpoint string
-	ApiToken    string
-	ServiceID   int
-	ServiceName string
-	OutputPath  string
+func (s *serviceIDs) String() string {
+	return fmt.Sprint([]int(*s))
 }
 
-func loadConfig() (config, error) {
-	apiEndpoint := getenvDefault("NUBES_API_ENDPOINT", "https://deck-api.ngcloud.ru/api/v1/index.cfm")
-	apiToken := strings.TrimSpace(os.Getenv("NUBES_API_TOKEN"))
-	if apiToken == "" {
-		apiToken = strings.TrimSpace(readTokenFromTfvars("/home/naeel/terra/universal_rebuild/test_universal/terraform.tfvars"))
-	}
-	if apiToken == "" {
-		return config{}, errors.New("NUBES_API_TOKEN is required")
+func (s *serviceIDs) Set(v string) error {
+	id, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid service ID %q", v)
 	}
+	*s = append(*s, id)
+	return nil
+}
 
-	serviceIDStr := strings.TrimSpace(os.Getenv("NUBES_SERVICE_ID"))
-	if serviceIDStr == "" {
-		return config{}, errors.New("NUBES_SERVICE_ID is required")
-	}
-	serviceID, err := strconv.Atoi(serviceIDStr)
-	if err != nil {
-		return config{}, fmt.Errorf("invalid NUBES_SERVICE_ID: %s", serviceIDStr)
-	}
+// ===== config =====
 
-	name := strings.TrimSpace(os.Getenv("NUBES_SERVICE_NAME"))
-	output := strings.TrimSpace(os.Getenv("NUBES_OUTPUT"))
-	if output == "" {
-		output = filepath.Join("/home/naeel/terra/universal_rebuild/resources_yaml", fmt.Sprintf("%s.yaml", nameOrDefault(name, serviceID)))
-	}
+type apiOptions struct {
+	endpoint  string
+	tokenFile string
+}
 
-	return config{
-		ApiEndpoint: apiEndpoint,
-		ApiToken:    apiToken,
-		ServiceID:   serviceID,
-		ServiceName: name,
-		OutputPath:  output,
-	}, nil
+// apiFlags registers the API flags shared by all modes.
+func apiFlags(fset *flag.FlagSet) *apiOptions {
+	o := &apiOptions{}
+	fset.StringVar(&o.endpoint, "endpoint", getenvDefault("NUBES_API_ENDPOINT", defaultEndpoint), "API endpoint")
+	fset.StringVar(&o.tokenFile, "token-file", "", "file with the API token, or a .tfvars with api_token (default: NUBES_API_TOKEN)")
+	return o
 }
 
-func nameOrDefault(name string, serviceID int) string {
-	if strings.TrimSpace(name) != "" {
-		return name
+func (o *apiOptions) client() (*apiClient, error) {
+	token := strings.TrimSpace(os.Getenv("NUBES_API_TOKEN"))
+	if o.tokenFile != "" {
+		var err error
+		if token, err = readTokenFile(o.tokenFile); err != nil {
+			return nil, err
+		}
 	}
-	return fmt.Sprintf("service_%d", serviceID)
+	if token == "" {
+		return nil, errors.New("API token is required: set NUBES_API_TOKEN or --token-file")
+	}
+	return &apiClient{endpoint: o.endpoint, token: token}, nil
 }
 
 func getenvDefault(key, def string) string {
@@ -138,17 +176,18 @@ func getenvDefault(key, def string) string {
 	return val
 }
 
-func readTokenFromTfvars(path string) string {
+// readTokenFile reads a token file: either the bare token or a terraform
+// .tfvars file with `api_token = "..."`.
+func readTokenFile(path string) (string, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
-		return ""
+		return "", err
 	}
 	re := regexp.MustCompile(`(?m)^\s*api_token\s*=\s*"([^"]+)"`)
-	m := re.FindStringSubmatch(string(b))
-	if len(m) < 2 {
-		return ""
+	if m := re.FindStringSubmatch(string(b)); len(m) == 2 {
+		return m[1], nil
 	}
-	return m[1]
+	return strings.TrimSpace(string(b)), nil
 }
 
 // ===== api client =====
@@ -189,6 +228,12 @@ type cfsParam struct {
 	DataType     string      `json:"dataType"`
 	IsRequired   bool        `json:"isRequired"`
 	DefaultValue interface{} `json:"defaultValue"`
+	RefSvcId     *int        `json:"refSvcId"`
+
+	// Human-readable texts; services fill them unevenly.
+	Label       string `json:"label"`
+	Descr       string `json:"descr"`
+	Description string `json:"description"`
 }
 
 func (c *apiClient) getService(serviceID int) (serviceInfo, error) {
@@ -200,6 +245,24 @@ func (c *apiClient) getService(serviceID int) (serviceInfo, error) {
 	return res.Service, nil
 }
 
+// listServices pages through /services (same paging as /instances).
+func (c *apiClient) listServices() ([]serviceInfo, error) {
+	const size = 100
+	var all []serviceInfo
+	for page := 1; ; page++ {
+		var res struct {
+			Results []serviceInfo `json:"results"`
+		}
+		if err := c.getViaProxy(fmt.Sprintf("/services?page=%d&size=%d", page, size), &res); err != nil {
+			return nil, err
+		}
+		all = append(all, res.Results...)
+		if len(res.Results) < size {
+			return all, nil
+		}
+	}
+}
+
 func (c *apiClient) getServiceOperation(svcOperationId int) (serviceOperationInfo, error) {
 	endpoint := fmt.Sprintf("/serviceOperation/%d", svcOperationId)
 	var res serviceOperationResponse
@@ -233,8 +296,50 @@ func (c *apiClient) getViaProxy(endpoint string, out interface{}) error {
 	return dec.Decode(out)
 }
 
-func (c *apiClient) collectOperations(ops []operationInfo) (map[string][]Param, error) {
-	result := map[string][]Param{
+// fetchSpec builds the resource spec of a service from the catalog.
+func (c *apiClient) fetchSpec(serviceID int) (servicespec.Service, error) {
+	service, err := c.getService(serviceID)
+	if err != nil {
+		return servicespec.Service{}, err
+	}
+	name := service.Name
+	if name == "" {
+		name = fmt.Sprintf("service_%d", serviceID)
+	}
+
+	ops, err := c.collectOperations(service.Operations)
+	if err != nil {
+		return servicespec.Service{}, err
+	}
+	spec := servicespec.Service{
+		Name:      name,
+		ServiceID: serviceID,
+		Create:    servicespec.Operation{Params: ops["create"]},
+		Modify:    servicespec.Operation{Params: ops["modify"]},
+		Lifecycle: servicespec.Lifecycle{DeleteModeDefault: "state_only", ResumeIfExistsDefault: true},
+	}
+	for _, op := range service.Operations {
+		if name := strings.ToLower(strings.TrimSpace(op.Operation)); name != "" {
+			spec.Operations = append(spec.Operations, name)
+		}
+	}
+	sort.Strings(spec.Operations)
+	spec.Operations = compactStrings(spec.Operations)
+	return spec, nil
+}
+
+func compactStrings(s []string) []string {
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (c *apiClient) collectOperations(ops []operationInfo) (map[string][]servicespec.Param, error) {
+	result := map[string][]servicespec.Param{
 		"create": {},
 		"modify": {},
 	}
@@ -247,15 +352,24 @@ func (c *apiClient) collectOperations(ops []operationInfo) (map[string][]Param,
 		if err != nil {
 			return nil, err
 		}
-		params := make([]Param, 0, len(opInfo.CfsParams))
+		params := make([]servicespec.Param, 0, len(opInfo.CfsParams))
 		for _, p := range opInfo.CfsParams {
-			params = append(params, Param{
+			param := servicespec.Param{
 				ID:       p.ID,
 				Code:     p.Code,
 				Type:     mapType(p.DataType),
 				Required: p.IsRequired,
 				Default:  formatDefault(p.DefaultValue),
-			})
+				Label:    strings.TrimSpace(p.Label),
+			}
+			param.Description = strings.TrimSpace(p.Descr)
+			if param.Description == "" {
+				param.Description = strings.TrimSpace(p.Description)
+			}
+			if p.RefSvcId != nil && *p.RefSvcId > 0 {
+				param.RefServiceID = *p.RefSvcId
+			}
+			params = append(params, param)
 		}
 		sort.Slice(params, func(i, j int) bool { return params[i].ID < params[j].ID })
 		result[name] = params
@@ -296,30 +410,3 @@ func formatDefault(v interface{}) string {
 		return string(b)
 	}
 }
-
-// ===== spec =====
-
-type ResourceSpec struct {
-	Name      string    `yaml:"name"`
-	ServiceID int       `yaml:"service_id"`
-	Create    Operation `yaml:"create"`
-	Modify    Operation `yaml:"modify"`
-	Lifecycle Lifecycle `yaml:"lifecycle"`
-}
-
-type Operation struct {
-	Params []Param `yaml:"params"`
-}
-
-type Lifecycle struct {
-	DeleteModeDefault     string `yaml:"delete_mode_default"`
-	ResumeIfExistsDefault bool   `yaml:"resume_if_exists_default"`
-}
-
-type Param struct {
-	ID       int    `yaml:"id"`
-	Code     string `yaml:"code"`
-	Type     string `yaml:"type"`
-	Required bool   `yaml:"required"`
-	Default  string `yaml:"default,omitempty"`
-}
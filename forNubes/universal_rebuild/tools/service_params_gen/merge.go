@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+// mergeSpec updates a local spec with the params fetched from the catalog.
+// The catalog owns the param list, IDs, codes, types, required flags and
+// defaults; everything a person may have edited by hand (name, lifecycle,
+// outputs, polling, sensitive/write_only, tf_name, texts, validation and
+// references) keeps its local value when set.
+func mergeSpec(local servicespec.Service, fetched servicespec.Service) servicespec.Service {
+	merged := local
+	merged.ServiceID = fetched.ServiceID
+	if merged.Name == "" {
+		merged.Name = fetched.Name
+	}
+	merged.Operations = fetched.Operations
+	merged.Create.Params = mergeParams(local.Create.Params, fetched.Create.Params)
+	merged.Modify.Params = mergeParams(local.Modify.Params, fetched.Modify.Params)
+	return merged
+}
+
+func mergeParams(local []servicespec.Param, fetched []servicespec.Param) []servicespec.Param {
+	byCode := make(map[string]servicespec.Param, len(local))
+	for _, p := range local {
+		byCode[strings.ToLower(p.Code)] = p
+	}
+	out := make([]servicespec.Param, 0, len(fetched))
+	for _, p := range fetched {
+		if l, ok := byCode[strings.ToLower(p.Code)]; ok {
+			p.Sensitive = l.Sensitive
+			p.WriteOnly = l.WriteOnly
+			p.TFName = l.TFName
+			if l.Label != "" {
+				p.Label = l.Label
+			}
+			if l.Description != "" {
+				p.Description = l.Description
+			}
+			if len(l.Enum) > 0 || l.Min != nil || l.Max != nil || l.Pattern != "" || l.Format != "" {
+				p.Enum, p.Min, p.Max, p.Pattern, p.Format = l.Enum, l.Min, l.Max, l.Pattern, l.Format
+			}
+			if l.RefServiceID > 0 {
+				p.RefServiceID = l.RefServiceID
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"terraform-provider-nubes/internal/servicespec"
+)
+
+func int64p(v int64) *int64 { return &v }
+
+func TestMergeParams(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		local   []servicespec.Param
+		fetched []servicespec.Param
+		want    []servicespec.Param
+	}{
+		{
+			name:    "new service",
+			fetched: []servicespec.Param{{ID: 1, Code: "domain", Type: "string", Required: true, Label: "Domain"}},
+			want:    []servicespec.Param{{ID: 1, Code: "domain", Type: "string", Required: true, Label: "Domain"}},
+		},
+		{
+			// The catalog owns id, code, type, required and default.
+			name:    "catalog fields",
+			local:   []servicespec.Param{{ID: 1, Code: "resourceCpu", Type: "string", Default: "500"}},
+			fetched: []servicespec.Param{{ID: 7, Code: "ResourceCpu", Type: "int", Required: true, Default: "1000"}},
+			want:    []servicespec.Param{{ID: 7, Code: "ResourceCpu", Type: "int", Required: true, Default: "1000"}},
+		},
+		{
+			name: "hand-edited fields",
+			local: []servicespec.Param{{
+				ID: 1, Code: "adminPassword", Type: "string",
+				Sensitive: true, WriteOnly: true, TFName: "password",
+				Label: "Password", Description: "Admin password.",
+				Min: int64p(8), Pattern: "^\\S+$", RefServiceID: 5,
+			}},
+			fetched: []servicespec.Param{{
+				ID: 1, Code: "adminPassword", Type: "string", Required: true,
+				Label: "pwd", Description: "descr", Enum: []string{"a"}, RefServiceID: 6,
+			}},
+			want: []servicespec.Param{{
+				ID: 1, Code: "adminPassword", Type: "string", Required: true,
+				Sensitive: true, WriteOnly: true, TFName: "password",
+				Label: "Password", Description: "Admin password.",
+				Min: int64p(8), Pattern: "^\\S+$", RefServiceID: 5,
+			}},
+		},
+		{
+			// Empty local texts, validation and references take the catalog's.
+			name:    "unset local fields",
+			local:   []servicespec.Param{{ID: 1, Code: "vmCpu", Type: "int", Sensitive: true}},
+			fetched: []servicespec.Param{{ID: 1, Code: "vmCpu", Type: "int", Label: "CPU", Enum: []string{"1", "2"}, RefServiceID: 6}},
+			want:    []servicespec.Param{{ID: 1, Code: "vmCpu", Type: "int", Sensitive: true, Label: "CPU", Enum: []string{"1", "2"}, RefServiceID: 6}},
+		},
+		{
+			name: "added and removed follow the catalog order",
+			local: []servicespec.Param{
+				{ID: 1, Code: "domain", Type: "string", TFName: "host"},
+				{ID: 2, Code: "legacy", Type: "string"},
+			},
+			fetched: []servicespec.Param{
+				{ID: 3, Code: "resourceMemory", Type: "int"},
+				{ID: 1, Code: "domain", Type: "string"},
+			},
+			want: []servicespec.Param{
+				{ID: 3, Code: "resourceMemory", Type: "int"},
+				{ID: 1, Code: "domain", Type: "string", TFName: "host"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeParams(tc.local, tc.fetched)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v\nwant %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeSpecKeepsLocalSections(t *testing.T) {
+	local := servicespec.Service{
+		Name:       "gitea",
+		ServiceID:  99,
+		Create:     servicespec.Operation{Params: []servicespec.Param{{ID: 1, Code: "domain", Type: "string", TFName: "host"}}},
+		Lifecycle:  servicespec.Lifecycle{DeleteModeDefault: "suspend", ResumeIfExistsDefault: true},
+		Operations: []string{"create", "delete"},
+		Outputs:    []servicespec.Output{{Name: "url", Path: "svp.url"}},
+		Polling:    &servicespec.PollSpec{},
+	}
+	fetched := servicespec.Service{
+		Name:       "Gitea",
+		ServiceID:  99,
+		Create:     servicespec.Operation{Params: []servicespec.Param{{ID: 1, Code: "domain", Type: "string", Required: true}}},
+		Modify:     servicespec.Operation{Params: []servicespec.Param{{ID: 2, Code: "resourceCpu", Type: "int"}}},
+		Lifecycle:  servicespec.Lifecycle{DeleteModeDefault: "delete"},
+		Operations: []string{"create", "delete", "backup"},
+	}
+
+	want := local
+	want.Operations = fetched.Operations
+	want.Create.Params = []servicespec.Param{{ID: 1, Code: "domain", Type: "string", Required: true, TFName: "host"}}
+	want.Modify.Params = fetched.Modify.Params
+	if got := mergeSpec(local, fetched); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+
+	// A new file takes the catalog name.
+	if got := mergeSpec(servicespec.Service{}, fetched); got.Name != "Gitea" || got.ServiceID != 99 {
+		t.Errorf("got name %q, service_id %d", got.Name, got.ServiceID)
+	}
+}
@@ -22,5 +22,12 @@ go run ./tools/gen/main.go
 
 go build -o "$ROOT_DIR/terraform-provider-nubes" .
 
-# Append minimal resource block to test_dummy/main.tf
-go run ./tools/min_tf/main.go "$SERVICE_NAME" >> "$ROOT_DIR/test_dummy/main.tf"
+# Append the resource block (with the resources it references and their
+# variables) to test_dummy/main.tf. The generator lives in forNubes/universal_rebuild.
+REBUILD_DIR="$(cd "$ROOT_DIR/../forNubes/universal_rebuild" && pwd)"
+if grep -q "^resource \"nubes_${SERVICE_NAME}\" " "$ROOT_DIR/test_dummy/main.tf"; then
+  echo "nubes_${SERVICE_NAME} is already in test_dummy/main.tf" >&2
+else
+  BLOCKS="$(cd "$REBUILD_DIR" && go run ./tools/min_tf -blocks "$SERVICE_NAME")"
+  printf '\n%s\n' "$BLOCKS" >> "$ROOT_DIR/test_dummy/main.tf"
+fi